@@ -11,6 +11,7 @@ import (
 	"github.com/iamolegga/goqite"
 	"github.com/iamolegga/goqite/jobs"
 
+	"github.com/grantsy/grantsy/internal/audit"
 	"github.com/grantsy/grantsy/internal/auth"
 	"github.com/grantsy/grantsy/internal/entitlements"
 	"github.com/grantsy/grantsy/internal/httptools"
@@ -22,6 +23,7 @@ import (
 	"github.com/grantsy/grantsy/internal/infra/tracing"
 	_ "github.com/grantsy/grantsy/internal/infra/validation"
 	"github.com/grantsy/grantsy/internal/openapi"
+	"github.com/grantsy/grantsy/internal/ratelimit"
 	"github.com/grantsy/grantsy/internal/subscriptions"
 	"github.com/grantsy/grantsy/internal/users"
 	"github.com/grantsy/grantsy/internal/webhooks"
@@ -68,6 +70,8 @@ func main() {
 	// Services
 	//
 
+	// MaxReceive and Timeout only cover jobs that crash or time out: outgoing
+	// webhooks schedule their own retries with per-endpoint backoff.
 	queueOpts := goqite.NewOpts{
 		DB:         database.DB,
 		Name:       "webhooks",
@@ -90,14 +94,40 @@ func main() {
 
 	// Create services (order matters for DI chain)
 	subsRepo := subscriptions.NewRepo(database)
+	webhookRepo := webhooks.NewRepo(database)
+	keyring := auth.NewKeyring(cfg.Auth, auth.NewRepo(database))
+	auditLog := audit.NewLog(audit.NewRepo(database))
+	if err := auditLog.RecordConfigLoaded(
+		gracefulshutdown.GetServerBaseContext(),
+		*configPath,
+		&cfg.Entitlements,
+		cfg.Providers.LemonSqueezy.Products,
+	); err != nil {
+		slog.Error("failed to record config load", "error", err)
+		os.Exit(1)
+	}
+	var userTokens auth.UserTokenVerifier
+	if cfg.Auth.UserTokens != nil {
+		userTokens, err = auth.NewUserTokens(cfg.Auth.UserTokens)
+		if err != nil {
+			slog.Error("failed to configure user tokens", "error", err)
+			os.Exit(1)
+		}
+	}
+	var bearerTokens auth.TokenAuthenticator
+	if cfg.Auth.Bearer != nil {
+		bearerTokens, err = auth.NewBearerTokens(gracefulshutdown.GetServerBaseContext(), cfg.Auth.Bearer)
+		if err != nil {
+			slog.Error("failed to configure bearer tokens", "error", err)
+			os.Exit(1)
+		}
+	}
 
-	webhookService := webhooks.NewService(webhookQueue, cfg.Webhooks.Endpoints)
+	webhookEndpoints := webhooks.NewEndpointRegistry(cfg.Webhooks.Endpoints, webhookRepo)
+	webhookService := webhooks.NewService(webhookQueue, webhookEndpoints, webhookRepo, webhookRepo)
+	webhooks.UpdateDeadLetterDepth(gracefulshutdown.GetServerBaseContext(), webhookRepo)
 
-	lsProvider := subscriptions.NewLemonSqueezyProvider(
-		cfg.Providers.LemonSqueezy.APIKey,
-		cfg.Providers.LemonSqueezy.Webhook.Secret,
-		cfg.Providers.LemonSqueezy.Products,
-	)
+	lsProvider := subscriptions.NewLemonSqueezyProvider(cfg.Providers.LemonSqueezy, subsRepo)
 
 	var syncPeriod time.Duration
 	if cfg.SyncPeriod != "" {
@@ -109,19 +139,39 @@ func main() {
 	}
 	go lsProvider.Start(gracefulshutdown.GetServerBaseContext(), syncPeriod)
 
+	planHistory := entitlements.NewRepo(database)
 	entService, err := entitlements.NewService(
 		&cfg.Entitlements,
 		cfg.Providers.LemonSqueezy.Products,
 		subsRepo,
 		webhookService,
+		auditLog,
+		planHistory,
 	)
 	if err != nil {
 		slog.Error("failed to create entitlements service", "error", err)
 		os.Exit(1)
 	}
 
-	// Start webhook worker
-	webhookWorker := webhooks.NewWorker(cfg.Webhooks.Endpoints)
+	webhookRoute := subscriptions.NewRouteWebhook(
+		lsProvider,
+		subscriptions.NewCachedPriceFetcher(lsProvider, subsRepo),
+		lsProvider,
+		subsRepo,
+		subsRepo,
+		subsRepo,
+		subscriptions.NewWebhookQueue(webhookQueue),
+		database,
+		entService,
+	)
+
+	// Start webhook workers
+	webhookWorker := webhooks.NewWorker(
+		webhookEndpoints,
+		webhookRepo,
+		webhookService,
+		webhookRepo,
+	)
 	runner := jobs.NewRunner(jobs.NewRunnerOpts{
 		Limit:        10,
 		PollInterval: time.Second,
@@ -129,8 +179,23 @@ func main() {
 		Log:          slog.Default(),
 	})
 	runner.Register("webhooks", webhookWorker.Handle)
+	runner.Register(subscriptions.IncomingWebhookJob, webhookRoute.HandleJob)
 	go runner.Start(gracefulshutdown.GetServerBaseContext())
 
+	var limiter *ratelimit.Limiter
+	if cfg.RateLimit.Enable {
+		var store ratelimit.Store = ratelimit.NewMemoryStore()
+		if cfg.RateLimit.Shared {
+			store = ratelimit.NewRepo(database)
+		}
+		limiter, err = ratelimit.NewLimiter(cfg.RateLimit, store)
+		if err != nil {
+			slog.Error("failed to configure rate limits", "error", err)
+			os.Exit(1)
+		}
+		go limiter.Start(gracefulshutdown.GetServerBaseContext())
+	}
+
 	//
 	// Routes
 	//
@@ -143,13 +208,36 @@ func main() {
 		entitlements.NewRouteFeature(entService),
 		entitlements.NewRoutePlans(entService, lsProvider),
 		entitlements.NewRoutePlan(entService, lsProvider),
+		users.NewRouteUsers(entService, subsRepo),
 		users.NewRouteUser(entService, subsRepo),
-		subscriptions.NewRouteWebhook(
-			lsProvider,
-			lsProvider,
-			subsRepo,
-			entService,
-		),
+		users.NewRouteUserPortal(subsRepo, lsProvider),
+		users.NewRouteUserSubscriptionCancel(subsRepo, lsProvider),
+		users.NewRouteUserSubscriptionResume(subsRepo, lsProvider),
+		users.NewRouteUserSubscriptionPause(subsRepo, lsProvider),
+		users.NewRouteUserSubscriptionChange(subsRepo, lsProvider, lsProvider),
+		users.NewRouteUserPlanChangePreview(entService, subsRepo, lsProvider),
+		users.NewRouteUserHistory(entService, planHistory),
+		webhookRoute,
+		subscriptions.NewRouteWebhookEvents(subsRepo),
+		subscriptions.NewRouteWebhookEvent(subsRepo),
+		subscriptions.NewRouteWebhookEventReplay(subsRepo, webhookRoute),
+		webhooks.NewRouteDeliveries(webhookRepo),
+		webhooks.NewRouteDelivery(webhookRepo),
+		webhooks.NewRouteDeliveryRetry(webhookRepo, webhookService),
+		webhooks.NewRouteDeadLetters(webhookRepo),
+		webhooks.NewRouteDeadLetterRequeue(webhookRepo, webhookService),
+		webhooks.NewRouteEndpoints(webhookEndpoints),
+		webhooks.NewRouteEndpoint(webhookEndpoints),
+		webhooks.NewRouteEndpointCreate(webhookEndpoints),
+		webhooks.NewRouteEndpointUpdate(webhookEndpoints),
+		webhooks.NewRouteEndpointDelete(webhookEndpoints),
+		webhooks.NewRouteEndpointRotateSecret(webhookEndpoints),
+		subscriptions.NewRouteCheckout(lsProvider, lsProvider),
+		auth.NewRouteAPIKeys(keyring),
+		auth.NewRouteAPIKeyCreate(keyring),
+		auth.NewRouteAPIKeyRevoke(keyring),
+		auth.NewRouteAPIKeyRotate(keyring),
+		audit.NewRouteAudit(auditLog),
 	}
 	mux := http.NewServeMux()
 	hideRouteMiddleware := httptools.Hidden(
@@ -181,19 +269,44 @@ func main() {
 	// Middlewares
 	//
 
+	// shared by request logs and audit entries
+	redactor := logger.NewRedactor(
+		cfg.Log.Redact.Headers,
+		cfg.Log.Redact.QueryParams,
+		cfg.Log.Redact.JSONFields,
+	)
+
 	// skip tracing, logging and metrics for unnecessary endpoints
-	// skip auth for healthz, metrics, and webhook (webhook has its own signature validation)
+	// skip auth and rate limits for healthz, metrics, and webhook (webhook has its own signature validation)
 	middlewares := []func(http.Handler) http.Handler{
 		httptools.Skip(tracing.Middleware, healthcheckProbePath, cfg.Metrics.Path),
-		httptools.Skip(logger.Middleware, healthcheckProbePath, cfg.Metrics.Path),
+		httptools.Skip(
+			logger.Middleware(redactor),
+			healthcheckProbePath,
+			cfg.Metrics.Path,
+		),
 		logger.RecoveryMiddleware,
+	}
+	if limiter != nil {
+		middlewares = append(
+			middlewares,
+			httptools.Skip(
+				limiter.IPMiddleware,
+				healthcheckProbePath,
+				cfg.Metrics.Path,
+				"/v1/webhook/*",
+			),
+		)
+	}
+	middlewares = append(
+		middlewares,
 		httptools.Skip(
-			auth.Middleware(cfg.Auth.APIKey),
+			auth.Middleware(keyring, userTokens, bearerTokens),
 			healthcheckProbePath,
 			cfg.Metrics.Path,
 			"/v1/webhook/*",
 		),
-	}
+	)
 	if cfg.Metrics.Enable {
 		middlewares = append(
 			middlewares,
@@ -204,6 +317,12 @@ func main() {
 			),
 		)
 	}
+	// after metrics, so rejected requests are counted with their key
+	if limiter != nil {
+		middlewares = append(middlewares, limiter.KeyMiddleware)
+	}
+	// last, so the route pattern matched by the mux is visible
+	middlewares = append(middlewares, audit.Middleware(auditLog, redactor))
 
 	//
 	// Start server
@@ -5,9 +5,13 @@ import (
 	"log"
 	"os"
 
+	"github.com/grantsy/grantsy/internal/audit"
+	"github.com/grantsy/grantsy/internal/auth"
 	"github.com/grantsy/grantsy/internal/entitlements"
 	"github.com/grantsy/grantsy/internal/openapi"
+	"github.com/grantsy/grantsy/internal/subscriptions"
 	"github.com/grantsy/grantsy/internal/users"
+	"github.com/grantsy/grantsy/internal/webhooks"
 )
 
 func main() {
@@ -19,7 +23,35 @@ func main() {
 	entitlements.RegisterFeatureSchema(reflector)
 	entitlements.RegisterPlansSchema(reflector)
 	entitlements.RegisterPlanSchema(reflector)
+	users.RegisterUsersSchema(reflector)
 	users.RegisterUserSchema(reflector)
+	users.RegisterUserPortalSchema(reflector)
+	users.RegisterUserSubscriptionCancelSchema(reflector)
+	users.RegisterUserSubscriptionResumeSchema(reflector)
+	users.RegisterUserSubscriptionPauseSchema(reflector)
+	users.RegisterUserSubscriptionChangeSchema(reflector)
+	users.RegisterUserPlanChangePreviewSchema(reflector)
+	users.RegisterUserHistorySchema(reflector)
+	subscriptions.RegisterWebhookEventsSchema(reflector)
+	subscriptions.RegisterWebhookEventSchema(reflector)
+	subscriptions.RegisterWebhookEventReplaySchema(reflector)
+	subscriptions.RegisterCheckoutSchema(reflector)
+	webhooks.RegisterDeliveriesSchema(reflector)
+	webhooks.RegisterDeliverySchema(reflector)
+	webhooks.RegisterDeliveryRetrySchema(reflector)
+	webhooks.RegisterDeadLettersSchema(reflector)
+	webhooks.RegisterDeadLetterRequeueSchema(reflector)
+	webhooks.RegisterEndpointsSchema(reflector)
+	webhooks.RegisterEndpointSchema(reflector)
+	webhooks.RegisterEndpointCreateSchema(reflector)
+	webhooks.RegisterEndpointUpdateSchema(reflector)
+	webhooks.RegisterEndpointDeleteSchema(reflector)
+	webhooks.RegisterEndpointRotateSecretSchema(reflector)
+	auth.RegisterAPIKeysSchema(reflector)
+	auth.RegisterAPIKeyCreateSchema(reflector)
+	auth.RegisterAPIKeyRevokeSchema(reflector)
+	auth.RegisterAPIKeyRotateSchema(reflector)
+	audit.RegisterAuditSchema(reflector)
 	// webhook intentionally excluded from OpenAPI documentation
 
 	data, err := json.MarshalIndent(reflector.Spec, "", "  ")
@@ -0,0 +1,147 @@
+package audit
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/grantsy/grantsy/internal/auth"
+	"github.com/grantsy/grantsy/internal/infra/config"
+	"github.com/grantsy/grantsy/internal/infra/tracing"
+)
+
+// Actions recorded besides admin requests, which are recorded with their
+// route pattern, e.g. "POST /v1/api-keys".
+const (
+	ActionPlanAssigned    = "plan.assigned"
+	ActionPlanDeactivated = "plan.deactivated"
+	ActionConfigLoaded    = "config.loaded"
+)
+
+// Kinds of actors an entry is attributed to.
+const (
+	ActorAPIKey   = "api_key"
+	ActorProvider = "provider"
+	ActorSystem   = "system"
+)
+
+// Entry is a record in the audit log. Entries are never updated or deleted.
+type Entry struct {
+	ID         string
+	Action     string
+	ActorType  string
+	Actor      string // API key or bearer token name, or provider name
+	ActorID    string // Stored API key ID, empty for other actors
+	UserID     string
+	PlanBefore string
+	PlanAfter  string
+	RequestID  string
+	Details    map[string]any
+	CreatedAt  int64
+}
+
+// Filter narrows the entries returned by ListEntries.
+type Filter struct {
+	UserID  string
+	Actor   string
+	ActorID string
+	Action  string
+	Since   int64
+	Until   int64
+	Cursor  string
+	Limit   int
+}
+
+// Store persists audit entries.
+type Store interface {
+	InsertEntry(ctx context.Context, e *Entry) error
+	ListEntries(ctx context.Context, filter Filter) ([]Entry, error)
+}
+
+// Log records entries attributed to the actor and request in their context.
+type Log struct {
+	store Store
+}
+
+// NewLog creates an audit log writing to store.
+func NewLog(store Store) *Log {
+	return &Log{store: store}
+}
+
+// Record stores e, filling its ID, time, actor and request ID. When called
+// within a transaction, the entry is only kept if the transaction commits.
+func (l *Log) Record(ctx context.Context, e Entry) error {
+	e.ID = uuid.Must(uuid.NewV7()).String()
+	e.CreatedAt = time.Now().Unix()
+	e.ActorType, e.Actor, e.ActorID = actorFromContext(ctx)
+	e.RequestID = tracing.GetRequestID(ctx)
+	return l.store.InsertEntry(ctx, &e)
+}
+
+// RecordPlanChange records a user moving from prevPlan to activePlan, either
+// by an active subscription or by its deactivation.
+// Implements entitlements.PlanChangeRecorder interface.
+func (l *Log) RecordPlanChange(ctx context.Context, userID, prevPlan, activePlan string, active bool) error {
+	action := ActionPlanAssigned
+	if !active {
+		action = ActionPlanDeactivated
+	}
+	return l.Record(ctx, Entry{
+		Action:     action,
+		UserID:     userID,
+		PlanBefore: prevPlan,
+		PlanAfter:  activePlan,
+	})
+}
+
+// RecordConfigLoaded records the plans and product mappings in effect after
+// the config file at path was loaded.
+func (l *Log) RecordConfigLoaded(
+	ctx context.Context,
+	path string,
+	ent *config.EntitlementsConfig,
+	products []config.ProductMapping,
+) error {
+	plans := make(map[string][]string, len(ent.Plans))
+	for _, plan := range ent.Plans {
+		plans[plan.ID] = plan.Features
+	}
+	productPlans := make(map[string]string, len(products))
+	for _, p := range products {
+		productPlans[strconv.Itoa(p.ProductID)] = p.PlanID
+	}
+	return l.Record(ctx, Entry{
+		Action: ActionConfigLoaded,
+		Details: map[string]any{
+			"config":       path,
+			"default_plan": ent.DefaultPlan,
+			"plans":        plans,
+			"products":     productPlans,
+		},
+	})
+}
+
+// ListEntries returns entries matching filter, newest first.
+func (l *Log) ListEntries(ctx context.Context, filter Filter) ([]Entry, error) {
+	return l.store.ListEntries(ctx, filter)
+}
+
+type providerContextKey struct{}
+
+// WithProvider returns a copy of ctx attributing entries recorded without an
+// authenticated API key to the named billing provider's webhooks.
+func WithProvider(ctx context.Context, provider string) context.Context {
+	return context.WithValue(ctx, providerContextKey{}, provider)
+}
+
+func actorFromContext(ctx context.Context) (actorType, actor, actorID string) {
+	if key := auth.KeyFromContext(ctx); key != nil {
+		return ActorAPIKey, key.Name, key.ID
+	}
+	if provider, ok := ctx.Value(providerContextKey{}).(string); ok {
+		return ActorProvider, provider, ""
+	}
+	return ActorSystem, "", ""
+}
@@ -0,0 +1,132 @@
+package audit_test
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grantsy/grantsy/internal/audit"
+	"github.com/grantsy/grantsy/internal/auth"
+	"github.com/grantsy/grantsy/internal/infra/config"
+	"github.com/grantsy/grantsy/internal/infra/db"
+	"github.com/grantsy/grantsy/internal/infra/tracing"
+)
+
+func newSQLiteDB(t *testing.T) *db.DB {
+	t.Helper()
+
+	dsn := filepath.Join(t.TempDir(), "test.db")
+	require.NoError(t, db.Migrate("sqlite", dsn, ""), "sqlite migration failed")
+
+	database, err := db.New("sqlite", dsn, "")
+	require.NoError(t, err, "sqlite connection failed")
+	t.Cleanup(func() { database.Close() })
+
+	return database
+}
+
+func TestLog_RecordPlanChange_Actors(t *testing.T) {
+	log := audit.NewLog(audit.NewRepo(newSQLiteDB(t)))
+
+	keyCtx := auth.WithKey(tracing.WithRequestID(context.Background(), "req-1"), &auth.Key{ID: "key-1", Name: "admin"})
+	require.NoError(t, log.RecordPlanChange(keyCtx, "user1", "free", "enterprise", true))
+
+	providerCtx := audit.WithProvider(tracing.WithRequestID(context.Background(), "req-2"), "lemonsqueezy")
+	require.NoError(t, log.RecordPlanChange(providerCtx, "user1", "enterprise", "free", false))
+
+	entries, err := log.ListEntries(context.Background(), audit.Filter{UserID: "user1", Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	assert.Equal(t, audit.ActionPlanDeactivated, entries[0].Action)
+	assert.Equal(t, audit.ActorProvider, entries[0].ActorType)
+	assert.Equal(t, "lemonsqueezy", entries[0].Actor)
+	assert.Empty(t, entries[0].ActorID)
+	assert.Equal(t, "req-2", entries[0].RequestID)
+
+	assert.Equal(t, audit.ActionPlanAssigned, entries[1].Action)
+	assert.Equal(t, audit.ActorAPIKey, entries[1].ActorType)
+	assert.Equal(t, "admin", entries[1].Actor)
+	assert.Equal(t, "key-1", entries[1].ActorID)
+	assert.Equal(t, "free", entries[1].PlanBefore)
+	assert.Equal(t, "enterprise", entries[1].PlanAfter)
+	assert.Equal(t, "req-1", entries[1].RequestID)
+}
+
+func TestLog_RecordConfigLoaded(t *testing.T) {
+	log := audit.NewLog(audit.NewRepo(newSQLiteDB(t)))
+
+	ent := &config.EntitlementsConfig{
+		DefaultPlan: "free",
+		Plans:       []config.PlanConfig{{ID: "free", Features: []string{"dashboard"}}},
+	}
+	products := []config.ProductMapping{{ProductID: 100, PlanID: "pro"}}
+	require.NoError(t, log.RecordConfigLoaded(context.Background(), "config.yaml", ent, products))
+
+	entries, err := log.ListEntries(context.Background(), audit.Filter{Action: audit.ActionConfigLoaded, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, audit.ActorSystem, entries[0].ActorType)
+	assert.Equal(t, "free", entries[0].Details["default_plan"])
+	assert.Equal(t, map[string]any{"100": "pro"}, entries[0].Details["products"])
+}
+
+func TestRepo_ListEntries_Filters(t *testing.T) {
+	repo := audit.NewRepo(newSQLiteDB(t))
+	ctx := context.Background()
+
+	for i, e := range []audit.Entry{
+		{Action: audit.ActionPlanAssigned, Actor: "a", UserID: "u1", CreatedAt: 100},
+		{Action: audit.ActionPlanAssigned, Actor: "b", UserID: "u2", CreatedAt: 200},
+		{Action: "POST /v1/api-keys", Actor: "a", CreatedAt: 300},
+	} {
+		e.ID = fmt.Sprintf("%02d", i+1)
+		e.ActorType = audit.ActorAPIKey
+		require.NoError(t, repo.InsertEntry(ctx, &e))
+	}
+
+	tests := []struct {
+		name   string
+		filter audit.Filter
+		want   []string
+	}{
+		{"all", audit.Filter{}, []string{"03", "02", "01"}},
+		{"user", audit.Filter{UserID: "u2"}, []string{"02"}},
+		{"actor", audit.Filter{Actor: "a"}, []string{"03", "01"}},
+		{"action", audit.Filter{Action: audit.ActionPlanAssigned}, []string{"02", "01"}},
+		{"time range", audit.Filter{Since: 200, Until: 300}, []string{"02"}},
+		{"cursor", audit.Filter{Cursor: "03", Limit: 1}, []string{"02"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.filter.Limit == 0 {
+				tt.filter.Limit = 10
+			}
+			entries, err := repo.ListEntries(ctx, tt.filter)
+			require.NoError(t, err)
+
+			var ids []string
+			for _, e := range entries {
+				ids = append(ids, e.ID)
+			}
+			assert.Equal(t, tt.want, ids)
+		})
+	}
+}
+
+func TestRepo_AppendOnly(t *testing.T) {
+	database := newSQLiteDB(t)
+	repo := audit.NewRepo(database)
+	ctx := context.Background()
+
+	require.NoError(t, repo.InsertEntry(ctx, &audit.Entry{ID: "01", Action: audit.ActionPlanAssigned, ActorType: audit.ActorSystem}))
+
+	_, err := database.ExecContext(ctx, `UPDATE audit_log SET actor = 'someone'`)
+	assert.ErrorContains(t, err, "append-only")
+	_, err = database.ExecContext(ctx, `DELETE FROM audit_log`)
+	assert.ErrorContains(t, err, "append-only")
+}
@@ -0,0 +1,118 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/zenazn/goji/web/mutil"
+
+	"github.com/grantsy/grantsy/internal/auth"
+	"github.com/grantsy/grantsy/internal/infra/logger"
+)
+
+// Recorder records audit entries.
+type Recorder interface {
+	Record(ctx context.Context, e Entry) error
+}
+
+// maxRecordedBody is the largest request body recorded with an entry. Admin
+// requests are small; larger bodies are left out rather than truncated.
+const maxRecordedBody = 64 << 10
+
+// Middleware records every successful request that changes state and was
+// made with an API key, with the route pattern as the action and the request
+// body, with sensitive fields replaced by redactor, in the details. It must
+// run after auth.Middleware, directly around the mux, to see the matched
+// route.
+func Middleware(recorder Recorder, redactor *logger.Redactor) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+				next.ServeHTTP(w, r)
+				return
+			}
+			if auth.KeyFromContext(r.Context()) == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			body := peekBody(r)
+			lw := mutil.WrapWriter(w)
+			next.ServeHTTP(lw, r)
+
+			if r.Pattern == "" || lw.Status() >= http.StatusBadRequest {
+				return
+			}
+
+			e := Entry{
+				Action:  r.Pattern,
+				UserID:  r.PathValue("user_id"),
+				Details: map[string]any{"status": lw.Status()},
+			}
+			if params := pathParams(r); len(params) > 0 {
+				e.Details["params"] = params
+			}
+			if recorded := recordedBody(redactor, body); recorded != nil {
+				e.Details["body"] = recorded
+			}
+			if err := recorder.Record(r.Context(), e); err != nil {
+				// The action already happened, the response can't report the failure.
+				logger.FromContext(r.Context()).Error("failed to record audit entry", "error", err, "action", e.Action)
+			}
+		})
+	}
+}
+
+// pathParams returns the values of the wildcards in the matched route pattern.
+func pathParams(r *http.Request) map[string]string {
+	params := map[string]string{}
+	pattern := r.Pattern
+	for {
+		start := strings.IndexByte(pattern, '{')
+		if start < 0 {
+			return params
+		}
+		end := strings.IndexByte(pattern[start:], '}')
+		if end < 0 {
+			return params
+		}
+		name := strings.TrimSuffix(pattern[start+1:start+end], "...")
+		if name != "$" {
+			params[name] = r.PathValue(name)
+		}
+		pattern = pattern[start+end+1:]
+	}
+}
+
+// peekBody reads up to maxRecordedBody+1 bytes of the request body and puts
+// them back in front of the rest, so the handler reads the whole body. A read
+// error is left for the handler to run into.
+func peekBody(r *http.Request) []byte {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil
+	}
+	body, _ := io.ReadAll(io.LimitReader(r.Body, maxRecordedBody+1))
+	r.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(body), r.Body), r.Body}
+	return body
+}
+
+// recordedBody returns body as redacted JSON, or nil if it is empty, too
+// large or not JSON.
+func recordedBody(redactor *logger.Redactor, body []byte) any {
+	if len(body) == 0 || len(body) > maxRecordedBody {
+		return nil
+	}
+	var v any
+	if err := json.Unmarshal([]byte(redactor.JSON(string(body))), &v); err != nil {
+		return nil
+	}
+	return v
+}
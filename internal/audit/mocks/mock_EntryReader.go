@@ -0,0 +1,97 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	audit "github.com/grantsy/grantsy/internal/audit"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockEntryReader is an autogenerated mock type for the EntryReader type
+type MockEntryReader struct {
+	mock.Mock
+}
+
+type MockEntryReader_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockEntryReader) EXPECT() *MockEntryReader_Expecter {
+	return &MockEntryReader_Expecter{mock: &_m.Mock}
+}
+
+// ListEntries provides a mock function with given fields: ctx, filter
+func (_m *MockEntryReader) ListEntries(ctx context.Context, filter audit.Filter) ([]audit.Entry, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListEntries")
+	}
+
+	var r0 []audit.Entry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, audit.Filter) ([]audit.Entry, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, audit.Filter) []audit.Entry); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]audit.Entry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, audit.Filter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockEntryReader_ListEntries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListEntries'
+type MockEntryReader_ListEntries_Call struct {
+	*mock.Call
+}
+
+// ListEntries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter audit.Filter
+func (_e *MockEntryReader_Expecter) ListEntries(ctx interface{}, filter interface{}) *MockEntryReader_ListEntries_Call {
+	return &MockEntryReader_ListEntries_Call{Call: _e.mock.On("ListEntries", ctx, filter)}
+}
+
+func (_c *MockEntryReader_ListEntries_Call) Run(run func(ctx context.Context, filter audit.Filter)) *MockEntryReader_ListEntries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(audit.Filter))
+	})
+	return _c
+}
+
+func (_c *MockEntryReader_ListEntries_Call) Return(_a0 []audit.Entry, _a1 error) *MockEntryReader_ListEntries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockEntryReader_ListEntries_Call) RunAndReturn(run func(context.Context, audit.Filter) ([]audit.Entry, error)) *MockEntryReader_ListEntries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockEntryReader creates a new instance of MockEntryReader. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockEntryReader(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockEntryReader {
+	mock := &MockEntryReader{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,85 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	audit "github.com/grantsy/grantsy/internal/audit"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockRecorder is an autogenerated mock type for the Recorder type
+type MockRecorder struct {
+	mock.Mock
+}
+
+type MockRecorder_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRecorder) EXPECT() *MockRecorder_Expecter {
+	return &MockRecorder_Expecter{mock: &_m.Mock}
+}
+
+// Record provides a mock function with given fields: ctx, e
+func (_m *MockRecorder) Record(ctx context.Context, e audit.Entry) error {
+	ret := _m.Called(ctx, e)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Record")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, audit.Entry) error); ok {
+		r0 = rf(ctx, e)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRecorder_Record_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Record'
+type MockRecorder_Record_Call struct {
+	*mock.Call
+}
+
+// Record is a helper method to define mock.On call
+//   - ctx context.Context
+//   - e audit.Entry
+func (_e *MockRecorder_Expecter) Record(ctx interface{}, e interface{}) *MockRecorder_Record_Call {
+	return &MockRecorder_Record_Call{Call: _e.mock.On("Record", ctx, e)}
+}
+
+func (_c *MockRecorder_Record_Call) Run(run func(ctx context.Context, e audit.Entry)) *MockRecorder_Record_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(audit.Entry))
+	})
+	return _c
+}
+
+func (_c *MockRecorder_Record_Call) Return(_a0 error) *MockRecorder_Record_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRecorder_Record_Call) RunAndReturn(run func(context.Context, audit.Entry) error) *MockRecorder_Record_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRecorder creates a new instance of MockRecorder. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRecorder(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRecorder {
+	mock := &MockRecorder{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
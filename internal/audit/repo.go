@@ -0,0 +1,141 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/grantsy/grantsy/internal/infra/db"
+)
+
+type Repo struct {
+	db *db.DB
+}
+
+func NewRepo(database *db.DB) *Repo {
+	return &Repo{db: database}
+}
+
+const entryColumns = `id, action, actor_type, actor, actor_id, user_id,
+			plan_before, plan_after, request_id, details, created_at`
+
+// InsertEntry appends an entry to the audit log.
+func (r *Repo) InsertEntry(ctx context.Context, e *Entry) error {
+	details := []byte("{}")
+	if len(e.Details) > 0 {
+		var err error
+		if details, err = json.Marshal(e.Details); err != nil {
+			return fmt.Errorf("audit: failed to marshal details: %w", err)
+		}
+	}
+
+	table := r.db.TableName("audit_log")
+	query := r.db.Rebind(fmt.Sprintf(`
+		INSERT INTO %s (%s)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`, table, entryColumns))
+
+	_, err := r.db.ExecContext(
+		ctx,
+		query,
+		e.ID,
+		e.Action,
+		e.ActorType,
+		e.Actor,
+		e.ActorID,
+		e.UserID,
+		e.PlanBefore,
+		e.PlanAfter,
+		e.RequestID,
+		string(details),
+		e.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("audit: failed to insert entry: %w", err)
+	}
+	return nil
+}
+
+// ListEntries returns entries matching filter, newest first.
+func (r *Repo) ListEntries(ctx context.Context, filter Filter) ([]Entry, error) {
+	var conds []string
+	var args []any
+	if filter.UserID != "" {
+		args = append(args, filter.UserID)
+		conds = append(conds, fmt.Sprintf("user_id = $%d", len(args)))
+	}
+	if filter.Actor != "" {
+		args = append(args, filter.Actor)
+		conds = append(conds, fmt.Sprintf("actor = $%d", len(args)))
+	}
+	if filter.ActorID != "" {
+		args = append(args, filter.ActorID)
+		conds = append(conds, fmt.Sprintf("actor_id = $%d", len(args)))
+	}
+	if filter.Action != "" {
+		args = append(args, filter.Action)
+		conds = append(conds, fmt.Sprintf("action = $%d", len(args)))
+	}
+	if filter.Since != 0 {
+		args = append(args, filter.Since)
+		conds = append(conds, fmt.Sprintf("created_at >= $%d", len(args)))
+	}
+	if filter.Until != 0 {
+		args = append(args, filter.Until)
+		conds = append(conds, fmt.Sprintf("created_at < $%d", len(args)))
+	}
+	if filter.Cursor != "" {
+		args = append(args, filter.Cursor)
+		conds = append(conds, fmt.Sprintf("id < $%d", len(args)))
+	}
+	where := ""
+	if len(conds) > 0 {
+		where = "WHERE " + strings.Join(conds, " AND ")
+	}
+	args = append(args, filter.Limit)
+
+	table := r.db.TableName("audit_log")
+	query := r.db.Rebind(fmt.Sprintf(`
+		SELECT %s FROM %s
+		%s
+		ORDER BY id DESC
+		LIMIT $%d
+	`, entryColumns, table, where, len(args)))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("audit: failed to query entries: %w", err)
+	}
+	defer rows.Close()
+
+	var result []Entry
+	for rows.Next() {
+		e, err := scanEntry(rows)
+		if err != nil {
+			return nil, fmt.Errorf("audit: failed to scan row: %w", err)
+		}
+		result = append(result, *e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("audit: rows error: %w", err)
+	}
+
+	return result, nil
+}
+
+func scanEntry(row interface{ Scan(dest ...any) error }) (*Entry, error) {
+	var e Entry
+	var details string
+	if err := row.Scan(
+		&e.ID, &e.Action, &e.ActorType, &e.Actor, &e.ActorID, &e.UserID,
+		&e.PlanBefore, &e.PlanAfter, &e.RequestID, &details, &e.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(details), &e.Details); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal details: %w", err)
+	}
+	return &e, nil
+}
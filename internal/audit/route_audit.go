@@ -0,0 +1,142 @@
+package audit
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/iamolegga/valmid"
+	"github.com/swaggest/openapi-go"
+	"github.com/swaggest/openapi-go/openapi31"
+
+	"github.com/grantsy/grantsy/internal/auth"
+	"github.com/grantsy/grantsy/internal/httptools"
+	"github.com/grantsy/grantsy/internal/infra/logger"
+	oa "github.com/grantsy/grantsy/internal/openapi"
+)
+
+// EntryReader reads the audit log.
+type EntryReader interface {
+	ListEntries(ctx context.Context, filter Filter) ([]Entry, error)
+}
+
+type AuditRequest struct {
+	UserID  string `in:"query=user_id"          query:"user_id" description:"Filter by the user the entry is about"`
+	Actor   string `in:"query=actor"            query:"actor"   description:"Filter by actor: API key or bearer token name, or billing provider"`
+	ActorID string `in:"query=actor_id" query:"actor_id" description:"Filter by the ID of the stored API key that made the request"`
+	Action  string `in:"query=action"           query:"action"  description:"Filter by action, e.g. plan.assigned or POST /v1/api-keys"`
+	Since   int64  `in:"query=since"            query:"since"   description:"Only entries recorded at or after this Unix timestamp"                validate:"min=0"`
+	Until   int64  `in:"query=until"            query:"until"   description:"Only entries recorded before this Unix timestamp"                     validate:"min=0"`
+	Cursor  string `in:"query=cursor"           query:"cursor"  description:"Pagination cursor (next_cursor from the previous page)"`
+	Limit   int    `in:"query=limit;default=50" query:"limit"   description:"Maximum number of entries to return"                                  validate:"min=1,max=200" default:"50"`
+}
+
+type AuditResponse struct {
+	Entries    []AuditEntry `json:"entries"               description:"Audit entries, newest first" nullable:"false" required:"true"`
+	NextCursor string       `json:"next_cursor,omitempty" description:"Cursor for the next page, omitted on the last page"`
+}
+
+type AuditEntry struct {
+	ID         string         `json:"id"                    description:"Entry identifier"                                                      required:"true"`
+	Action     string         `json:"action"                description:"plan.assigned, plan.deactivated, config.loaded, or the route of an admin request" required:"true"`
+	ActorType  string         `json:"actor_type"            description:"Kind of actor"                                                         required:"true" enum:"api_key,provider,system"`
+	Actor      string         `json:"actor,omitempty"       description:"API key or bearer token name, or billing provider whose webhook made the change"`
+	ActorID    string         `json:"actor_id,omitempty" description:"ID of the stored API key that made the request, omitted for keys from the config file and bearer tokens"`
+	UserID     string         `json:"user_id,omitempty"     description:"User the entry is about"`
+	PlanBefore string         `json:"plan_before,omitempty" description:"User's plan before the change"`
+	PlanAfter  string         `json:"plan_after,omitempty"  description:"User's plan after the change"`
+	RequestID  string         `json:"request_id,omitempty"  description:"X-Request-ID of the request that made the change"`
+	Details    map[string]any `json:"details,omitempty"     description:"Action-specific details"`
+	CreatedAt  int64          `json:"created_at"            description:"Unix timestamp of the entry"                                           required:"true"`
+}
+
+type RouteAudit struct {
+	reader EntryReader
+}
+
+func NewRouteAudit(reader EntryReader) *RouteAudit {
+	return &RouteAudit{reader: reader}
+}
+
+func (route *RouteAudit) Register(mux *http.ServeMux, r *openapi31.Reflector) {
+	mux.Handle("GET /v1/audit", httptools.Wrap(
+		route.Handler(),
+		auth.RequireScope(auth.ScopeAuditRead),
+		valmid.Middleware[AuditRequest](),
+	))
+	RegisterAuditSchema(r)
+}
+
+func RegisterAuditSchema(r *openapi31.Reflector) {
+	op, _ := r.NewOperationContext(http.MethodGet, "/v1/audit")
+	op.AddReqStructure(new(AuditRequest))
+	op.AddRespStructure(struct {
+		Data AuditResponse  `json:"data"`
+		Meta httptools.Meta `json:"meta"`
+		_    struct{}       `title:"AuditResponse"`
+	}{}, func(cu *openapi.ContentUnit) {
+		cu.HTTPStatus = http.StatusOK
+		cu.Description = "Audit log entries"
+	})
+	oa.AddErrorResponses(op)
+	op.SetSummary("List audit log")
+	op.SetDescription(
+		"List plan assignments and deactivations, config loads and admin requests with who made them, newest first. Use next_cursor to fetch the next page.",
+	)
+	op.SetTags("Audit")
+	op.AddSecurity("ApiKeyAuth", auth.ScopeAuditRead)
+	op.AddSecurity("BearerAuth", auth.ScopeAuditRead)
+	r.AddOperation(op)
+}
+
+func (route *RouteAudit) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		input := valmid.Get[AuditRequest](r)
+
+		entries, err := route.reader.ListEntries(r.Context(), Filter{
+			UserID:  input.UserID,
+			Actor:   input.Actor,
+			ActorID: input.ActorID,
+			Action:  input.Action,
+			Since:   input.Since,
+			Until:   input.Until,
+			Cursor:  input.Cursor,
+			// Fetch one extra entry to know whether another page exists.
+			Limit: input.Limit + 1,
+		})
+		if err != nil {
+			logger.FromContext(r.Context()).Error("failed to list audit entries", "error", err)
+			httptools.InternalError(w, r)
+			return
+		}
+
+		resp := AuditResponse{
+			Entries: make([]AuditEntry, 0, min(len(entries), input.Limit)),
+		}
+		for i, e := range entries {
+			if i == input.Limit {
+				resp.NextCursor = entries[i-1].ID
+				break
+			}
+			resp.Entries = append(resp.Entries, ToAuditEntry(&e))
+		}
+
+		httptools.JSON(w, r, http.StatusOK, resp)
+	})
+}
+
+// ToAuditEntry converts an Entry to its display type.
+func ToAuditEntry(e *Entry) AuditEntry {
+	return AuditEntry{
+		ID:         e.ID,
+		Action:     e.Action,
+		ActorType:  e.ActorType,
+		Actor:      e.Actor,
+		ActorID:    e.ActorID,
+		UserID:     e.UserID,
+		PlanBefore: e.PlanBefore,
+		PlanAfter:  e.PlanAfter,
+		RequestID:  e.RequestID,
+		Details:    e.Details,
+		CreatedAt:  e.CreatedAt,
+	}
+}
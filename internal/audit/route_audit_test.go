@@ -0,0 +1,133 @@
+package audit_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/openapi-go/openapi31"
+
+	"github.com/grantsy/grantsy/internal/audit"
+	"github.com/grantsy/grantsy/internal/audit/mocks"
+	"github.com/grantsy/grantsy/internal/auth"
+	"github.com/grantsy/grantsy/internal/auth/authtest"
+	"github.com/grantsy/grantsy/internal/httptools"
+	"github.com/grantsy/grantsy/internal/infra/logger"
+	_ "github.com/grantsy/grantsy/internal/infra/validation"
+)
+
+func serveAudit(t *testing.T, h http.Handler, method, target string) (int, map[string]any) {
+	t.Helper()
+	return serveAuditBody(t, h, method, target, "")
+}
+
+func serveAuditBody(t *testing.T, h http.Handler, method, target, body string) (int, map[string]any) {
+	t.Helper()
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Body.Len() == 0 {
+		return w.Code, nil
+	}
+	var resp httptools.Response
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	data, _ := resp.Data.(map[string]any)
+	return w.Code, data
+}
+
+func TestRouteAudit_Paginates(t *testing.T) {
+	reader := mocks.NewMockEntryReader(t)
+	reader.EXPECT().
+		ListEntries(mock.Anything, audit.Filter{UserID: "user1", Action: audit.ActionPlanAssigned, Limit: 3}).
+		Return([]audit.Entry{
+			{ID: "03", Action: audit.ActionPlanAssigned, ActorType: audit.ActorAPIKey, Actor: "admin"},
+			{ID: "02", Action: audit.ActionPlanAssigned, ActorType: audit.ActorProvider, Actor: "lemonsqueezy"},
+			{ID: "01", Action: audit.ActionPlanAssigned, ActorType: audit.ActorSystem},
+		}, nil)
+
+	mux := http.NewServeMux()
+	audit.NewRouteAudit(reader).Register(mux, openapi31.NewReflector())
+
+	code, data := serveAudit(t, authtest.Handler(mux), http.MethodGet,
+		"/v1/audit?user_id=user1&action=plan.assigned&limit=2")
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Len(t, data["entries"], 2)
+	assert.Equal(t, "02", data["next_cursor"])
+}
+
+func TestRouteAudit_RequiresAuditScope(t *testing.T) {
+	mux := http.NewServeMux()
+	audit.NewRouteAudit(mocks.NewMockEntryReader(t)).Register(mux, openapi31.NewReflector())
+
+	code, _ := serveAudit(t, authtest.Handler(mux, auth.ScopeAdminWrite), http.MethodGet, "/v1/audit")
+
+	assert.Equal(t, http.StatusForbidden, code)
+}
+
+func TestMiddleware_RecordsAdminRequests(t *testing.T) {
+	recorder := mocks.NewMockRecorder(t)
+	recorder.EXPECT().
+		Record(mock.Anything, audit.Entry{
+			Action: "POST /v1/users/{user_id}/subscription/cancel",
+			UserID: "user1",
+			Details: map[string]any{
+				"status": http.StatusOK,
+				"params": map[string]string{"user_id": "user1"},
+			},
+		}).
+		Return(nil).Once()
+
+	mux := http.NewServeMux()
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+	mux.Handle("POST /v1/users/{user_id}/subscription/cancel", ok)
+	mux.Handle("GET /v1/users/{user_id}", ok)
+	mux.Handle("DELETE /v1/api-keys/{id}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		httptools.NotFound(w, r, "API key not found")
+	}))
+	h := authtest.Handler(audit.Middleware(recorder, logger.NewRedactor(nil, nil, nil))(mux))
+
+	code, _ := serveAudit(t, h, http.MethodPost, "/v1/users/user1/subscription/cancel")
+	assert.Equal(t, http.StatusOK, code)
+
+	// Reads and failed requests are not recorded.
+	serveAudit(t, h, http.MethodGet, "/v1/users/user1")
+	serveAudit(t, h, http.MethodDelete, "/v1/api-keys/missing")
+}
+
+func TestMiddleware_RecordsRedactedBody(t *testing.T) {
+	recorder := mocks.NewMockRecorder(t)
+	recorder.EXPECT().
+		Record(mock.Anything, audit.Entry{
+			Action: "POST /v1/users/{user_id}/subscription/change",
+			UserID: "user1",
+			Details: map[string]any{
+				"status": http.StatusOK,
+				"params": map[string]string{"user_id": "user1"},
+				"body":   map[string]any{"variant_id": float64(7), "token": "REDACTED"},
+			},
+		}).
+		Return(nil).Once()
+
+	var handled string
+	mux := http.NewServeMux()
+	mux.Handle("POST /v1/users/{user_id}/subscription/change", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		handled = string(b)
+		w.WriteHeader(http.StatusOK)
+	}))
+	h := authtest.Handler(audit.Middleware(recorder, logger.NewRedactor(nil, nil, nil))(mux))
+
+	body := `{"variant_id":7,"token":"secret"}`
+	code, _ := serveAuditBody(t, h, http.MethodPost, "/v1/users/user1/subscription/change", body)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, body, handled, "the handler reads the whole body")
+}
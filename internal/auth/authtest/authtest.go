@@ -0,0 +1,24 @@
+// Package authtest provides helpers for testing routes that require API key
+// scopes.
+package authtest
+
+import (
+	"net/http"
+
+	"github.com/grantsy/grantsy/internal/auth"
+)
+
+// KeyName is the name of the key requests are authenticated with.
+const KeyName = "test"
+
+// Handler serves requests with h as if they were authenticated with a key
+// granting scopes, or every scope if none are given.
+func Handler(h http.Handler, scopes ...string) http.Handler {
+	if len(scopes) == 0 {
+		scopes = auth.AllScopes
+	}
+	key := &auth.Key{Name: KeyName, Scopes: scopes}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.ServeHTTP(w, r.WithContext(auth.WithKey(r.Context(), key)))
+	})
+}
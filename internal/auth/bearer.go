@@ -0,0 +1,125 @@
+package auth
+
+import (
+	"cmp"
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/grantsy/grantsy/internal/infra/config"
+)
+
+const (
+	defaultNameClaim   = "sub"
+	defaultScopesClaim = "scope"
+)
+
+// TokenAuthenticator resolves the key a bearer token stands for.
+type TokenAuthenticator interface {
+	AuthenticateToken(ctx context.Context, token string) (*Key, error)
+}
+
+// BearerTokens authenticates service callers by JWTs from a trusted issuer,
+// such as workload identity tokens, instead of static API keys.
+type BearerTokens struct {
+	keys        *jwks
+	issuer      string
+	audience    string
+	nameClaim   string
+	scopesClaim string
+	mappings    []config.ScopeMapping
+}
+
+// NewBearerTokens creates a bearer token authenticator from cfg. A JWKS file
+// is read right away, so a missing or malformed file fails at startup. An
+// audience is required: without it, tokens the issuer mints for any other
+// service would be accepted.
+func NewBearerTokens(ctx context.Context, cfg *config.BearerConfig) (*BearerTokens, error) {
+	if cfg.Audience == "" {
+		return nil, errors.New("auth: bearer audience is required")
+	}
+	b := &BearerTokens{
+		issuer:      cfg.Issuer,
+		audience:    cfg.Audience,
+		nameClaim:   cmp.Or(cfg.NameClaim, defaultNameClaim),
+		scopesClaim: cmp.Or(cfg.ScopesClaim, defaultScopesClaim),
+		mappings:    cfg.ScopeMappings,
+	}
+	if cfg.JWKSFile != "" {
+		b.keys = newJWKSFile(cfg.JWKSFile)
+		if err := b.keys.load(ctx); err != nil {
+			return nil, err
+		}
+	} else {
+		b.keys = newJWKS(cfg.JWKSURL, &http.Client{Timeout: 10 * time.Second})
+	}
+	return b, nil
+}
+
+// AuthenticateToken verifies token and returns a key named after its name
+// claim, with the scopes its claims map to. Errors caused by the token itself
+// wrap errInvalidToken.
+func (b *BearerTokens) AuthenticateToken(ctx context.Context, token string) (*Key, error) {
+	claims, err := parseJWT(token, func(alg, kid string) (crypto.PublicKey, []byte, error) {
+		if alg != "RS256" && alg != "ES256" {
+			return nil, nil, fmt.Errorf("%w: %s tokens are not accepted", errInvalidToken, alg)
+		}
+		key, err := b.keys.key(ctx, kid)
+		return key, nil, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := claims.validate(time.Now(), b.issuer, b.audience, 0); err != nil {
+		return nil, err
+	}
+
+	name, _ := claims.Raw[b.nameClaim].(string)
+	if name == "" {
+		return nil, fmt.Errorf("%w: missing %s claim", errInvalidToken, b.nameClaim)
+	}
+	return &Key{Name: name, Scopes: b.scopes(claims.Raw), Bearer: true}, nil
+}
+
+// scopes returns the grantsy scopes listed in the scopes claim, plus those of
+// every matching mapping, in the order of AllScopes.
+func (b *BearerTokens) scopes(claims map[string]any) []string {
+	granted := claimValues(claims[b.scopesClaim])
+	for _, m := range b.mappings {
+		if slices.Contains(claimValues(claims[m.Claim]), m.Value) {
+			granted = append(granted, m.Scopes...)
+		}
+	}
+
+	var scopes []string
+	for _, scope := range AllScopes {
+		if slices.Contains(granted, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
+
+// claimValues returns the values of a claim: the words of a string claim, as
+// in the space-separated OAuth scope claim, or the strings of an array claim.
+func claimValues(claim any) []string {
+	switch v := claim.(type) {
+	case string:
+		return strings.Fields(v)
+	case []any:
+		var values []string
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,182 @@
+package auth_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grantsy/grantsy/internal/auth"
+	"github.com/grantsy/grantsy/internal/infra/config"
+)
+
+const (
+	testIssuer   = "https://identity.internal"
+	testAudience = "grantsy"
+)
+
+// newBearerTokens writes a JWKS file with a fresh RSA key and returns an
+// authenticator for cfg using it, and a function signing tokens with the key.
+func newBearerTokens(t *testing.T, cfg config.BearerConfig) (*auth.BearerTokens, func(map[string]any) string) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	jwks, err := json.Marshal(map[string]any{"keys": []map[string]any{{
+		"kty": "RSA", "kid": "workload-1",
+		"n": b64(key.N.Bytes()),
+		"e": b64(big.NewInt(int64(key.E)).Bytes()),
+	}}})
+	require.NoError(t, err)
+	cfg.Issuer = testIssuer
+	cfg.Audience = testAudience
+	cfg.JWKSFile = filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(cfg.JWKSFile, jwks, 0o600))
+
+	bearer, err := auth.NewBearerTokens(context.Background(), &cfg)
+	require.NoError(t, err)
+
+	sign := func(claims map[string]any) string {
+		return signToken(t, map[string]any{"alg": "RS256", "kid": "workload-1"}, claims, func(data []byte) []byte {
+			digest := sha256.Sum256(data)
+			sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+			require.NoError(t, err)
+			return sig
+		})
+	}
+	return bearer, sign
+}
+
+func workloadClaims(extra map[string]any) map[string]any {
+	claims := map[string]any{
+		"iss": testIssuer,
+		"aud": testAudience,
+		"sub": "billing-service",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}
+	for k, v := range extra {
+		claims[k] = v
+	}
+	return claims
+}
+
+func TestBearerTokens_Scopes(t *testing.T) {
+	bearer, sign := newBearerTokens(t, config.BearerConfig{
+		ScopeMappings: []config.ScopeMapping{
+			{Claim: "groups", Value: "billing", Scopes: []string{auth.ScopeAdminWrite, auth.ScopeUsersRead}},
+		},
+	})
+
+	tests := []struct {
+		name       string
+		claims     map[string]any
+		wantScopes []string
+	}{
+		{
+			name:       "scope claim",
+			claims:     workloadClaims(map[string]any{"scope": "plans:read check:read openid"}),
+			wantScopes: []string{auth.ScopeCheckRead, auth.ScopePlansRead},
+		},
+		{
+			name:       "mapped claim",
+			claims:     workloadClaims(map[string]any{"groups": []string{"platform", "billing"}}),
+			wantScopes: []string{auth.ScopeUsersRead, auth.ScopeAdminWrite},
+		},
+		{
+			name:   "no scopes",
+			claims: workloadClaims(map[string]any{"groups": []string{"platform"}}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := bearer.AuthenticateToken(context.Background(), sign(tt.claims))
+			require.NoError(t, err)
+			assert.Equal(t, "billing-service", key.Name)
+			assert.Equal(t, tt.wantScopes, key.Scopes)
+		})
+	}
+}
+
+func TestBearerTokens_Rejected(t *testing.T) {
+	bearer, sign := newBearerTokens(t, config.BearerConfig{})
+
+	tests := []struct {
+		name   string
+		claims map[string]any
+	}{
+		{name: "wrong issuer", claims: workloadClaims(map[string]any{"iss": "https://other"})},
+		{name: "wrong audience", claims: workloadClaims(map[string]any{"aud": "other"})},
+		{name: "no audience", claims: workloadClaims(map[string]any{"aud": nil})},
+		{name: "expired", claims: workloadClaims(map[string]any{"exp": time.Now().Add(-time.Hour).Unix()})},
+		{name: "no subject", claims: workloadClaims(map[string]any{"sub": ""})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := bearer.AuthenticateToken(context.Background(), sign(tt.claims))
+			require.Error(t, err)
+			assert.Nil(t, key)
+		})
+	}
+
+	// HS256 tokens are never accepted as bearer tokens.
+	_, err := bearer.AuthenticateToken(context.Background(),
+		hmacToken(t, testHMACSecret, workloadClaims(nil)))
+	require.Error(t, err)
+}
+
+func TestNewBearerTokens_MissingFile(t *testing.T) {
+	_, err := auth.NewBearerTokens(context.Background(), &config.BearerConfig{
+		Issuer:   testIssuer,
+		Audience: testAudience,
+		JWKSFile: filepath.Join(t.TempDir(), "missing.json"),
+	})
+	require.Error(t, err)
+}
+
+func TestNewBearerTokens_RequiresAudience(t *testing.T) {
+	_, err := auth.NewBearerTokens(context.Background(), &config.BearerConfig{
+		Issuer:  testIssuer,
+		JWKSURL: "https://identity.internal/.well-known/jwks.json",
+	})
+	require.ErrorContains(t, err, "audience")
+}
+
+func TestMiddleware_Bearer(t *testing.T) {
+	bearer, sign := newBearerTokens(t, config.BearerConfig{})
+	var got *auth.Key
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = auth.KeyFromContext(r.Context())
+	})
+	handler := auth.Middleware(auth.NewKeyring(config.AuthConfig{APIKeys: testKeys}, nil), nil, bearer)(next)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer "+sign(workloadClaims(map[string]any{"scope": "check:read"})))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	require.NotNil(t, got)
+	assert.Equal(t, &auth.Key{Name: "billing-service", Scopes: []string{auth.ScopeCheckRead}, Bearer: true}, got)
+
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer invalid")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Equal(t, `Bearer error="invalid_token"`, rec.Header().Get("WWW-Authenticate"))
+}
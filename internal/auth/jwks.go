@@ -0,0 +1,208 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/grantsy/grantsy/internal/infra/logger"
+)
+
+const (
+	// jwksTTL is how long fetched keys are used before they are fetched again.
+	jwksTTL = time.Hour
+	// jwksMinRefresh limits refetching when a token names an unknown key.
+	jwksMinRefresh = time.Minute
+)
+
+// jwks is a JSON Web Key Set fetched from a URL or read from a file, and
+// cached. Keys are loaded again after jwksTTL, or sooner when a token is
+// signed by an unknown key, so key rotation at the identity provider is
+// picked up.
+type jwks struct {
+	url    string
+	path   string
+	client *http.Client
+
+	mu        sync.Mutex
+	keys      map[string]crypto.PublicKey
+	fetchedAt time.Time
+}
+
+func newJWKS(url string, client *http.Client) *jwks {
+	return &jwks{url: url, client: client}
+}
+
+func newJWKSFile(path string) *jwks {
+	return &jwks{path: path}
+}
+
+// load reads the keys up front, so a broken key set fails at startup.
+func (s *jwks) load(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	keys, err := s.fetch(ctx)
+	if err != nil {
+		return err
+	}
+	s.keys, s.fetchedAt = keys, time.Now()
+	return nil
+}
+
+// key returns the key with ID kid. If kid is empty and the set holds a single
+// key, that key is returned.
+func (s *jwks) key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	stale := now.Sub(s.fetchedAt) >= jwksTTL
+	missing := s.lookup(kid) == nil && now.Sub(s.fetchedAt) >= jwksMinRefresh
+	if s.keys == nil || stale || missing {
+		keys, err := s.fetch(ctx)
+		if err != nil {
+			if s.keys == nil {
+				return nil, err
+			}
+			// Keep verifying with the cached keys while the provider is unreachable.
+			logger.FromContext(ctx).Error("failed to refresh JWKS", "error", err, "source", s.source())
+		} else {
+			s.keys = keys
+		}
+		s.fetchedAt = now
+	}
+
+	key := s.lookup(kid)
+	if key == nil {
+		return nil, fmt.Errorf("%w: unknown key %q", errInvalidToken, kid)
+	}
+	return key, nil
+}
+
+func (s *jwks) lookup(kid string) crypto.PublicKey {
+	if kid == "" && len(s.keys) == 1 {
+		for _, key := range s.keys {
+			return key
+		}
+	}
+	return s.keys[kid]
+}
+
+func (s *jwks) source() string {
+	if s.path != "" {
+		return s.path
+	}
+	return s.url
+}
+
+func (s *jwks) fetch(ctx context.Context) (map[string]crypto.PublicKey, error) {
+	if s.path != "" {
+		return s.readFile()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("auth: failed to create JWKS request: %w", err)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("auth: failed to fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("auth: failed to fetch JWKS: status %d", resp.StatusCode)
+	}
+
+	var set jsonWebKeySet
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return nil, fmt.Errorf("auth: failed to decode JWKS: %w", err)
+	}
+	return parseJWKS(set.Keys), nil
+}
+
+func (s *jwks) readFile() (map[string]crypto.PublicKey, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("auth: failed to read JWKS file: %w", err)
+	}
+	var set jsonWebKeySet
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("auth: failed to decode JWKS file: %w", err)
+	}
+	return parseJWKS(set.Keys), nil
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// parseJWKS returns the RSA and P-256 signing keys in keys by ID. Other keys
+// are skipped.
+func parseJWKS(keys []jsonWebKey) map[string]crypto.PublicKey {
+	result := make(map[string]crypto.PublicKey, len(keys))
+	for _, k := range keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		if key, err := k.publicKey(); err == nil {
+			result[k.Kid] = key
+		}
+	}
+	return result
+}
+
+func (k jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}, nil
+	case "EC":
+		if k.Crv != "P-256" {
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		point := append([]byte{4}, append(x, y...)...)
+		return ecdsa.ParseUncompressedPublicKey(elliptic.P256(), point)
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
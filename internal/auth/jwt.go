@@ -0,0 +1,162 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+)
+
+// errInvalidToken is wrapped by every error caused by the token itself, as
+// opposed to failures fetching the keys to verify it with.
+var errInvalidToken = errors.New("auth: invalid token")
+
+// clockSkew is the leeway allowed when checking token timestamps.
+const clockSkew = 30 * time.Second
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// jwtClaims holds the registered claims grantsy checks. The full claim set is
+// kept in Raw for callers that map custom claims.
+type jwtClaims struct {
+	Subject   string         `json:"sub"`
+	Issuer    string         `json:"iss"`
+	Audience  jwtAudience    `json:"aud"`
+	ExpiresAt *int64         `json:"exp"`
+	NotBefore *int64         `json:"nbf"`
+	Raw       map[string]any `json:"-"`
+}
+
+// jwtAudience accepts both forms of the aud claim: a string or an array.
+type jwtAudience []string
+
+func (a *jwtAudience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = jwtAudience{single}
+		return nil
+	}
+	var multiple []string
+	if err := json.Unmarshal(data, &multiple); err != nil {
+		return err
+	}
+	*a = multiple
+	return nil
+}
+
+// keyFunc returns the key to verify a token signed with alg by key kid.
+type keyFunc func(alg, kid string) (crypto.PublicKey, []byte, error)
+
+// parseJWT verifies a compact JWS token and returns its claims. Only HS256,
+// RS256 and ES256 are accepted. The caller checks the claims.
+func parseJWT(token string, key keyFunc) (*jwtClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed token", errInvalidToken)
+	}
+
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("%w: malformed header: %v", errInvalidToken, err)
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed signature: %v", errInvalidToken, err)
+	}
+
+	pub, secret, err := key(header.Alg, header.Kid)
+	if err != nil {
+		return nil, err
+	}
+	signed := []byte(parts[0] + "." + parts[1])
+	if err := verifySignature(header.Alg, signed, sig, pub, secret); err != nil {
+		return nil, err
+	}
+
+	var claims jwtClaims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("%w: malformed claims: %v", errInvalidToken, err)
+	}
+	if err := decodeSegment(parts[1], &claims.Raw); err != nil {
+		return nil, fmt.Errorf("%w: malformed claims: %v", errInvalidToken, err)
+	}
+	return &claims, nil
+}
+
+func verifySignature(alg string, signed, sig []byte, pub crypto.PublicKey, secret []byte) error {
+	digest := sha256.Sum256(signed)
+	switch alg {
+	case "HS256":
+		if secret == nil {
+			break
+		}
+		mac := hmac.New(sha256.New, secret)
+		mac.Write(signed)
+		if hmac.Equal(sig, mac.Sum(nil)) {
+			return nil
+		}
+	case "RS256":
+		rsaKey, ok := pub.(*rsa.PublicKey)
+		if ok && rsa.VerifyPKCS1v15(rsaKey, crypto.SHA256, digest[:], sig) == nil {
+			return nil
+		}
+	case "ES256":
+		ecKey, ok := pub.(*ecdsa.PublicKey)
+		if ok && len(sig) == 64 {
+			r := new(big.Int).SetBytes(sig[:32])
+			s := new(big.Int).SetBytes(sig[32:])
+			if ecdsa.Verify(ecKey, digest[:], r, s) {
+				return nil
+			}
+		}
+	default:
+		return fmt.Errorf("%w: unsupported algorithm %q", errInvalidToken, alg)
+	}
+	return fmt.Errorf("%w: bad signature", errInvalidToken)
+}
+
+// validate checks the token's expiry and, when set, its issuer and audience.
+// Tokens without an expiry are rejected, as are tokens valid for longer than
+// maxTTL if it is set.
+func (c *jwtClaims) validate(now time.Time, issuer, audience string, maxTTL time.Duration) error {
+	if c.ExpiresAt == nil {
+		return fmt.Errorf("%w: missing exp claim", errInvalidToken)
+	}
+	exp := time.Unix(*c.ExpiresAt, 0)
+	if now.After(exp.Add(clockSkew)) {
+		return fmt.Errorf("%w: token expired", errInvalidToken)
+	}
+	if maxTTL > 0 && exp.After(now.Add(maxTTL+clockSkew)) {
+		return fmt.Errorf("%w: token lifetime exceeds %s", errInvalidToken, maxTTL)
+	}
+	if c.NotBefore != nil && now.Add(clockSkew).Before(time.Unix(*c.NotBefore, 0)) {
+		return fmt.Errorf("%w: token not valid yet", errInvalidToken)
+	}
+	if issuer != "" && c.Issuer != issuer {
+		return fmt.Errorf("%w: unexpected issuer %q", errInvalidToken, c.Issuer)
+	}
+	if audience != "" && !slices.Contains(c.Audience, audience) {
+		return fmt.Errorf("%w: audience does not include %q", errInvalidToken, audience)
+	}
+	return nil
+}
+
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,267 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/grantsy/grantsy/internal/infra/config"
+	"github.com/grantsy/grantsy/internal/infra/logger"
+)
+
+// ErrKeyRevoked is returned when rotating a revoked key.
+var ErrKeyRevoked = errors.New("auth: API key is revoked")
+
+// ErrScopeNotHeld is returned when the requesting key would obtain a key with
+// scopes it does not hold itself.
+var ErrScopeNotHeld = errors.New("auth: requesting key does not hold the key's scopes")
+
+// errKeyExpired is returned by Authenticate for a stored key past its expiry.
+var errKeyExpired = errors.New("auth: API key expired")
+
+// storedKeyPrefix marks keys managed through the API. The key ID follows the
+// prefix, so the key can be looked up before its hash is checked.
+const storedKeyPrefix = "gsk_"
+
+// touchInterval limits how often the last-used timestamp of a key is written.
+const touchInterval = time.Minute
+
+// StoredKey is an API key managed through the API. Only a salted hash of the
+// secret is stored; the secret itself is returned once, when it is generated.
+type StoredKey struct {
+	ID                string
+	Name              string
+	Scopes            []string
+	Salt              string
+	Hash              string
+	PrevSalt          string // Salt of the secret replaced by the last rotation
+	PrevHash          string // Hash of the secret replaced by the last rotation, valid until PrevHashExpiresAt
+	PrevHashExpiresAt int64
+	ExpiresAt         *int64
+	LastUsedAt        *int64
+	RevokedAt         *int64
+	CreatedAt         int64
+	UpdatedAt         int64
+}
+
+// KeyInput holds the fields of a key set on creation.
+type KeyInput struct {
+	Name      string
+	Scopes    []string
+	ExpiresAt *int64
+}
+
+// Store persists keys managed through the API.
+type Store interface {
+	ListKeys(ctx context.Context) ([]StoredKey, error)
+	GetKey(ctx context.Context, id string) (*StoredKey, error)
+	InsertKey(ctx context.Context, k *StoredKey) error
+	UpdateKey(ctx context.Context, k *StoredKey) error
+	TouchKey(ctx context.Context, id string, usedAt int64) error
+}
+
+// Keyring authenticates requests against the keys from the config file and
+// those stored in the database, and manages the stored keys.
+type Keyring struct {
+	configured []configuredKey
+	store      Store
+}
+
+type configuredKey struct {
+	secret []byte
+	key    *Key
+}
+
+// NewKeyring creates a keyring accepting the keys in cfg and those in store.
+func NewKeyring(cfg config.AuthConfig, store Store) *Keyring {
+	var keys []configuredKey
+	for _, k := range Keys(cfg) {
+		keys = append(keys, configuredKey{
+			secret: []byte(k.Key),
+			key:    &Key{Name: k.Name, Scopes: k.Scopes},
+		})
+	}
+	for _, k := range cfg.PublishableKeys {
+		keys = append(keys, configuredKey{
+			secret: []byte(k.Key),
+			key:    &Key{Name: k.Name, Scopes: PublishableScopes, Publishable: true},
+		})
+	}
+	return &Keyring{configured: keys, store: store}
+}
+
+// Authenticate returns the key matching provided, or nil if there is none.
+func (k *Keyring) Authenticate(ctx context.Context, provided string) (*Key, error) {
+	if key := matchConfigured(k.configured, []byte(provided)); key != nil {
+		return key, nil
+	}
+
+	id, secret, ok := parseStoredKey(provided)
+	if !ok {
+		return nil, nil
+	}
+	stored, err := k.store.GetKey(ctx, id)
+	if err != nil || stored == nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if stored.RevokedAt != nil || !stored.matches(secret, now) {
+		return nil, nil
+	}
+	if stored.ExpiresAt != nil && now.Unix() >= *stored.ExpiresAt {
+		return nil, errKeyExpired
+	}
+
+	if stored.LastUsedAt == nil || now.Unix()-*stored.LastUsedAt >= int64(touchInterval/time.Second) {
+		if err := k.store.TouchKey(ctx, stored.ID, now.Unix()); err != nil {
+			// The timestamp is informational, don't fail the request over it.
+			logger.FromContext(ctx).Error("failed to record API key use", "error", err, "api_key_id", stored.ID)
+		}
+	}
+	return &Key{ID: stored.ID, Name: stored.Name, Scopes: stored.Scopes}, nil
+}
+
+// matchConfigured compares provided against every key, so the time taken
+// does not reveal which key, if any, matched.
+func matchConfigured(keys []configuredKey, provided []byte) *Key {
+	var matched *Key
+	for _, k := range keys {
+		if subtle.ConstantTimeCompare(provided, k.secret) == 1 && matched == nil {
+			matched = k.key
+		}
+	}
+	return matched
+}
+
+// ListKeys returns the stored keys, including revoked ones.
+func (k *Keyring) ListKeys(ctx context.Context) ([]StoredKey, error) {
+	return k.store.ListKeys(ctx)
+}
+
+// CreateKey stores a new key and returns it together with its secret.
+func (k *Keyring) CreateKey(ctx context.Context, in KeyInput) (*StoredKey, string, error) {
+	now := time.Now().Unix()
+	stored := &StoredKey{
+		ID:        uuid.Must(uuid.NewV7()).String(),
+		Name:      in.Name,
+		Scopes:    in.Scopes,
+		ExpiresAt: in.ExpiresAt,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	secret, err := stored.setSecret()
+	if err != nil {
+		return nil, "", err
+	}
+	if err := k.store.InsertKey(ctx, stored); err != nil {
+		return nil, "", err
+	}
+	return stored, secret, nil
+}
+
+// RevokeKey rejects a stored key from now on. Revoking a revoked key is a
+// no-op. It returns nil if the key does not exist.
+func (k *Keyring) RevokeKey(ctx context.Context, id string) (*StoredKey, error) {
+	stored, err := k.store.GetKey(ctx, id)
+	if err != nil || stored == nil || stored.RevokedAt != nil {
+		return stored, err
+	}
+
+	now := time.Now().Unix()
+	stored.RevokedAt = &now
+	stored.UpdatedAt = now
+	if err := k.store.UpdateKey(ctx, stored); err != nil {
+		return nil, err
+	}
+	return stored, nil
+}
+
+// RotateKey replaces a stored key's secret with a generated one. The previous
+// secret keeps working for the rollover duration, so clients can switch
+// without downtime. It returns nil if the key does not exist, ErrKeyRevoked
+// if it is revoked and ErrScopeNotHeld if the key the request was
+// authenticated with lacks one of its scopes.
+func (k *Keyring) RotateKey(ctx context.Context, id string, rollover time.Duration) (*StoredKey, string, error) {
+	stored, err := k.store.GetKey(ctx, id)
+	if err != nil || stored == nil {
+		return nil, "", err
+	}
+	if stored.RevokedAt != nil {
+		return nil, "", ErrKeyRevoked
+	}
+	if caller := KeyFromContext(ctx); caller != nil && !caller.HasScopes(stored.Scopes) {
+		return nil, "", ErrScopeNotHeld
+	}
+
+	now := time.Now()
+	stored.PrevSalt, stored.PrevHash, stored.PrevHashExpiresAt = "", "", 0
+	if rollover > 0 {
+		stored.PrevSalt, stored.PrevHash = stored.Salt, stored.Hash
+		stored.PrevHashExpiresAt = now.Add(rollover).Unix()
+	}
+	secret, err := stored.setSecret()
+	if err != nil {
+		return nil, "", err
+	}
+	stored.UpdatedAt = now.Unix()
+	if err := k.store.UpdateKey(ctx, stored); err != nil {
+		return nil, "", err
+	}
+	return stored, secret, nil
+}
+
+// setSecret generates a secret for the key, stores its salted hash and
+// returns the full key value clients authenticate with.
+func (s *StoredKey) setSecret() (string, error) {
+	buf := make([]byte, 48)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("auth: failed to generate key: %w", err)
+	}
+	salt := hex.EncodeToString(buf[:16])
+	secret := base64.RawURLEncoding.EncodeToString(buf[16:])
+
+	s.Salt = salt
+	s.Hash = hashSecret(salt, secret)
+	return storedKeyPrefix + s.ID + "_" + secret, nil
+}
+
+// matches reports whether secret is the key's current secret or, during a
+// rollover window, its previous one.
+func (s *StoredKey) matches(secret string, now time.Time) bool {
+	current := subtle.ConstantTimeCompare([]byte(hashSecret(s.Salt, secret)), []byte(s.Hash)) == 1
+	if current {
+		return true
+	}
+	if s.PrevHash == "" || now.Unix() >= s.PrevHashExpiresAt {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(hashSecret(s.PrevSalt, secret)), []byte(s.PrevHash)) == 1
+}
+
+func hashSecret(salt, secret string) string {
+	sum := sha256.Sum256([]byte(salt + secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// parseStoredKey splits a "gsk_<id>_<secret>" key into its ID and secret.
+func parseStoredKey(provided string) (id, secret string, ok bool) {
+	rest, ok := strings.CutPrefix(provided, storedKeyPrefix)
+	if !ok {
+		return "", "", false
+	}
+	id, secret, ok = strings.Cut(rest, "_")
+	if !ok || id == "" || secret == "" {
+		return "", "", false
+	}
+	return id, secret, true
+}
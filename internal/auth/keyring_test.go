@@ -0,0 +1,158 @@
+package auth_test
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grantsy/grantsy/internal/auth"
+	"github.com/grantsy/grantsy/internal/infra/config"
+	"github.com/grantsy/grantsy/internal/infra/db"
+)
+
+func newSQLiteKeyring(t *testing.T) *auth.Keyring {
+	t.Helper()
+
+	dsn := filepath.Join(t.TempDir(), "test.db")
+	require.NoError(t, db.Migrate("sqlite", dsn, ""), "sqlite migration failed")
+
+	database, err := db.New("sqlite", dsn, "")
+	require.NoError(t, err, "sqlite connection failed")
+	t.Cleanup(func() { database.Close() })
+
+	return auth.NewKeyring(config.AuthConfig{APIKeys: testKeys}, auth.NewRepo(database))
+}
+
+func TestKeyring_CreateKey(t *testing.T) {
+	ctx := context.Background()
+	keyring := newSQLiteKeyring(t)
+
+	created, value, err := keyring.CreateKey(ctx, auth.KeyInput{
+		Name:   "backend",
+		Scopes: []string{auth.ScopeCheckRead},
+	})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(value, "gsk_"+created.ID+"_"))
+	assert.NotContains(t, created.Hash, strings.TrimPrefix(value, "gsk_"+created.ID+"_"))
+
+	key, err := keyring.Authenticate(ctx, value)
+	require.NoError(t, err)
+	require.NotNil(t, key)
+	assert.Equal(t, &auth.Key{ID: created.ID, Name: "backend", Scopes: []string{auth.ScopeCheckRead}}, key)
+
+	keys, err := keyring.ListKeys(ctx)
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	require.NotNil(t, keys[0].LastUsedAt, "authentication records the last use")
+
+	wrong, err := keyring.Authenticate(ctx, "gsk_"+created.ID+"_wrong")
+	require.NoError(t, err)
+	assert.Nil(t, wrong)
+
+	missing, err := keyring.Authenticate(ctx, "gsk_missing_secret")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
+func TestKeyring_ConfiguredKeys(t *testing.T) {
+	keyring := newSQLiteKeyring(t)
+
+	key, err := keyring.Authenticate(context.Background(), "pricing-key")
+	require.NoError(t, err)
+	require.NotNil(t, key)
+	assert.Equal(t, "pricing-page", key.Name)
+}
+
+func TestKeyring_ExpiredKey(t *testing.T) {
+	ctx := context.Background()
+	keyring := newSQLiteKeyring(t)
+
+	expiresAt := time.Now().Add(-time.Minute).Unix()
+	_, value, err := keyring.CreateKey(ctx, auth.KeyInput{
+		Name:      "temporary",
+		Scopes:    []string{auth.ScopeCheckRead},
+		ExpiresAt: &expiresAt,
+	})
+	require.NoError(t, err)
+
+	key, err := keyring.Authenticate(ctx, value)
+	require.Error(t, err)
+	assert.Nil(t, key)
+}
+
+func TestKeyring_RevokeKey(t *testing.T) {
+	ctx := context.Background()
+	keyring := newSQLiteKeyring(t)
+
+	created, value, err := keyring.CreateKey(ctx, auth.KeyInput{Name: "backend", Scopes: auth.AllScopes})
+	require.NoError(t, err)
+
+	revoked, err := keyring.RevokeKey(ctx, created.ID)
+	require.NoError(t, err)
+	require.NotNil(t, revoked.RevokedAt)
+
+	key, err := keyring.Authenticate(ctx, value)
+	require.NoError(t, err)
+	assert.Nil(t, key)
+
+	_, _, err = keyring.RotateKey(ctx, created.ID, 0)
+	require.ErrorIs(t, err, auth.ErrKeyRevoked)
+
+	missing, err := keyring.RevokeKey(ctx, "missing")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
+func TestKeyring_RotateKey(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		rollover   time.Duration
+		previousOK bool
+	}{
+		{name: "rollover keeps previous key", rollover: time.Hour, previousOK: true},
+		{name: "no rollover rejects previous key", rollover: 0, previousOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keyring := newSQLiteKeyring(t)
+
+			created, previous, err := keyring.CreateKey(ctx, auth.KeyInput{Name: "backend", Scopes: auth.AllScopes})
+			require.NoError(t, err)
+
+			rotated, current, err := keyring.RotateKey(ctx, created.ID, tt.rollover)
+			require.NoError(t, err)
+			assert.NotEqual(t, previous, current)
+			assert.Equal(t, created.ID, rotated.ID)
+
+			key, err := keyring.Authenticate(ctx, current)
+			require.NoError(t, err)
+			assert.NotNil(t, key)
+
+			key, err = keyring.Authenticate(ctx, previous)
+			require.NoError(t, err)
+			assert.Equal(t, tt.previousOK, key != nil)
+		})
+	}
+}
+
+func TestKeyring_RotateKey_ScopeNotHeld(t *testing.T) {
+	keyring := newSQLiteKeyring(t)
+
+	created, _, err := keyring.CreateKey(context.Background(), auth.KeyInput{
+		Name:   "auditor",
+		Scopes: []string{auth.ScopeAuditRead},
+	})
+	require.NoError(t, err)
+
+	ctx := auth.WithKey(context.Background(), &auth.Key{Name: "admin", Scopes: []string{auth.ScopeAdminWrite}})
+	_, _, err = keyring.RotateKey(ctx, created.ID, 0)
+	require.ErrorIs(t, err, auth.ErrScopeNotHeld)
+}
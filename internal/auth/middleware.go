@@ -1,21 +1,108 @@
 package auth
 
 import (
-	"crypto/subtle"
+	"context"
+	"errors"
 	"net/http"
+	"slices"
+	"strings"
 
 	"github.com/grantsy/grantsy/internal/httptools"
+	"github.com/grantsy/grantsy/internal/infra/config"
+	"github.com/grantsy/grantsy/internal/infra/logger"
 )
 
 const headerName = "X-Api-Key"
 
-func Middleware(apiKey string) httptools.Middleware {
-	keyBytes := []byte(apiKey)
+// Scopes granted to API keys.
+const (
+	ScopeCheckRead  = "check:read"
+	ScopePlansRead  = "plans:read"
+	ScopeUsersRead  = "users:read"
+	ScopeAdminWrite = "admin:write"
+	ScopeAuditRead  = "audit:read"
+)
+
+// AllScopes lists every scope, in the order they are documented.
+var AllScopes = []string{ScopeCheckRead, ScopePlansRead, ScopeUsersRead, ScopeAdminWrite, ScopeAuditRead}
+
+// PublishableScopes are the scopes granted to publishable keys, limited to
+// the user in the request's user token.
+var PublishableScopes = []string{ScopeCheckRead, ScopeUsersRead}
+
+// DefaultKeyName names the key configured with the single auth.api_key setting.
+const DefaultKeyName = "default"
+
+// Key is an authenticated API key. A publishable key only grants access to
+// the data of Subject, the user its user token was issued for.
+type Key struct {
+	ID          string // Stored key ID, empty for configured keys and bearer tokens
+	Name        string
+	Scopes      []string
+	Publishable bool
+	Bearer      bool // Authenticated with a bearer token
+	Subject     string
+}
+
+// HasScope reports whether the key grants scope.
+func (k *Key) HasScope(scope string) bool {
+	return slices.Contains(k.Scopes, scope)
+}
+
+// HasScopes reports whether the key grants every scope in scopes.
+func (k *Key) HasScopes(scopes []string) bool {
+	for _, scope := range scopes {
+		if !k.HasScope(scope) {
+			return false
+		}
+	}
+	return true
+}
+
+type keyContextKey struct{}
+
+// KeyFromContext returns the API key the request was authenticated with,
+// or nil if it was not.
+func KeyFromContext(ctx context.Context) *Key {
+	key, _ := ctx.Value(keyContextKey{}).(*Key)
+	return key
+}
+
+// WithKey returns a copy of ctx carrying the authenticated key.
+func WithKey(ctx context.Context, key *Key) context.Context {
+	return context.WithValue(ctx, keyContextKey{}, key)
+}
+
+// Keys returns the API keys in cfg: the named keys, plus the single api_key
+// with every scope if set.
+func Keys(cfg config.AuthConfig) []config.APIKey {
+	keys := slices.Clone(cfg.APIKeys)
+	if cfg.APIKey != "" {
+		keys = append(keys, config.APIKey{
+			Name:   DefaultKeyName,
+			Key:    cfg.APIKey,
+			Scopes: AllScopes,
+		})
+	}
+	return keys
+}
+
+// Authenticator resolves the key a request was made with.
+type Authenticator interface {
+	Authenticate(ctx context.Context, provided string) (*Key, error)
+}
 
+// Middleware authenticates requests by the X-Api-Key header or, if bearer is
+// set, by a JWT in the Authorization header. Requests with a publishable key
+// must also carry a user token in the X-User-Token header, verified by users.
+// The matched key is stored in the request context and its name is added to
+// the request logger.
+func Middleware(authenticator Authenticator, users UserTokenVerifier, bearer TokenAuthenticator) httptools.Middleware {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			provided := r.Header.Get(headerName)
-			if provided == "" {
+			token, hasBearer := bearerToken(r)
+			if provided == "" && (!hasBearer || bearer == nil) {
 				httptools.Error(w, r, http.StatusUnauthorized,
 					httptools.ErrTypeUnauthorized,
 					"Unauthorized",
@@ -24,7 +111,18 @@ func Middleware(apiKey string) httptools.Middleware {
 				return
 			}
 
-			if subtle.ConstantTimeCompare([]byte(provided), keyBytes) != 1 {
+			var key *Key
+			var err error
+			if provided != "" {
+				key, err = authenticateKey(r, authenticator, users, provided)
+			} else {
+				key, err = bearer.AuthenticateToken(r.Context(), token)
+			}
+			if err != nil {
+				writeAuthError(w, r, err, provided == "")
+				return
+			}
+			if key == nil {
 				httptools.Error(w, r, http.StatusUnauthorized,
 					httptools.ErrTypeUnauthorized,
 					"Unauthorized",
@@ -33,6 +131,145 @@ func Middleware(apiKey string) httptools.Middleware {
 				return
 			}
 
+			logger.With(r.Context(), "api_key", key.Name)
+			next.ServeHTTP(w, r.WithContext(WithKey(r.Context(), key)))
+		})
+	}
+}
+
+// bearerToken returns the token in the request's Authorization header.
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return "", false
+	}
+	return token, true
+}
+
+// authenticateKey resolves an X-Api-Key value, limiting publishable keys to
+// the user in the request's user token.
+func authenticateKey(r *http.Request, authenticator Authenticator, users UserTokenVerifier, provided string) (*Key, error) {
+	key, err := authenticator.Authenticate(r.Context(), provided)
+	if err != nil || key == nil || !key.Publishable {
+		return key, err
+	}
+	return authenticateUser(r, key, users)
+}
+
+// errMissingUserToken is returned for publishable key requests without a user token.
+var errMissingUserToken = errors.New("auth: missing user token")
+
+// authenticateUser returns a copy of the publishable key limited to the user
+// in the request's user token.
+func authenticateUser(r *http.Request, key *Key, users UserTokenVerifier) (*Key, error) {
+	token := r.Header.Get(userTokenHeader)
+	if token == "" {
+		return nil, errMissingUserToken
+	}
+	if users == nil {
+		return nil, errors.New("auth: no user token verifier configured")
+	}
+	subject, err := users.VerifyUserToken(r.Context(), token)
+	if err != nil {
+		return nil, err
+	}
+	limited := *key
+	limited.Subject = subject
+	return &limited, nil
+}
+
+func writeAuthError(w http.ResponseWriter, r *http.Request, err error, isBearer bool) {
+	switch {
+	case errors.Is(err, errKeyExpired):
+		httptools.Error(w, r, http.StatusUnauthorized,
+			httptools.ErrTypeUnauthorized,
+			"Unauthorized",
+			"API key expired",
+		)
+	case errors.Is(err, errMissingUserToken):
+		httptools.Error(w, r, http.StatusUnauthorized,
+			httptools.ErrTypeUnauthorized,
+			"Unauthorized",
+			"Missing user token",
+		)
+	case errors.Is(err, errInvalidToken) && isBearer:
+		logger.FromContext(r.Context()).Info("rejected bearer token", "error", err)
+		w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+		httptools.Error(w, r, http.StatusUnauthorized,
+			httptools.ErrTypeUnauthorized,
+			"Unauthorized",
+			"Invalid bearer token",
+		)
+	case errors.Is(err, errInvalidToken):
+		logger.FromContext(r.Context()).Info("rejected user token", "error", err)
+		httptools.Error(w, r, http.StatusUnauthorized,
+			httptools.ErrTypeUnauthorized,
+			"Unauthorized",
+			"Invalid user token",
+		)
+	default:
+		logger.FromContext(r.Context()).Error("failed to authenticate request", "error", err)
+		httptools.InternalError(w, r)
+	}
+}
+
+// UserIDFromPath reads the user_id path parameter, for RequireUserScope.
+func UserIDFromPath(r *http.Request) string {
+	return r.PathValue("user_id")
+}
+
+// UserIDFromQuery reads the user_id query parameter, for RequireUserScope.
+func UserIDFromQuery(r *http.Request) string {
+	return r.URL.Query().Get("user_id")
+}
+
+// RequireScope rejects requests whose API key lacks scope with 403 Forbidden,
+// and requests without an authenticated key with 401 Unauthorized.
+// Publishable keys are always rejected; endpoints open to them use
+// RequireUserScope.
+func RequireScope(scope string) httptools.Middleware {
+	return requireScope(scope, nil)
+}
+
+// RequireUserScope is RequireScope for endpoints reading a single user's
+// data. Publishable keys are accepted when userID returns the user in their
+// user token.
+func RequireUserScope(scope string, userID func(*http.Request) string) httptools.Middleware {
+	return requireScope(scope, userID)
+}
+
+func requireScope(scope string, userID func(*http.Request) string) httptools.Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := KeyFromContext(r.Context())
+			if key == nil {
+				httptools.Error(w, r, http.StatusUnauthorized,
+					httptools.ErrTypeUnauthorized,
+					"Unauthorized",
+					"Missing API key",
+				)
+				return
+			}
+			if !key.HasScope(scope) {
+				httptools.Error(w, r, http.StatusForbidden,
+					httptools.ErrTypeForbidden,
+					"Forbidden",
+					"API key is missing the "+scope+" scope",
+				)
+				return
+			}
+			if key.Publishable && (userID == nil || userID(r) != key.Subject) {
+				detail := "Publishable keys can only read the user in their user token"
+				if userID == nil {
+					detail = "Publishable keys can't access this endpoint"
+				}
+				httptools.Error(w, r, http.StatusForbidden,
+					httptools.ErrTypeForbidden,
+					"Forbidden",
+					detail,
+				)
+				return
+			}
 			next.ServeHTTP(w, r)
 		})
 	}
@@ -5,20 +5,35 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/grantsy/grantsy/internal/auth"
 	"github.com/grantsy/grantsy/internal/httptools"
+	"github.com/grantsy/grantsy/internal/infra/config"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 const testAPIKey = "test-secret-key"
 
+var testKeys = []config.APIKey{
+	{Name: "pricing-page", Key: "pricing-key", Scopes: []string{auth.ScopeCheckRead, auth.ScopePlansRead}},
+	{Name: "admin", Key: testAPIKey, Scopes: auth.AllScopes},
+}
+
 func setupMiddleware() http.Handler {
 	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusOK)
 	})
-	return auth.Middleware(testAPIKey)(next)
+	return auth.Middleware(auth.NewKeyring(config.AuthConfig{APIKeys: testKeys}, nil), nil, nil)(next)
+}
+
+// setupScopedMiddleware authenticates requests and requires scope.
+func setupScopedMiddleware(scope string) http.Handler {
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	return httptools.Wrap(next, auth.Middleware(auth.NewKeyring(config.AuthConfig{APIKeys: testKeys}, nil), nil, nil), auth.RequireScope(scope))
 }
 
 func TestMiddleware_MissingKey(t *testing.T) {
@@ -79,3 +94,153 @@ func TestMiddleware_EmptyKeyInHeader(t *testing.T) {
 	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
 	assert.Equal(t, "Missing API key", resp.Error.Detail)
 }
+
+func TestMiddleware_StoresKeyInContext(t *testing.T) {
+	var got *auth.Key
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = auth.KeyFromContext(r.Context())
+	})
+	handler := auth.Middleware(auth.NewKeyring(config.AuthConfig{APIKeys: testKeys}, nil), nil, nil)(next)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-Api-Key", "pricing-key")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	require.NotNil(t, got)
+	assert.Equal(t, "pricing-page", got.Name)
+	assert.Equal(t, []string{auth.ScopeCheckRead, auth.ScopePlansRead}, got.Scopes)
+}
+
+func TestRequireScope_Granted(t *testing.T) {
+	handler := setupScopedMiddleware(auth.ScopePlansRead)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-Api-Key", "pricing-key")
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestRequireScope_MissingScope(t *testing.T) {
+	handler := setupScopedMiddleware(auth.ScopeAdminWrite)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-Api-Key", "pricing-key")
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	var resp httptools.ErrorResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, "API key is missing the admin:write scope", resp.Error.Detail)
+}
+
+func TestRequireScope_Unauthenticated(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := auth.RequireScope(auth.ScopeCheckRead)(next)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestKeys_SingleAPIKeyHasAllScopes(t *testing.T) {
+	keys := auth.Keys(config.AuthConfig{APIKey: "legacy", APIKeys: testKeys})
+
+	require.Len(t, keys, 3)
+	assert.Equal(t, config.APIKey{Name: auth.DefaultKeyName, Key: "legacy", Scopes: auth.AllScopes}, keys[2])
+}
+
+// setupPublishable authenticates requests with a publishable key and
+// requires users:read for the user in the path.
+func setupPublishable(t *testing.T, next http.Handler) http.Handler {
+	t.Helper()
+	cfg := config.AuthConfig{
+		APIKeys:         testKeys,
+		PublishableKeys: []config.PublishableKey{{Name: "web", Key: "pk_web"}},
+	}
+	tokens, err := auth.NewUserTokens(&config.UserTokensConfig{HMACSecret: testHMACSecret})
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /users/{user_id}", next)
+	return auth.Middleware(auth.NewKeyring(cfg, nil), tokens, nil)(mux)
+}
+
+func TestMiddleware_PublishableKey(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	userScoped := setupPublishable(t, auth.RequireUserScope(auth.ScopeUsersRead, auth.UserIDFromPath)(ok))
+	adminOnly := setupPublishable(t, auth.RequireScope(auth.ScopeUsersRead)(ok))
+	token := hmacToken(t, testHMACSecret, userClaims("user-1", 5*time.Minute))
+
+	tests := []struct {
+		name       string
+		handler    http.Handler
+		target     string
+		token      string
+		wantCode   int
+		wantDetail string
+	}{
+		{name: "own user", handler: userScoped, target: "/users/user-1", token: token, wantCode: http.StatusOK},
+		{
+			name: "other user", handler: userScoped, target: "/users/user-2", token: token,
+			wantCode: http.StatusForbidden, wantDetail: "Publishable keys can only read the user in their user token",
+		},
+		{
+			name: "missing token", handler: userScoped, target: "/users/user-1",
+			wantCode: http.StatusUnauthorized, wantDetail: "Missing user token",
+		},
+		{
+			name: "invalid token", handler: userScoped, target: "/users/user-1", token: "invalid",
+			wantCode: http.StatusUnauthorized, wantDetail: "Invalid user token",
+		},
+		{
+			name: "endpoint without user scope", handler: adminOnly, target: "/users/user-1", token: token,
+			wantCode: http.StatusForbidden, wantDetail: "Publishable keys can't access this endpoint",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			req.Header.Set("X-Api-Key", "pk_web")
+			if tt.token != "" {
+				req.Header.Set("X-User-Token", tt.token)
+			}
+			rec := httptest.NewRecorder()
+
+			tt.handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantCode, rec.Code)
+			if tt.wantDetail != "" {
+				var resp httptools.ErrorResponse
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				assert.Equal(t, tt.wantDetail, resp.Error.Detail)
+			}
+		})
+	}
+}
+
+func TestRequireUserScope_SecretKeyReadsAnyUser(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := setupPublishable(t, auth.RequireUserScope(auth.ScopeUsersRead, auth.UserIDFromPath)(ok))
+
+	req := httptest.NewRequest(http.MethodGet, "/users/user-2", nil)
+	req.Header.Set("X-Api-Key", testAPIKey)
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
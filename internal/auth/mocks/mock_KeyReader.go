@@ -0,0 +1,96 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	auth "github.com/grantsy/grantsy/internal/auth"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockKeyReader is an autogenerated mock type for the KeyReader type
+type MockKeyReader struct {
+	mock.Mock
+}
+
+type MockKeyReader_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockKeyReader) EXPECT() *MockKeyReader_Expecter {
+	return &MockKeyReader_Expecter{mock: &_m.Mock}
+}
+
+// ListKeys provides a mock function with given fields: ctx
+func (_m *MockKeyReader) ListKeys(ctx context.Context) ([]auth.StoredKey, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListKeys")
+	}
+
+	var r0 []auth.StoredKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]auth.StoredKey, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []auth.StoredKey); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]auth.StoredKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockKeyReader_ListKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListKeys'
+type MockKeyReader_ListKeys_Call struct {
+	*mock.Call
+}
+
+// ListKeys is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockKeyReader_Expecter) ListKeys(ctx interface{}) *MockKeyReader_ListKeys_Call {
+	return &MockKeyReader_ListKeys_Call{Call: _e.mock.On("ListKeys", ctx)}
+}
+
+func (_c *MockKeyReader_ListKeys_Call) Run(run func(ctx context.Context)) *MockKeyReader_ListKeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockKeyReader_ListKeys_Call) Return(_a0 []auth.StoredKey, _a1 error) *MockKeyReader_ListKeys_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockKeyReader_ListKeys_Call) RunAndReturn(run func(context.Context) ([]auth.StoredKey, error)) *MockKeyReader_ListKeys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockKeyReader creates a new instance of MockKeyReader. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockKeyReader(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockKeyReader {
+	mock := &MockKeyReader{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
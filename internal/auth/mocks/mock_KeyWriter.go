@@ -0,0 +1,232 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	auth "github.com/grantsy/grantsy/internal/auth"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MockKeyWriter is an autogenerated mock type for the KeyWriter type
+type MockKeyWriter struct {
+	mock.Mock
+}
+
+type MockKeyWriter_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockKeyWriter) EXPECT() *MockKeyWriter_Expecter {
+	return &MockKeyWriter_Expecter{mock: &_m.Mock}
+}
+
+// CreateKey provides a mock function with given fields: ctx, in
+func (_m *MockKeyWriter) CreateKey(ctx context.Context, in auth.KeyInput) (*auth.StoredKey, string, error) {
+	ret := _m.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateKey")
+	}
+
+	var r0 *auth.StoredKey
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, auth.KeyInput) (*auth.StoredKey, string, error)); ok {
+		return rf(ctx, in)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, auth.KeyInput) *auth.StoredKey); ok {
+		r0 = rf(ctx, in)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*auth.StoredKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, auth.KeyInput) string); ok {
+		r1 = rf(ctx, in)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, auth.KeyInput) error); ok {
+		r2 = rf(ctx, in)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockKeyWriter_CreateKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateKey'
+type MockKeyWriter_CreateKey_Call struct {
+	*mock.Call
+}
+
+// CreateKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in auth.KeyInput
+func (_e *MockKeyWriter_Expecter) CreateKey(ctx interface{}, in interface{}) *MockKeyWriter_CreateKey_Call {
+	return &MockKeyWriter_CreateKey_Call{Call: _e.mock.On("CreateKey", ctx, in)}
+}
+
+func (_c *MockKeyWriter_CreateKey_Call) Run(run func(ctx context.Context, in auth.KeyInput)) *MockKeyWriter_CreateKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(auth.KeyInput))
+	})
+	return _c
+}
+
+func (_c *MockKeyWriter_CreateKey_Call) Return(_a0 *auth.StoredKey, _a1 string, _a2 error) *MockKeyWriter_CreateKey_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockKeyWriter_CreateKey_Call) RunAndReturn(run func(context.Context, auth.KeyInput) (*auth.StoredKey, string, error)) *MockKeyWriter_CreateKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeKey provides a mock function with given fields: ctx, id
+func (_m *MockKeyWriter) RevokeKey(ctx context.Context, id string) (*auth.StoredKey, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeKey")
+	}
+
+	var r0 *auth.StoredKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*auth.StoredKey, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *auth.StoredKey); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*auth.StoredKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockKeyWriter_RevokeKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeKey'
+type MockKeyWriter_RevokeKey_Call struct {
+	*mock.Call
+}
+
+// RevokeKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockKeyWriter_Expecter) RevokeKey(ctx interface{}, id interface{}) *MockKeyWriter_RevokeKey_Call {
+	return &MockKeyWriter_RevokeKey_Call{Call: _e.mock.On("RevokeKey", ctx, id)}
+}
+
+func (_c *MockKeyWriter_RevokeKey_Call) Run(run func(ctx context.Context, id string)) *MockKeyWriter_RevokeKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockKeyWriter_RevokeKey_Call) Return(_a0 *auth.StoredKey, _a1 error) *MockKeyWriter_RevokeKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockKeyWriter_RevokeKey_Call) RunAndReturn(run func(context.Context, string) (*auth.StoredKey, error)) *MockKeyWriter_RevokeKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RotateKey provides a mock function with given fields: ctx, id, rollover
+func (_m *MockKeyWriter) RotateKey(ctx context.Context, id string, rollover time.Duration) (*auth.StoredKey, string, error) {
+	ret := _m.Called(ctx, id, rollover)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RotateKey")
+	}
+
+	var r0 *auth.StoredKey
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) (*auth.StoredKey, string, error)); ok {
+		return rf(ctx, id, rollover)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) *auth.StoredKey); ok {
+		r0 = rf(ctx, id, rollover)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*auth.StoredKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration) string); ok {
+		r1 = rf(ctx, id, rollover)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, time.Duration) error); ok {
+		r2 = rf(ctx, id, rollover)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockKeyWriter_RotateKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RotateKey'
+type MockKeyWriter_RotateKey_Call struct {
+	*mock.Call
+}
+
+// RotateKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - rollover time.Duration
+func (_e *MockKeyWriter_Expecter) RotateKey(ctx interface{}, id interface{}, rollover interface{}) *MockKeyWriter_RotateKey_Call {
+	return &MockKeyWriter_RotateKey_Call{Call: _e.mock.On("RotateKey", ctx, id, rollover)}
+}
+
+func (_c *MockKeyWriter_RotateKey_Call) Run(run func(ctx context.Context, id string, rollover time.Duration)) *MockKeyWriter_RotateKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockKeyWriter_RotateKey_Call) Return(_a0 *auth.StoredKey, _a1 string, _a2 error) *MockKeyWriter_RotateKey_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockKeyWriter_RotateKey_Call) RunAndReturn(run func(context.Context, string, time.Duration) (*auth.StoredKey, string, error)) *MockKeyWriter_RotateKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockKeyWriter creates a new instance of MockKeyWriter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockKeyWriter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockKeyWriter {
+	mock := &MockKeyWriter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,297 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	auth "github.com/grantsy/grantsy/internal/auth"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockStore is an autogenerated mock type for the Store type
+type MockStore struct {
+	mock.Mock
+}
+
+type MockStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockStore) EXPECT() *MockStore_Expecter {
+	return &MockStore_Expecter{mock: &_m.Mock}
+}
+
+// GetKey provides a mock function with given fields: ctx, id
+func (_m *MockStore) GetKey(ctx context.Context, id string) (*auth.StoredKey, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetKey")
+	}
+
+	var r0 *auth.StoredKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*auth.StoredKey, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *auth.StoredKey); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*auth.StoredKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_GetKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetKey'
+type MockStore_GetKey_Call struct {
+	*mock.Call
+}
+
+// GetKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockStore_Expecter) GetKey(ctx interface{}, id interface{}) *MockStore_GetKey_Call {
+	return &MockStore_GetKey_Call{Call: _e.mock.On("GetKey", ctx, id)}
+}
+
+func (_c *MockStore_GetKey_Call) Run(run func(ctx context.Context, id string)) *MockStore_GetKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockStore_GetKey_Call) Return(_a0 *auth.StoredKey, _a1 error) *MockStore_GetKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_GetKey_Call) RunAndReturn(run func(context.Context, string) (*auth.StoredKey, error)) *MockStore_GetKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertKey provides a mock function with given fields: ctx, k
+func (_m *MockStore) InsertKey(ctx context.Context, k *auth.StoredKey) error {
+	ret := _m.Called(ctx, k)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *auth.StoredKey) error); ok {
+		r0 = rf(ctx, k)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_InsertKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertKey'
+type MockStore_InsertKey_Call struct {
+	*mock.Call
+}
+
+// InsertKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - k *auth.StoredKey
+func (_e *MockStore_Expecter) InsertKey(ctx interface{}, k interface{}) *MockStore_InsertKey_Call {
+	return &MockStore_InsertKey_Call{Call: _e.mock.On("InsertKey", ctx, k)}
+}
+
+func (_c *MockStore_InsertKey_Call) Run(run func(ctx context.Context, k *auth.StoredKey)) *MockStore_InsertKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*auth.StoredKey))
+	})
+	return _c
+}
+
+func (_c *MockStore_InsertKey_Call) Return(_a0 error) *MockStore_InsertKey_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_InsertKey_Call) RunAndReturn(run func(context.Context, *auth.StoredKey) error) *MockStore_InsertKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListKeys provides a mock function with given fields: ctx
+func (_m *MockStore) ListKeys(ctx context.Context) ([]auth.StoredKey, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListKeys")
+	}
+
+	var r0 []auth.StoredKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]auth.StoredKey, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []auth.StoredKey); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]auth.StoredKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_ListKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListKeys'
+type MockStore_ListKeys_Call struct {
+	*mock.Call
+}
+
+// ListKeys is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockStore_Expecter) ListKeys(ctx interface{}) *MockStore_ListKeys_Call {
+	return &MockStore_ListKeys_Call{Call: _e.mock.On("ListKeys", ctx)}
+}
+
+func (_c *MockStore_ListKeys_Call) Run(run func(ctx context.Context)) *MockStore_ListKeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockStore_ListKeys_Call) Return(_a0 []auth.StoredKey, _a1 error) *MockStore_ListKeys_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_ListKeys_Call) RunAndReturn(run func(context.Context) ([]auth.StoredKey, error)) *MockStore_ListKeys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TouchKey provides a mock function with given fields: ctx, id, usedAt
+func (_m *MockStore) TouchKey(ctx context.Context, id string, usedAt int64) error {
+	ret := _m.Called(ctx, id, usedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TouchKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) error); ok {
+		r0 = rf(ctx, id, usedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_TouchKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TouchKey'
+type MockStore_TouchKey_Call struct {
+	*mock.Call
+}
+
+// TouchKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - usedAt int64
+func (_e *MockStore_Expecter) TouchKey(ctx interface{}, id interface{}, usedAt interface{}) *MockStore_TouchKey_Call {
+	return &MockStore_TouchKey_Call{Call: _e.mock.On("TouchKey", ctx, id, usedAt)}
+}
+
+func (_c *MockStore_TouchKey_Call) Run(run func(ctx context.Context, id string, usedAt int64)) *MockStore_TouchKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockStore_TouchKey_Call) Return(_a0 error) *MockStore_TouchKey_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_TouchKey_Call) RunAndReturn(run func(context.Context, string, int64) error) *MockStore_TouchKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateKey provides a mock function with given fields: ctx, k
+func (_m *MockStore) UpdateKey(ctx context.Context, k *auth.StoredKey) error {
+	ret := _m.Called(ctx, k)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *auth.StoredKey) error); ok {
+		r0 = rf(ctx, k)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_UpdateKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateKey'
+type MockStore_UpdateKey_Call struct {
+	*mock.Call
+}
+
+// UpdateKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - k *auth.StoredKey
+func (_e *MockStore_Expecter) UpdateKey(ctx interface{}, k interface{}) *MockStore_UpdateKey_Call {
+	return &MockStore_UpdateKey_Call{Call: _e.mock.On("UpdateKey", ctx, k)}
+}
+
+func (_c *MockStore_UpdateKey_Call) Run(run func(ctx context.Context, k *auth.StoredKey)) *MockStore_UpdateKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*auth.StoredKey))
+	})
+	return _c
+}
+
+func (_c *MockStore_UpdateKey_Call) Return(_a0 error) *MockStore_UpdateKey_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_UpdateKey_Call) RunAndReturn(run func(context.Context, *auth.StoredKey) error) *MockStore_UpdateKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockStore creates a new instance of MockStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockStore {
+	mock := &MockStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
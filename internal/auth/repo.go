@@ -0,0 +1,166 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/grantsy/grantsy/internal/infra/db"
+)
+
+type Repo struct {
+	db *db.DB
+}
+
+func NewRepo(database *db.DB) *Repo {
+	return &Repo{db: database}
+}
+
+const keyColumns = `id, name, scopes, salt, hash, prev_salt, prev_hash,
+			prev_hash_expires_at, expires_at, last_used_at, revoked_at,
+			created_at, updated_at`
+
+// ListKeys returns the stored keys, oldest first.
+func (r *Repo) ListKeys(ctx context.Context) ([]StoredKey, error) {
+	table := r.db.TableName("api_keys")
+	query := fmt.Sprintf(`SELECT %s FROM %s ORDER BY id`, keyColumns, table)
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("auth: failed to query API keys: %w", err)
+	}
+	defer rows.Close()
+
+	var result []StoredKey
+	for rows.Next() {
+		k, err := scanKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("auth: failed to scan row: %w", err)
+		}
+		result = append(result, *k)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("auth: rows error: %w", err)
+	}
+
+	return result, nil
+}
+
+// GetKey returns the key with the given ID, or nil if not found.
+func (r *Repo) GetKey(ctx context.Context, id string) (*StoredKey, error) {
+	table := r.db.TableName("api_keys")
+	query := r.db.Rebind(fmt.Sprintf(`
+		SELECT %s FROM %s WHERE id = $1
+	`, keyColumns, table))
+
+	k, err := scanKey(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("auth: failed to get API key: %w", err)
+	}
+	return k, nil
+}
+
+// InsertKey stores a new key.
+func (r *Repo) InsertKey(ctx context.Context, k *StoredKey) error {
+	scopes, err := json.Marshal(k.Scopes)
+	if err != nil {
+		return fmt.Errorf("auth: failed to marshal scopes: %w", err)
+	}
+
+	table := r.db.TableName("api_keys")
+	query := r.db.Rebind(fmt.Sprintf(`
+		INSERT INTO %s (%s)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	`, table, keyColumns))
+
+	_, err = r.db.ExecContext(
+		ctx,
+		query,
+		k.ID,
+		k.Name,
+		string(scopes),
+		k.Salt,
+		k.Hash,
+		k.PrevSalt,
+		k.PrevHash,
+		k.PrevHashExpiresAt,
+		k.ExpiresAt,
+		k.LastUsedAt,
+		k.RevokedAt,
+		k.CreatedAt,
+		k.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("auth: failed to insert API key: %w", err)
+	}
+	return nil
+}
+
+// UpdateKey overwrites the secret, expiry and revocation of a stored key.
+// The last-used timestamp is only written by TouchKey.
+func (r *Repo) UpdateKey(ctx context.Context, k *StoredKey) error {
+	table := r.db.TableName("api_keys")
+	query := r.db.Rebind(fmt.Sprintf(`
+		UPDATE %s SET
+			salt = $1,
+			hash = $2,
+			prev_salt = $3,
+			prev_hash = $4,
+			prev_hash_expires_at = $5,
+			expires_at = $6,
+			revoked_at = $7,
+			updated_at = $8
+		WHERE id = $9
+	`, table))
+
+	_, err := r.db.ExecContext(
+		ctx,
+		query,
+		k.Salt,
+		k.Hash,
+		k.PrevSalt,
+		k.PrevHash,
+		k.PrevHashExpiresAt,
+		k.ExpiresAt,
+		k.RevokedAt,
+		k.UpdatedAt,
+		k.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("auth: failed to update API key: %w", err)
+	}
+	return nil
+}
+
+// TouchKey records when a key was last used.
+func (r *Repo) TouchKey(ctx context.Context, id string, usedAt int64) error {
+	table := r.db.TableName("api_keys")
+	query := r.db.Rebind(fmt.Sprintf(`UPDATE %s SET last_used_at = $1 WHERE id = $2`, table))
+
+	if _, err := r.db.ExecContext(ctx, query, usedAt, id); err != nil {
+		return fmt.Errorf("auth: failed to record API key use: %w", err)
+	}
+	return nil
+}
+
+func scanKey(row interface{ Scan(dest ...any) error }) (*StoredKey, error) {
+	var k StoredKey
+	var scopes string
+	if err := row.Scan(
+		&k.ID, &k.Name, &scopes, &k.Salt, &k.Hash, &k.PrevSalt, &k.PrevHash,
+		&k.PrevHashExpiresAt, &k.ExpiresAt, &k.LastUsedAt, &k.RevokedAt,
+		&k.CreatedAt, &k.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(scopes), &k.Scopes); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal scopes: %w", err)
+	}
+	return &k, nil
+}
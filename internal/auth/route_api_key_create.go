@@ -0,0 +1,89 @@
+package auth
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/iamolegga/valmid"
+	"github.com/swaggest/openapi-go"
+	"github.com/swaggest/openapi-go/openapi31"
+
+	"github.com/grantsy/grantsy/internal/httptools"
+	"github.com/grantsy/grantsy/internal/infra/logger"
+	oa "github.com/grantsy/grantsy/internal/openapi"
+)
+
+type APIKeyBody struct {
+	Name      string   `json:"name"                 validate:"required,max=100"                                                       description:"Key name, recorded in request logs and metrics" required:"true"`
+	Scopes    []string `json:"scopes"               validate:"required,min=1,dive,oneof=check:read plans:read users:read admin:write audit:read" description:"Scopes granted to the key"                     required:"true"`
+	ExpiresAt *int64   `json:"expires_at,omitempty" validate:"omitempty,gt=0"                                                         description:"Unix timestamp after which the key is rejected; omit for a key that never expires"`
+}
+
+type APIKeyCreateRequest struct {
+	Body *APIKeyBody `in:"body=json" validate:"required"`
+}
+
+type RouteAPIKeyCreate struct {
+	writer KeyWriter
+}
+
+func NewRouteAPIKeyCreate(writer KeyWriter) *RouteAPIKeyCreate {
+	return &RouteAPIKeyCreate{writer: writer}
+}
+
+func (route *RouteAPIKeyCreate) Register(mux *http.ServeMux, r *openapi31.Reflector) {
+	mux.Handle("POST /v1/api-keys", httptools.Wrap(
+		route.Handler(),
+		RequireScope(ScopeAdminWrite),
+		valmid.Middleware[APIKeyCreateRequest](),
+	))
+	RegisterAPIKeyCreateSchema(r)
+}
+
+func RegisterAPIKeyCreateSchema(r *openapi31.Reflector) {
+	op, _ := r.NewOperationContext(http.MethodPost, "/v1/api-keys")
+	op.AddReqStructure(new(APIKeyBody))
+	op.AddRespStructure(struct {
+		Data APIKeySecretResponse `json:"data"`
+		Meta httptools.Meta       `json:"meta"`
+		_    struct{}             `title:"APIKeyCreateResponse"`
+	}{}, func(cu *openapi.ContentUnit) {
+		cu.HTTPStatus = http.StatusCreated
+		cu.Description = "API key created"
+	})
+	oa.AddErrorResponses(op)
+	op.SetSummary("Create API key")
+	op.SetDescription(
+		"Create an API key with a generated value. Only a salted hash is stored, so the value is only returned in this response and when the key is rotated. The key can only be granted scopes the requesting key holds.",
+	)
+	op.SetTags("API Keys")
+	op.AddSecurity("ApiKeyAuth", ScopeAdminWrite)
+	op.AddSecurity("BearerAuth", ScopeAdminWrite)
+	r.AddOperation(op)
+}
+
+func (route *RouteAPIKeyCreate) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		input := valmid.Get[APIKeyCreateRequest](r)
+
+		if !KeyFromContext(r.Context()).HasScopes(input.Body.Scopes) {
+			writeScopeNotHeld(w, r)
+			return
+		}
+
+		k, value, err := route.writer.CreateKey(r.Context(), KeyInput{
+			Name:      input.Body.Name,
+			Scopes:    input.Body.Scopes,
+			ExpiresAt: input.Body.ExpiresAt,
+		})
+		if err != nil {
+			logger.FromContext(r.Context()).Error("failed to create API key", "error", err)
+			httptools.InternalError(w, r)
+			return
+		}
+
+		httptools.JSON(w, r, http.StatusCreated, APIKeySecretResponse{
+			APIKey: ToAPIKeyWithSecret(k, value, time.Now()),
+		})
+	})
+}
@@ -0,0 +1,72 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/iamolegga/valmid"
+	"github.com/swaggest/openapi-go"
+	"github.com/swaggest/openapi-go/openapi31"
+
+	"github.com/grantsy/grantsy/internal/httptools"
+	"github.com/grantsy/grantsy/internal/infra/logger"
+	oa "github.com/grantsy/grantsy/internal/openapi"
+)
+
+type APIKeyRequest struct {
+	KeyID string `in:"path=key_id" path:"key_id" validate:"required" description:"API key ID"`
+}
+
+type RouteAPIKeyRevoke struct {
+	writer KeyWriter
+}
+
+func NewRouteAPIKeyRevoke(writer KeyWriter) *RouteAPIKeyRevoke {
+	return &RouteAPIKeyRevoke{writer: writer}
+}
+
+func (route *RouteAPIKeyRevoke) Register(mux *http.ServeMux, r *openapi31.Reflector) {
+	mux.Handle("DELETE /v1/api-keys/{key_id}", httptools.Wrap(
+		route.Handler(),
+		RequireScope(ScopeAdminWrite),
+		valmid.Middleware[APIKeyRequest](),
+	))
+	RegisterAPIKeyRevokeSchema(r)
+}
+
+func RegisterAPIKeyRevokeSchema(r *openapi31.Reflector) {
+	op, _ := r.NewOperationContext(http.MethodDelete, "/v1/api-keys/{key_id}")
+	op.AddReqStructure(new(APIKeyRequest))
+	op.AddRespStructure(nil, func(cu *openapi.ContentUnit) {
+		cu.HTTPStatus = http.StatusNoContent
+		cu.Description = "API key revoked"
+	})
+	oa.AddErrorResponses(op)
+	op.SetSummary("Revoke API key")
+	op.SetDescription(
+		"Reject the API key from now on. The key stays listed with its revocation time.",
+	)
+	op.SetTags("API Keys")
+	op.AddSecurity("ApiKeyAuth", ScopeAdminWrite)
+	op.AddSecurity("BearerAuth", ScopeAdminWrite)
+	r.AddOperation(op)
+}
+
+func (route *RouteAPIKeyRevoke) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		input := valmid.Get[APIKeyRequest](r)
+
+		k, err := route.writer.RevokeKey(r.Context(), input.KeyID)
+		if err != nil {
+			logger.FromContext(r.Context()).
+				Error("failed to revoke API key", "error", err, "api_key_id", input.KeyID)
+			httptools.InternalError(w, r)
+			return
+		}
+		if k == nil {
+			writeKeyNotFound(w, r, input.KeyID)
+			return
+		}
+
+		httptools.WriteStatus(w, http.StatusNoContent)
+	})
+}
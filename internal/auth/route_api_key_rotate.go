@@ -0,0 +1,114 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/iamolegga/valmid"
+	"github.com/swaggest/openapi-go"
+	"github.com/swaggest/openapi-go/openapi31"
+
+	"github.com/grantsy/grantsy/internal/httptools"
+	"github.com/grantsy/grantsy/internal/infra/logger"
+	oa "github.com/grantsy/grantsy/internal/openapi"
+)
+
+// defaultRollover is how long the previous key value is accepted after a rotation.
+const defaultRollover = 24 * time.Hour
+
+type RotateAPIKeyBody struct {
+	RolloverSeconds *int64 `json:"rollover_seconds,omitempty" validate:"omitempty,min=0,max=604800" description:"How long the previous key value is still accepted (defaults to 86400, 0 rejects it immediately)"`
+}
+
+type RotateAPIKeyRequest struct {
+	KeyID string            `in:"path=key_id" path:"key_id" validate:"required" description:"API key ID"`
+	Body  *RotateAPIKeyBody `in:"body=json" validate:"required"`
+}
+
+// rotateAPIKeySchema mirrors RotateAPIKeyRequest for OpenAPI spec generation.
+type rotateAPIKeySchema struct {
+	KeyID string `path:"key_id" description:"API key ID"`
+	RotateAPIKeyBody
+}
+
+type RouteAPIKeyRotate struct {
+	writer KeyWriter
+}
+
+func NewRouteAPIKeyRotate(writer KeyWriter) *RouteAPIKeyRotate {
+	return &RouteAPIKeyRotate{writer: writer}
+}
+
+func (route *RouteAPIKeyRotate) Register(mux *http.ServeMux, r *openapi31.Reflector) {
+	mux.Handle("POST /v1/api-keys/{key_id}/rotate", httptools.Wrap(
+		route.Handler(),
+		RequireScope(ScopeAdminWrite),
+		valmid.Middleware[RotateAPIKeyRequest](),
+	))
+	RegisterAPIKeyRotateSchema(r)
+}
+
+func RegisterAPIKeyRotateSchema(r *openapi31.Reflector) {
+	op, _ := r.NewOperationContext(http.MethodPost, "/v1/api-keys/{key_id}/rotate")
+	op.AddReqStructure(new(rotateAPIKeySchema))
+	op.AddRespStructure(struct {
+		Data APIKeySecretResponse `json:"data"`
+		Meta httptools.Meta       `json:"meta"`
+		_    struct{}             `title:"APIKeyRotateResponse"`
+	}{}, func(cu *openapi.ContentUnit) {
+		cu.HTTPStatus = http.StatusOK
+		cu.Description = "API key rotated"
+	})
+	op.AddRespStructure(
+		new(httptools.ErrorResponse),
+		func(cu *openapi.ContentUnit) {
+			cu.HTTPStatus = http.StatusConflict
+			cu.Description = "The API key is revoked"
+		},
+	)
+	oa.AddErrorResponses(op)
+	op.SetSummary("Rotate API key")
+	op.SetDescription(
+		"Generate a new value for the API key. During the rollover window the previous value is accepted too, so clients can switch without downtime. Send an empty object for the default 24 hour rollover. Only keys whose scopes the requesting key holds can be rotated.",
+	)
+	op.SetTags("API Keys")
+	op.AddSecurity("ApiKeyAuth", ScopeAdminWrite)
+	op.AddSecurity("BearerAuth", ScopeAdminWrite)
+	r.AddOperation(op)
+}
+
+func (route *RouteAPIKeyRotate) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		input := valmid.Get[RotateAPIKeyRequest](r)
+
+		rollover := defaultRollover
+		if input.Body.RolloverSeconds != nil {
+			rollover = time.Duration(*input.Body.RolloverSeconds) * time.Second
+		}
+
+		k, value, err := route.writer.RotateKey(r.Context(), input.KeyID, rollover)
+		if errors.Is(err, ErrKeyRevoked) {
+			httptools.Conflict(w, r, "API key is revoked")
+			return
+		}
+		if errors.Is(err, ErrScopeNotHeld) {
+			writeScopeNotHeld(w, r)
+			return
+		}
+		if err != nil {
+			logger.FromContext(r.Context()).
+				Error("failed to rotate API key", "error", err, "api_key_id", input.KeyID)
+			httptools.InternalError(w, r)
+			return
+		}
+		if k == nil {
+			writeKeyNotFound(w, r, input.KeyID)
+			return
+		}
+
+		httptools.JSON(w, r, http.StatusOK, APIKeySecretResponse{
+			APIKey: ToAPIKeyWithSecret(k, value, time.Now()),
+		})
+	})
+}
@@ -0,0 +1,145 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/swaggest/openapi-go"
+	"github.com/swaggest/openapi-go/openapi31"
+
+	"github.com/grantsy/grantsy/internal/httptools"
+	"github.com/grantsy/grantsy/internal/infra/logger"
+	oa "github.com/grantsy/grantsy/internal/openapi"
+)
+
+// KeyReader reads keys managed through the API.
+type KeyReader interface {
+	ListKeys(ctx context.Context) ([]StoredKey, error)
+}
+
+// KeyWriter manages keys through the API.
+type KeyWriter interface {
+	CreateKey(ctx context.Context, in KeyInput) (*StoredKey, string, error)
+	RevokeKey(ctx context.Context, id string) (*StoredKey, error)
+	RotateKey(ctx context.Context, id string, rollover time.Duration) (*StoredKey, string, error)
+}
+
+type APIKeysResponse struct {
+	APIKeys []APIKeyDetail `json:"api_keys" description:"API keys managed through the API, oldest first" nullable:"false" required:"true"`
+}
+
+type APIKeyDetail struct {
+	ID                      string   `json:"id"                                   description:"API key identifier"                                                required:"true"`
+	Name                    string   `json:"name"                                 description:"Key name, recorded in request logs and metrics"                    required:"true"`
+	Scopes                  []string `json:"scopes"                               description:"Scopes granted to the key"                                         required:"true" nullable:"false"`
+	ExpiresAt               *int64   `json:"expires_at"                           description:"Unix timestamp after which the key is rejected, null if it never expires"`
+	LastUsedAt              *int64   `json:"last_used_at"                         description:"Unix timestamp of the last request made with the key, to the minute"`
+	RevokedAt               *int64   `json:"revoked_at"                           description:"Unix timestamp of revocation, null if the key is active"`
+	PreviousSecretExpiresAt *int64   `json:"previous_secret_expires_at,omitempty" description:"Unix timestamp until which the key value replaced by the last rotation is also accepted"`
+	CreatedAt               int64    `json:"created_at"                           description:"Unix timestamp of creation"                                        required:"true"`
+	UpdatedAt               int64    `json:"updated_at"                           description:"Unix timestamp of the last change"                                 required:"true"`
+}
+
+// APIKeyWithSecret is an API key together with its value, only returned when
+// the value is generated.
+type APIKeyWithSecret struct {
+	APIKeyDetail
+	Key string `json:"key" description:"Key value to send in the X-Api-Key header (gsk_ prefixed)" required:"true"`
+}
+
+type APIKeySecretResponse struct {
+	APIKey APIKeyWithSecret `json:"api_key" description:"API key with its value" required:"true"`
+}
+
+type RouteAPIKeys struct {
+	reader KeyReader
+}
+
+func NewRouteAPIKeys(reader KeyReader) *RouteAPIKeys {
+	return &RouteAPIKeys{reader: reader}
+}
+
+func (route *RouteAPIKeys) Register(mux *http.ServeMux, r *openapi31.Reflector) {
+	mux.Handle("GET /v1/api-keys",
+		RequireScope(ScopeAdminWrite)(route.Handler()),
+	)
+	RegisterAPIKeysSchema(r)
+}
+
+func RegisterAPIKeysSchema(r *openapi31.Reflector) {
+	op, _ := r.NewOperationContext(http.MethodGet, "/v1/api-keys")
+	op.AddRespStructure(struct {
+		Data APIKeysResponse `json:"data"`
+		Meta httptools.Meta  `json:"meta"`
+		_    struct{}        `title:"APIKeysResponse"`
+	}{}, func(cu *openapi.ContentUnit) {
+		cu.HTTPStatus = http.StatusOK
+		cu.Description = "API keys"
+	})
+	oa.AddErrorResponses(op)
+	op.SetSummary("List API keys")
+	op.SetDescription(
+		"List the API keys managed through the API, including revoked ones. Key values are never returned after creation or rotation. Keys from the config file are not included.",
+	)
+	op.SetTags("API Keys")
+	op.AddSecurity("ApiKeyAuth", ScopeAdminWrite)
+	op.AddSecurity("BearerAuth", ScopeAdminWrite)
+	r.AddOperation(op)
+}
+
+func (route *RouteAPIKeys) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys, err := route.reader.ListKeys(r.Context())
+		if err != nil {
+			logger.FromContext(r.Context()).Error("failed to list API keys", "error", err)
+			httptools.InternalError(w, r)
+			return
+		}
+
+		resp := APIKeysResponse{APIKeys: make([]APIKeyDetail, 0, len(keys))}
+		for _, k := range keys {
+			resp.APIKeys = append(resp.APIKeys, ToAPIKeyDetail(&k, time.Now()))
+		}
+
+		httptools.JSON(w, r, http.StatusOK, resp)
+	})
+}
+
+// ToAPIKeyDetail converts a StoredKey to its display type, without the hashes.
+func ToAPIKeyDetail(k *StoredKey, now time.Time) APIKeyDetail {
+	detail := APIKeyDetail{
+		ID:         k.ID,
+		Name:       k.Name,
+		Scopes:     k.Scopes,
+		ExpiresAt:  k.ExpiresAt,
+		LastUsedAt: k.LastUsedAt,
+		RevokedAt:  k.RevokedAt,
+		CreatedAt:  k.CreatedAt,
+		UpdatedAt:  k.UpdatedAt,
+	}
+	if k.PrevHash != "" && now.Unix() < k.PrevHashExpiresAt {
+		detail.PreviousSecretExpiresAt = &k.PrevHashExpiresAt
+	}
+	return detail
+}
+
+// ToAPIKeyWithSecret converts a StoredKey to its display type including the key value.
+func ToAPIKeyWithSecret(k *StoredKey, value string, now time.Time) APIKeyWithSecret {
+	return APIKeyWithSecret{APIKeyDetail: ToAPIKeyDetail(k, now), Key: value}
+}
+
+func writeKeyNotFound(w http.ResponseWriter, r *http.Request, id string) {
+	httptools.NotFound(w, r, fmt.Sprintf("API key '%s' not found", id))
+}
+
+// writeScopeNotHeld rejects a request that would hand out a key with scopes
+// the requesting key does not hold.
+func writeScopeNotHeld(w http.ResponseWriter, r *http.Request) {
+	httptools.Error(w, r, http.StatusForbidden,
+		httptools.ErrTypeForbidden,
+		"Forbidden",
+		"API keys can only be issued with scopes the requesting key holds",
+	)
+}
@@ -0,0 +1,179 @@
+package auth_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/openapi-go/openapi31"
+
+	"github.com/grantsy/grantsy/internal/auth"
+	"github.com/grantsy/grantsy/internal/auth/authtest"
+	"github.com/grantsy/grantsy/internal/auth/mocks"
+	"github.com/grantsy/grantsy/internal/httptools"
+	_ "github.com/grantsy/grantsy/internal/infra/validation"
+)
+
+func newAPIKeysMux(t *testing.T, reader auth.KeyReader, writer auth.KeyWriter) http.Handler {
+	t.Helper()
+	mux := http.NewServeMux()
+	r := openapi31.NewReflector()
+	auth.NewRouteAPIKeys(reader).Register(mux, r)
+	auth.NewRouteAPIKeyCreate(writer).Register(mux, r)
+	auth.NewRouteAPIKeyRevoke(writer).Register(mux, r)
+	auth.NewRouteAPIKeyRotate(writer).Register(mux, r)
+	return authtest.Handler(mux)
+}
+
+func serveAPIKeys(t *testing.T, mux http.Handler, method, target, body string) (int, map[string]any) {
+	t.Helper()
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Body.Len() == 0 {
+		return w.Code, nil
+	}
+	var resp httptools.Response
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	data, _ := resp.Data.(map[string]any)
+	return w.Code, data
+}
+
+func testStoredKey() *auth.StoredKey {
+	return &auth.StoredKey{
+		ID:        "01",
+		Name:      "backend",
+		Scopes:    []string{auth.ScopeCheckRead},
+		Salt:      "salt",
+		Hash:      "hash",
+		CreatedAt: 1700000000,
+		UpdatedAt: 1700000000,
+	}
+}
+
+func TestRouteAPIKeys_List_HidesHashes(t *testing.T) {
+	reader := mocks.NewMockKeyReader(t)
+	reader.EXPECT().ListKeys(mock.Anything).Return([]auth.StoredKey{*testStoredKey()}, nil)
+
+	mux := newAPIKeysMux(t, reader, mocks.NewMockKeyWriter(t))
+	code, data := serveAPIKeys(t, mux, http.MethodGet, "/v1/api-keys", "")
+
+	assert.Equal(t, http.StatusOK, code)
+	keys := data["api_keys"].([]any)
+	require.Len(t, keys, 1)
+	key := keys[0].(map[string]any)
+	assert.Equal(t, "backend", key["name"])
+	assert.NotContains(t, key, "key")
+	assert.NotContains(t, key, "hash")
+	assert.NotContains(t, key, "salt")
+}
+
+func TestRouteAPIKeyCreate(t *testing.T) {
+	writer := mocks.NewMockKeyWriter(t)
+	writer.EXPECT().
+		CreateKey(mock.Anything, auth.KeyInput{Name: "backend", Scopes: []string{auth.ScopeCheckRead}}).
+		Return(testStoredKey(), "gsk_01_secret", nil)
+
+	mux := newAPIKeysMux(t, mocks.NewMockKeyReader(t), writer)
+	code, data := serveAPIKeys(t, mux, http.MethodPost, "/v1/api-keys",
+		`{"name":"backend","scopes":["check:read"]}`)
+
+	assert.Equal(t, http.StatusCreated, code)
+	assert.Equal(t, "gsk_01_secret", data["api_key"].(map[string]any)["key"])
+}
+
+func TestRouteAPIKeyCreate_InvalidScope(t *testing.T) {
+	mux := newAPIKeysMux(t, mocks.NewMockKeyReader(t), mocks.NewMockKeyWriter(t))
+	code, _ := serveAPIKeys(t, mux, http.MethodPost, "/v1/api-keys",
+		`{"name":"backend","scopes":["everything"]}`)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, code)
+}
+
+func TestRouteAPIKeyCreate_ScopeNotHeld(t *testing.T) {
+	mux := http.NewServeMux()
+	auth.NewRouteAPIKeyCreate(mocks.NewMockKeyWriter(t)).Register(mux, openapi31.NewReflector())
+
+	handler := authtest.Handler(mux, auth.ScopeAdminWrite)
+	code, _ := serveAPIKeys(t, handler, http.MethodPost, "/v1/api-keys",
+		`{"name":"auditor","scopes":["admin:write","audit:read"]}`)
+
+	assert.Equal(t, http.StatusForbidden, code)
+}
+
+func TestRouteAPIKeyRevoke(t *testing.T) {
+	tests := []struct {
+		name     string
+		revoked  *auth.StoredKey
+		wantCode int
+	}{
+		{name: "revoked", revoked: testStoredKey(), wantCode: http.StatusNoContent},
+		{name: "not found", revoked: nil, wantCode: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writer := mocks.NewMockKeyWriter(t)
+			writer.EXPECT().RevokeKey(mock.Anything, "01").Return(tt.revoked, nil)
+
+			mux := newAPIKeysMux(t, mocks.NewMockKeyReader(t), writer)
+			code, _ := serveAPIKeys(t, mux, http.MethodDelete, "/v1/api-keys/01", "")
+
+			assert.Equal(t, tt.wantCode, code)
+		})
+	}
+}
+
+func TestRouteAPIKeyRotate_DefaultRollover(t *testing.T) {
+	rotated := testStoredKey()
+	rotated.PrevHash = "old"
+	rotated.PrevHashExpiresAt = time.Now().Add(24 * time.Hour).Unix()
+	writer := mocks.NewMockKeyWriter(t)
+	writer.EXPECT().RotateKey(mock.Anything, "01", 24*time.Hour).Return(rotated, "gsk_01_new", nil)
+
+	mux := newAPIKeysMux(t, mocks.NewMockKeyReader(t), writer)
+	code, data := serveAPIKeys(t, mux, http.MethodPost, "/v1/api-keys/01/rotate", `{}`)
+
+	assert.Equal(t, http.StatusOK, code)
+	key := data["api_key"].(map[string]any)
+	assert.Equal(t, "gsk_01_new", key["key"])
+	assert.InDelta(t, rotated.PrevHashExpiresAt, key["previous_secret_expires_at"], 0)
+}
+
+func TestRouteAPIKeyRotate_Revoked(t *testing.T) {
+	writer := mocks.NewMockKeyWriter(t)
+	writer.EXPECT().RotateKey(mock.Anything, "01", time.Duration(0)).Return(nil, "", auth.ErrKeyRevoked)
+
+	mux := newAPIKeysMux(t, mocks.NewMockKeyReader(t), writer)
+	code, _ := serveAPIKeys(t, mux, http.MethodPost, "/v1/api-keys/01/rotate", `{"rollover_seconds":0}`)
+
+	assert.Equal(t, http.StatusConflict, code)
+}
+
+func TestRouteAPIKeyRotate_ScopeNotHeld(t *testing.T) {
+	writer := mocks.NewMockKeyWriter(t)
+	writer.EXPECT().RotateKey(mock.Anything, "01", 24*time.Hour).Return(nil, "", auth.ErrScopeNotHeld)
+
+	mux := newAPIKeysMux(t, mocks.NewMockKeyReader(t), writer)
+	code, _ := serveAPIKeys(t, mux, http.MethodPost, "/v1/api-keys/01/rotate", `{}`)
+
+	assert.Equal(t, http.StatusForbidden, code)
+}
+
+func TestRouteAPIKeys_RequiresAdminScope(t *testing.T) {
+	mux := http.NewServeMux()
+	auth.NewRouteAPIKeys(mocks.NewMockKeyReader(t)).Register(mux, openapi31.NewReflector())
+
+	handler := authtest.Handler(mux, auth.ScopeUsersRead)
+	code, _ := serveAPIKeys(t, handler, http.MethodGet, "/v1/api-keys", "")
+
+	assert.Equal(t, http.StatusForbidden, code)
+}
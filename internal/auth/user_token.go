@@ -0,0 +1,96 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/grantsy/grantsy/internal/infra/config"
+)
+
+// userTokenHeader carries the user token that must accompany a publishable key.
+const userTokenHeader = "X-User-Token"
+
+// defaultUserTokenTTL is the longest lifetime accepted for a user token when
+// max_ttl is not configured.
+const defaultUserTokenTTL = time.Hour
+
+// UserTokenVerifier returns the user a token was issued for.
+type UserTokenVerifier interface {
+	VerifyUserToken(ctx context.Context, token string) (string, error)
+}
+
+// UserTokens verifies the short-lived user tokens sent with publishable keys.
+// Tokens are JWTs with the user ID in the sub claim, signed with the shared
+// HMAC secret (HS256) or by a key from the identity provider's JWKS (RS256,
+// ES256).
+type UserTokens struct {
+	hmacSecret []byte
+	jwks       *jwks
+	issuer     string
+	audience   string
+	maxTTL     time.Duration
+}
+
+// NewUserTokens creates a verifier from cfg. Tokens from a JWKS require an
+// issuer and audience: without them, tokens the identity provider issues for
+// any other application would be accepted.
+func NewUserTokens(cfg *config.UserTokensConfig) (*UserTokens, error) {
+	if cfg.JWKSURL != "" && (cfg.Issuer == "" || cfg.Audience == "") {
+		return nil, errors.New("auth: user_tokens issuer and audience are required with jwks_url")
+	}
+
+	maxTTL := defaultUserTokenTTL
+	if cfg.MaxTTL != "" {
+		var err error
+		maxTTL, err = time.ParseDuration(cfg.MaxTTL)
+		if err != nil {
+			return nil, fmt.Errorf("auth: invalid user_tokens.max_ttl: %w", err)
+		}
+	}
+
+	t := &UserTokens{
+		issuer:   cfg.Issuer,
+		audience: cfg.Audience,
+		maxTTL:   maxTTL,
+	}
+	if cfg.HMACSecret != "" {
+		t.hmacSecret = []byte(cfg.HMACSecret)
+	}
+	if cfg.JWKSURL != "" {
+		t.jwks = newJWKS(cfg.JWKSURL, &http.Client{Timeout: 10 * time.Second})
+	}
+	return t, nil
+}
+
+// VerifyUserToken checks the token's signature and claims and returns its
+// subject. Errors caused by the token itself wrap errInvalidToken.
+func (t *UserTokens) VerifyUserToken(ctx context.Context, token string) (string, error) {
+	claims, err := parseJWT(token, func(alg, kid string) (crypto.PublicKey, []byte, error) {
+		if alg == "HS256" {
+			if t.hmacSecret == nil {
+				return nil, nil, fmt.Errorf("%w: HS256 tokens are not accepted", errInvalidToken)
+			}
+			return nil, t.hmacSecret, nil
+		}
+		if t.jwks == nil {
+			return nil, nil, fmt.Errorf("%w: %s tokens are not accepted", errInvalidToken, alg)
+		}
+		key, err := t.jwks.key(ctx, kid)
+		return key, nil, err
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if err := claims.validate(time.Now(), t.issuer, t.audience, t.maxTTL); err != nil {
+		return "", err
+	}
+	if claims.Subject == "" {
+		return "", fmt.Errorf("%w: missing sub claim", errInvalidToken)
+	}
+	return claims.Subject, nil
+}
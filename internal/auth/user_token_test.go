@@ -0,0 +1,206 @@
+package auth_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grantsy/grantsy/internal/auth"
+	"github.com/grantsy/grantsy/internal/infra/config"
+)
+
+const testHMACSecret = "0123456789abcdef0123456789abcdef"
+
+func b64(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// signToken returns a JWT with claims, signed by sign over its header and payload.
+func signToken(t *testing.T, header, claims map[string]any, sign func([]byte) []byte) string {
+	t.Helper()
+	h, err := json.Marshal(header)
+	require.NoError(t, err)
+	c, err := json.Marshal(claims)
+	require.NoError(t, err)
+	signed := b64(h) + "." + b64(c)
+	return signed + "." + b64(sign([]byte(signed)))
+}
+
+func hmacToken(t *testing.T, secret string, claims map[string]any) string {
+	return signToken(t, map[string]any{"alg": "HS256", "typ": "JWT"}, claims, func(data []byte) []byte {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(data)
+		return mac.Sum(nil)
+	})
+}
+
+func userClaims(sub string, ttl time.Duration) map[string]any {
+	return map[string]any{"sub": sub, "exp": time.Now().Add(ttl).Unix()}
+}
+
+func TestUserTokens_HMAC(t *testing.T) {
+	tokens, err := auth.NewUserTokens(&config.UserTokensConfig{HMACSecret: testHMACSecret})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		token   string
+		wantSub string
+	}{
+		{name: "valid", token: hmacToken(t, testHMACSecret, userClaims("user-1", 5*time.Minute)), wantSub: "user-1"},
+		{name: "wrong secret", token: hmacToken(t, "another-secret-another-secret-xx", userClaims("user-1", 5*time.Minute))},
+		{name: "expired", token: hmacToken(t, testHMACSecret, userClaims("user-1", -5*time.Minute))},
+		{name: "lifetime too long", token: hmacToken(t, testHMACSecret, userClaims("user-1", 24*time.Hour))},
+		{name: "no expiry", token: hmacToken(t, testHMACSecret, map[string]any{"sub": "user-1"})},
+		{name: "no subject", token: hmacToken(t, testHMACSecret, map[string]any{"exp": time.Now().Add(time.Minute).Unix()})},
+		{name: "malformed", token: "not-a-token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sub, err := tokens.VerifyUserToken(context.Background(), tt.token)
+			if tt.wantSub == "" {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSub, sub)
+		})
+	}
+}
+
+func TestUserTokens_IssuerAndAudience(t *testing.T) {
+	tokens, err := auth.NewUserTokens(&config.UserTokensConfig{
+		HMACSecret: testHMACSecret,
+		Issuer:     "https://example.com",
+		Audience:   "grantsy",
+	})
+	require.NoError(t, err)
+
+	claims := userClaims("user-1", time.Minute)
+	claims["iss"] = "https://example.com"
+	claims["aud"] = []string{"other", "grantsy"}
+	_, err = tokens.VerifyUserToken(context.Background(), hmacToken(t, testHMACSecret, claims))
+	require.NoError(t, err)
+
+	claims["aud"] = "other"
+	_, err = tokens.VerifyUserToken(context.Background(), hmacToken(t, testHMACSecret, claims))
+	require.Error(t, err)
+}
+
+func TestUserTokens_JWKS(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	ecPoint, err := ecKey.PublicKey.Bytes()
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]any{
+			{
+				"kty": "RSA", "kid": "rsa-1", "use": "sig",
+				"n": b64(rsaKey.N.Bytes()),
+				"e": b64(big.NewInt(int64(rsaKey.E)).Bytes()),
+			},
+			{
+				"kty": "EC", "kid": "ec-1", "crv": "P-256",
+				"x": b64(ecPoint[1:33]),
+				"y": b64(ecPoint[33:]),
+			},
+		}})
+	}))
+	defer server.Close()
+
+	tokens, err := auth.NewUserTokens(&config.UserTokensConfig{
+		JWKSURL:  server.URL,
+		Issuer:   "https://auth.example.com/",
+		Audience: "grantsy",
+	})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	claims := func(sub string) map[string]any {
+		c := userClaims(sub, time.Minute)
+		c["iss"] = "https://auth.example.com/"
+		c["aud"] = "grantsy"
+		return c
+	}
+	signRSA := func(data []byte) []byte {
+		digest := sha256.Sum256(data)
+		sig, err := rsa.SignPKCS1v15(rand.Reader, rsaKey, crypto.SHA256, digest[:])
+		require.NoError(t, err)
+		return sig
+	}
+
+	rs256 := signToken(t, map[string]any{"alg": "RS256", "kid": "rsa-1"}, claims("user-rsa"), signRSA)
+	sub, err := tokens.VerifyUserToken(ctx, rs256)
+	require.NoError(t, err)
+	assert.Equal(t, "user-rsa", sub)
+
+	// Tokens the provider issued for another application are rejected.
+	other := claims("user-rsa")
+	other["aud"] = "another-app"
+	_, err = tokens.VerifyUserToken(ctx, signToken(t, map[string]any{"alg": "RS256", "kid": "rsa-1"}, other, signRSA))
+	require.Error(t, err)
+	other = claims("user-rsa")
+	delete(other, "iss")
+	_, err = tokens.VerifyUserToken(ctx, signToken(t, map[string]any{"alg": "RS256", "kid": "rsa-1"}, other, signRSA))
+	require.Error(t, err)
+
+	es256 := signToken(t, map[string]any{"alg": "ES256", "kid": "ec-1"}, claims("user-ec"),
+		func(data []byte) []byte {
+			digest := sha256.Sum256(data)
+			r, s, err := ecdsa.Sign(rand.Reader, ecKey, digest[:])
+			require.NoError(t, err)
+			sig := make([]byte, 64)
+			r.FillBytes(sig[:32])
+			s.FillBytes(sig[32:])
+			return sig
+		})
+	sub, err = tokens.VerifyUserToken(ctx, es256)
+	require.NoError(t, err)
+	assert.Equal(t, "user-ec", sub)
+
+	// HS256 tokens are rejected when no HMAC secret is configured, so the
+	// public JWKS key can't be used as a shared secret.
+	_, err = tokens.VerifyUserToken(ctx, hmacToken(t, testHMACSecret, userClaims("user-1", time.Minute)))
+	require.Error(t, err)
+
+	unknownKid := signToken(t, map[string]any{"alg": "RS256", "kid": "rsa-2"}, claims("user-rsa"),
+		func(data []byte) []byte { return []byte("sig") })
+	_, err = tokens.VerifyUserToken(ctx, unknownKid)
+	require.Error(t, err)
+}
+
+func TestNewUserTokens_JWKSRequiresIssuerAndAudience(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.UserTokensConfig
+	}{
+		{name: "no issuer", cfg: config.UserTokensConfig{JWKSURL: "https://auth.example.com/jwks", Audience: "grantsy"}},
+		{name: "no audience", cfg: config.UserTokensConfig{JWKSURL: "https://auth.example.com/jwks", Issuer: "https://auth.example.com/"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := auth.NewUserTokens(&tt.cfg)
+			require.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package entitlements
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PlanTransition is a subscription change applied to a user, as recorded in
+// their plan history. PrevPlan and NewPlan are equal when the subscription
+// changed without moving the user to another plan.
+type PlanTransition struct {
+	ID           string
+	UserID       string
+	PrevPlan     string
+	NewPlan      string
+	Active       bool
+	Cause        string          // Provider event that changed the subscription, e.g. subscription_expired
+	Subscription json.RawMessage // Subscription state after the change
+	CreatedAt    int64
+}
+
+// TransitionCursor marks the position of a transition in the plan history,
+// which is ordered by CreatedAt and then ID, as CreatedAt alone may be shared.
+type TransitionCursor struct {
+	CreatedAt int64
+	ID        string
+}
+
+// Cursor returns the position of t in the plan history.
+func (t *PlanTransition) Cursor() TransitionCursor {
+	return TransitionCursor{CreatedAt: t.CreatedAt, ID: t.ID}
+}
+
+// String encodes the cursor as "<created_at>_<id>".
+func (c TransitionCursor) String() string {
+	return strconv.FormatInt(c.CreatedAt, 10) + "_" + c.ID
+}
+
+// ParseTransitionCursor decodes a cursor encoded by TransitionCursor.String.
+func ParseTransitionCursor(s string) (*TransitionCursor, error) {
+	createdAt, id, ok := strings.Cut(s, "_")
+	if !ok || id == "" {
+		return nil, fmt.Errorf("entitlements: invalid cursor %q", s)
+	}
+	ts, err := strconv.ParseInt(createdAt, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("entitlements: invalid cursor %q", s)
+	}
+	return &TransitionCursor{CreatedAt: ts, ID: id}, nil
+}
+
+// ConfigVersion is the set of plans and their features loaded from the
+// config file, saved whenever it changes.
+type ConfigVersion struct {
+	ID          string
+	Hash        string
+	DefaultPlan string
+	Plans       map[string][]string // Plan ID -> feature IDs
+	CreatedAt   int64
+}
+
+// HistoryStore persists the plan history of users and the config versions
+// in effect over time.
+type HistoryStore interface {
+	InsertTransition(ctx context.Context, t *PlanTransition) error
+	// PlanAt returns the plan the user's history shows at the given time,
+	// and false if the history has no transitions for the user.
+	PlanAt(ctx context.Context, userID string, at int64) (string, bool, error)
+	// SaveConfigVersion stores v unless the latest version has the same hash.
+	SaveConfigVersion(ctx context.Context, v *ConfigVersion) error
+	// ConfigVersionAt returns the version in effect at the given time, the
+	// oldest version for times before it, or nil if none is stored.
+	ConfigVersionAt(ctx context.Context, at int64) (*ConfigVersion, error)
+}
+
+// CheckFeatureAt reports whether the user had access to the feature at the
+// given Unix time, from their plan history and the config version in effect
+// then. Users without a recorded transition are assumed to have been on
+// their current plan, and times before the first recorded config version are
+// checked against it.
+func (s *Service) CheckFeatureAt(ctx context.Context, userID, featureID string, at int64) (*CheckResult, error) {
+	if s.history == nil {
+		return nil, errors.New("entitlements: no plan history configured")
+	}
+
+	version, err := s.history.ConfigVersionAt(ctx, at)
+	if err != nil {
+		return nil, err
+	}
+	if version == nil {
+		version = s.configVersion()
+	}
+
+	planID, found, err := s.history.PlanAt(ctx, userID, at)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		planID = s.GetUserPlan(userID)
+		if planID == s.ent.DefaultPlan {
+			planID = version.DefaultPlan
+		}
+	}
+
+	allowed := slices.Contains(version.Plans[planID], featureID)
+	return &CheckResult{
+		Allowed:   allowed,
+		FeatureID: featureID,
+		UserID:    userID,
+		PlanID:    planID,
+		Reason:    checkReason(planID, version.DefaultPlan, allowed),
+	}, nil
+}
+
+// configVersion returns the plans and features of the loaded config.
+func (s *Service) configVersion() *ConfigVersion {
+	plans := make(map[string][]string, len(s.ent.Plans))
+	for _, plan := range s.ent.Plans {
+		plans[plan.ID] = plan.Features
+	}
+	// Map keys are marshaled in sorted order, so equal configs hash equally.
+	data, _ := json.Marshal(struct {
+		DefaultPlan string              `json:"default_plan"`
+		Plans       map[string][]string `json:"plans"`
+	}{s.ent.DefaultPlan, plans})
+	sum := sha256.Sum256(data)
+
+	return &ConfigVersion{
+		ID:          uuid.Must(uuid.NewV7()).String(),
+		Hash:        hex.EncodeToString(sum[:]),
+		DefaultPlan: s.ent.DefaultPlan,
+		Plans:       plans,
+		CreatedAt:   time.Now().Unix(),
+	}
+}
@@ -0,0 +1,86 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockPlanChangeRecorder is an autogenerated mock type for the PlanChangeRecorder type
+type MockPlanChangeRecorder struct {
+	mock.Mock
+}
+
+type MockPlanChangeRecorder_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockPlanChangeRecorder) EXPECT() *MockPlanChangeRecorder_Expecter {
+	return &MockPlanChangeRecorder_Expecter{mock: &_m.Mock}
+}
+
+// RecordPlanChange provides a mock function with given fields: ctx, userID, prevPlan, activePlan, active
+func (_m *MockPlanChangeRecorder) RecordPlanChange(ctx context.Context, userID string, prevPlan string, activePlan string, active bool) error {
+	ret := _m.Called(ctx, userID, prevPlan, activePlan, active)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordPlanChange")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, bool) error); ok {
+		r0 = rf(ctx, userID, prevPlan, activePlan, active)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockPlanChangeRecorder_RecordPlanChange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordPlanChange'
+type MockPlanChangeRecorder_RecordPlanChange_Call struct {
+	*mock.Call
+}
+
+// RecordPlanChange is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - prevPlan string
+//   - activePlan string
+//   - active bool
+func (_e *MockPlanChangeRecorder_Expecter) RecordPlanChange(ctx interface{}, userID interface{}, prevPlan interface{}, activePlan interface{}, active interface{}) *MockPlanChangeRecorder_RecordPlanChange_Call {
+	return &MockPlanChangeRecorder_RecordPlanChange_Call{Call: _e.mock.On("RecordPlanChange", ctx, userID, prevPlan, activePlan, active)}
+}
+
+func (_c *MockPlanChangeRecorder_RecordPlanChange_Call) Run(run func(ctx context.Context, userID string, prevPlan string, activePlan string, active bool)) *MockPlanChangeRecorder_RecordPlanChange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(bool))
+	})
+	return _c
+}
+
+func (_c *MockPlanChangeRecorder_RecordPlanChange_Call) Return(_a0 error) *MockPlanChangeRecorder_RecordPlanChange_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPlanChangeRecorder_RecordPlanChange_Call) RunAndReturn(run func(context.Context, string, string, string, bool) error) *MockPlanChangeRecorder_RecordPlanChange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockPlanChangeRecorder creates a new instance of MockPlanChangeRecorder. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockPlanChangeRecorder(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockPlanChangeRecorder {
+	mock := &MockPlanChangeRecorder{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
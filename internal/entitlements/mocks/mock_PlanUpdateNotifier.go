@@ -21,17 +21,68 @@ func (_m *MockPlanUpdateNotifier) EXPECT() *MockPlanUpdateNotifier_Expecter {
 	return &MockPlanUpdateNotifier_Expecter{mock: &_m.Mock}
 }
 
-// NotifyPlanUpdated provides a mock function with given fields: ctx, userID, activePlan, prevPlan, subscription
-func (_m *MockPlanUpdateNotifier) NotifyPlanUpdated(ctx context.Context, userID string, activePlan string, prevPlan string, subscription interface{}) error {
-	ret := _m.Called(ctx, userID, activePlan, prevPlan, subscription)
+// NotifyPayment provides a mock function with given fields: ctx, userID, activePlan, outcome, subscription, payment
+func (_m *MockPlanUpdateNotifier) NotifyPayment(ctx context.Context, userID string, activePlan string, outcome string, subscription interface{}, payment interface{}) error {
+	ret := _m.Called(ctx, userID, activePlan, outcome, subscription, payment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NotifyPayment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, interface{}, interface{}) error); ok {
+		r0 = rf(ctx, userID, activePlan, outcome, subscription, payment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockPlanUpdateNotifier_NotifyPayment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NotifyPayment'
+type MockPlanUpdateNotifier_NotifyPayment_Call struct {
+	*mock.Call
+}
+
+// NotifyPayment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - activePlan string
+//   - outcome string
+//   - subscription interface{}
+//   - payment interface{}
+func (_e *MockPlanUpdateNotifier_Expecter) NotifyPayment(ctx interface{}, userID interface{}, activePlan interface{}, outcome interface{}, subscription interface{}, payment interface{}) *MockPlanUpdateNotifier_NotifyPayment_Call {
+	return &MockPlanUpdateNotifier_NotifyPayment_Call{Call: _e.mock.On("NotifyPayment", ctx, userID, activePlan, outcome, subscription, payment)}
+}
+
+func (_c *MockPlanUpdateNotifier_NotifyPayment_Call) Run(run func(ctx context.Context, userID string, activePlan string, outcome string, subscription interface{}, payment interface{})) *MockPlanUpdateNotifier_NotifyPayment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(interface{}), args[5].(interface{}))
+	})
+	return _c
+}
+
+func (_c *MockPlanUpdateNotifier_NotifyPayment_Call) Return(_a0 error) *MockPlanUpdateNotifier_NotifyPayment_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPlanUpdateNotifier_NotifyPayment_Call) RunAndReturn(run func(context.Context, string, string, string, interface{}, interface{}) error) *MockPlanUpdateNotifier_NotifyPayment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NotifyPlanUpdated provides a mock function with given fields: ctx, userID, activePlan, prevPlan, active, subscription
+func (_m *MockPlanUpdateNotifier) NotifyPlanUpdated(ctx context.Context, userID string, activePlan string, prevPlan string, active bool, subscription interface{}) error {
+	ret := _m.Called(ctx, userID, activePlan, prevPlan, active, subscription)
 
 	if len(ret) == 0 {
 		panic("no return value specified for NotifyPlanUpdated")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, interface{}) error); ok {
-		r0 = rf(ctx, userID, activePlan, prevPlan, subscription)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, bool, interface{}) error); ok {
+		r0 = rf(ctx, userID, activePlan, prevPlan, active, subscription)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -49,14 +100,15 @@ type MockPlanUpdateNotifier_NotifyPlanUpdated_Call struct {
 //   - userID string
 //   - activePlan string
 //   - prevPlan string
+//   - active bool
 //   - subscription interface{}
-func (_e *MockPlanUpdateNotifier_Expecter) NotifyPlanUpdated(ctx interface{}, userID interface{}, activePlan interface{}, prevPlan interface{}, subscription interface{}) *MockPlanUpdateNotifier_NotifyPlanUpdated_Call {
-	return &MockPlanUpdateNotifier_NotifyPlanUpdated_Call{Call: _e.mock.On("NotifyPlanUpdated", ctx, userID, activePlan, prevPlan, subscription)}
+func (_e *MockPlanUpdateNotifier_Expecter) NotifyPlanUpdated(ctx interface{}, userID interface{}, activePlan interface{}, prevPlan interface{}, active interface{}, subscription interface{}) *MockPlanUpdateNotifier_NotifyPlanUpdated_Call {
+	return &MockPlanUpdateNotifier_NotifyPlanUpdated_Call{Call: _e.mock.On("NotifyPlanUpdated", ctx, userID, activePlan, prevPlan, active, subscription)}
 }
 
-func (_c *MockPlanUpdateNotifier_NotifyPlanUpdated_Call) Run(run func(ctx context.Context, userID string, activePlan string, prevPlan string, subscription interface{})) *MockPlanUpdateNotifier_NotifyPlanUpdated_Call {
+func (_c *MockPlanUpdateNotifier_NotifyPlanUpdated_Call) Run(run func(ctx context.Context, userID string, activePlan string, prevPlan string, active bool, subscription interface{})) *MockPlanUpdateNotifier_NotifyPlanUpdated_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(interface{}))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(bool), args[5].(interface{}))
 	})
 	return _c
 }
@@ -66,7 +118,7 @@ func (_c *MockPlanUpdateNotifier_NotifyPlanUpdated_Call) Return(_a0 error) *Mock
 	return _c
 }
 
-func (_c *MockPlanUpdateNotifier_NotifyPlanUpdated_Call) RunAndReturn(run func(context.Context, string, string, string, interface{}) error) *MockPlanUpdateNotifier_NotifyPlanUpdated_Call {
+func (_c *MockPlanUpdateNotifier_NotifyPlanUpdated_Call) RunAndReturn(run func(context.Context, string, string, string, bool, interface{}) error) *MockPlanUpdateNotifier_NotifyPlanUpdated_Call {
 	_c.Call.Return(run)
 	return _c
 }
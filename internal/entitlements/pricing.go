@@ -0,0 +1,59 @@
+package entitlements
+
+import (
+	"math"
+	"slices"
+
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// LocalizeVariants returns copies of variants with prices formatted for the
+// given BCP 47 locale (English if empty or invalid). When a variant has a price
+// point in currencyCode it becomes the variant's price; other variants keep
+// their default currency.
+func LocalizeVariants(variants []Variant, currencyCode, locale string) []Variant {
+	if variants == nil {
+		return nil
+	}
+
+	tag := language.English
+	if locale != "" {
+		if t, err := language.Parse(locale); err == nil {
+			tag = t
+		}
+	}
+
+	localized := make([]Variant, len(variants))
+	for i, v := range variants {
+		v.Prices = slices.Clone(v.Prices)
+		for j := range v.Prices {
+			v.Prices[j].FormattedPrice = FormatPrice(v.Prices[j].Price, v.Prices[j].Currency, tag)
+		}
+		if currencyCode != "" {
+			if j := slices.IndexFunc(v.Prices, func(p VariantPrice) bool {
+				return p.Currency == currencyCode
+			}); j >= 0 {
+				v.Price = v.Prices[j].Price
+				v.Currency = v.Prices[j].Currency
+			}
+		}
+		v.FormattedPrice = FormatPrice(v.Price, v.Currency, tag)
+		localized[i] = v
+	}
+	return localized
+}
+
+// FormatPrice formats an amount in the smallest unit of an ISO 4217 currency
+// for display, e.g. 1999 USD as "$ 19.99" in English. It returns an empty
+// string for unknown currencies.
+func FormatPrice(amount int, currencyCode string, tag language.Tag) string {
+	unit, err := currency.ParseISO(currencyCode)
+	if err != nil {
+		return ""
+	}
+	scale, _ := currency.Standard.Rounding(unit)
+	value := float64(amount) / math.Pow10(scale)
+	return message.NewPrinter(tag).Sprint(currency.Symbol(unit.Amount(value)))
+}
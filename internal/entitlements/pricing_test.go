@@ -0,0 +1,40 @@
+package entitlements_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+
+	"github.com/grantsy/grantsy/internal/entitlements"
+)
+
+func TestFormatPrice(t *testing.T) {
+	tests := []struct {
+		amount   int
+		currency string
+		tag      language.Tag
+		want     string
+	}{
+		{1999, "USD", language.English, "$ 19.99"},
+		{123456, "EUR", language.German, "€ 1.234,56"},
+		{1500, "JPY", language.Japanese, "￥ 1,500"},
+		{1999, "XXX1", language.English, ""},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, entitlements.FormatPrice(tt.amount, tt.currency, tt.tag))
+	}
+}
+
+func TestLocalizeVariants_DoesNotModifyInput(t *testing.T) {
+	variants := []entitlements.Variant{{
+		ID: 1, Price: 999, Currency: "USD",
+		Prices: []entitlements.VariantPrice{{Currency: "USD", Price: 999}, {Currency: "EUR", Price: 899}},
+	}}
+
+	localized := entitlements.LocalizeVariants(variants, "EUR", "")
+
+	assert.Equal(t, "EUR", localized[0].Currency)
+	assert.Equal(t, "USD", variants[0].Currency)
+	assert.Empty(t, variants[0].Prices[1].FormattedPrice)
+}
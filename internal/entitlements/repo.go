@@ -0,0 +1,217 @@
+package entitlements
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/grantsy/grantsy/internal/infra/db"
+)
+
+type Repo struct {
+	db *db.DB
+}
+
+func NewRepo(database *db.DB) *Repo {
+	return &Repo{db: database}
+}
+
+const transitionColumns = `id, user_id, prev_plan, new_plan, active, cause,
+			subscription, created_at`
+
+// InsertTransition appends a transition to the user's plan history.
+func (r *Repo) InsertTransition(ctx context.Context, t *PlanTransition) error {
+	subscription := string(t.Subscription)
+	if subscription == "" {
+		subscription = "null"
+	}
+
+	table := r.db.TableName("plan_history")
+	query := r.db.Rebind(fmt.Sprintf(`
+		INSERT INTO %s (%s)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`, table, transitionColumns))
+
+	_, err := r.db.ExecContext(
+		ctx,
+		query,
+		t.ID,
+		t.UserID,
+		t.PrevPlan,
+		t.NewPlan,
+		t.Active,
+		t.Cause,
+		subscription,
+		t.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("entitlements: failed to insert plan transition: %w", err)
+	}
+	return nil
+}
+
+// ListTransitions returns the user's plan history, newest first by the time
+// of each change, starting after the transition at cursor if set.
+func (r *Repo) ListTransitions(
+	ctx context.Context,
+	userID string,
+	cursor *TransitionCursor,
+	limit int,
+) ([]PlanTransition, error) {
+	table := r.db.TableName("plan_history")
+	args := []any{userID}
+	where := "user_id = $1"
+	if cursor != nil {
+		args = append(args, cursor.CreatedAt, cursor.CreatedAt, cursor.ID)
+		where += " AND (created_at < $2 OR (created_at = $3 AND id < $4))"
+	}
+	args = append(args, limit)
+	query := r.db.Rebind(fmt.Sprintf(`
+		SELECT %s FROM %s
+		WHERE %s
+		ORDER BY created_at DESC, id DESC
+		LIMIT $%d
+	`, transitionColumns, table, where, len(args)))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("entitlements: failed to query plan history: %w", err)
+	}
+	defer rows.Close()
+
+	var result []PlanTransition
+	for rows.Next() {
+		var t PlanTransition
+		var subscription string
+		if err := rows.Scan(
+			&t.ID, &t.UserID, &t.PrevPlan, &t.NewPlan, &t.Active, &t.Cause,
+			&subscription, &t.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("entitlements: failed to scan row: %w", err)
+		}
+		t.Subscription = []byte(subscription)
+		result = append(result, t)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("entitlements: rows error: %w", err)
+	}
+
+	return result, nil
+}
+
+// PlanAt returns the plan set by the last transition at or before at. For
+// times before the first transition, it returns the plan the user had
+// before it.
+func (r *Repo) PlanAt(ctx context.Context, userID string, at int64) (string, bool, error) {
+	table := r.db.TableName("plan_history")
+
+	var plan string
+	query := r.db.Rebind(fmt.Sprintf(`
+		SELECT new_plan FROM %s
+		WHERE user_id = $1 AND created_at <= $2
+		ORDER BY created_at DESC, id DESC
+		LIMIT 1
+	`, table))
+	err := r.db.QueryRowContext(ctx, query, userID, at).Scan(&plan)
+	if err == nil {
+		return plan, true, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return "", false, fmt.Errorf("entitlements: failed to get plan at time: %w", err)
+	}
+
+	query = r.db.Rebind(fmt.Sprintf(`
+		SELECT prev_plan FROM %s
+		WHERE user_id = $1
+		ORDER BY created_at, id
+		LIMIT 1
+	`, table))
+	err = r.db.QueryRowContext(ctx, query, userID).Scan(&plan)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", false, nil
+		}
+		return "", false, fmt.Errorf("entitlements: failed to get plan at time: %w", err)
+	}
+	return plan, true, nil
+}
+
+const configVersionColumns = `id, hash, default_plan, plans, created_at`
+
+// SaveConfigVersion stores v unless the latest version has the same hash.
+func (r *Repo) SaveConfigVersion(ctx context.Context, v *ConfigVersion) error {
+	table := r.db.TableName("config_versions")
+
+	var latest string
+	err := r.db.QueryRowContext(ctx, fmt.Sprintf(`
+		SELECT hash FROM %s ORDER BY created_at DESC, id DESC LIMIT 1
+	`, table)).Scan(&latest)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("entitlements: failed to get latest config version: %w", err)
+	}
+	if latest == v.Hash {
+		return nil
+	}
+
+	plans, err := json.Marshal(v.Plans)
+	if err != nil {
+		return fmt.Errorf("entitlements: failed to marshal plans: %w", err)
+	}
+	query := r.db.Rebind(fmt.Sprintf(`
+		INSERT INTO %s (%s)
+		VALUES ($1, $2, $3, $4, $5)
+	`, table, configVersionColumns))
+	if _, err := r.db.ExecContext(ctx, query, v.ID, v.Hash, v.DefaultPlan, string(plans), v.CreatedAt); err != nil {
+		return fmt.Errorf("entitlements: failed to insert config version: %w", err)
+	}
+	return nil
+}
+
+// ConfigVersionAt returns the version in effect at the given time, the
+// oldest version for times before it, or nil if none is stored.
+func (r *Repo) ConfigVersionAt(ctx context.Context, at int64) (*ConfigVersion, error) {
+	table := r.db.TableName("config_versions")
+
+	query := r.db.Rebind(fmt.Sprintf(`
+		SELECT %s FROM %s
+		WHERE created_at <= $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT 1
+	`, configVersionColumns, table))
+	v, err := scanConfigVersion(r.db.QueryRowContext(ctx, query, at))
+	if err == nil {
+		return v, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("entitlements: failed to get config version: %w", err)
+	}
+
+	query = fmt.Sprintf(`
+		SELECT %s FROM %s
+		ORDER BY created_at, id
+		LIMIT 1
+	`, configVersionColumns, table)
+	v, err = scanConfigVersion(r.db.QueryRowContext(ctx, query))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("entitlements: failed to get config version: %w", err)
+	}
+	return v, nil
+}
+
+func scanConfigVersion(row interface{ Scan(dest ...any) error }) (*ConfigVersion, error) {
+	var v ConfigVersion
+	var plans string
+	if err := row.Scan(&v.ID, &v.Hash, &v.DefaultPlan, &plans, &v.CreatedAt); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(plans), &v.Plans); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal plans: %w", err)
+	}
+	return &v, nil
+}
@@ -0,0 +1,214 @@
+package entitlements_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grantsy/grantsy/internal/entitlements"
+	"github.com/grantsy/grantsy/internal/entitlements/mocks"
+)
+
+func TestOnSubscriptionChange_RecordsHistory(t *testing.T) {
+	repo := entitlements.NewRepo(newTestDB(t))
+	svc, err := entitlements.NewService(testEntitlementsConfig(), testProducts(), newEmptyLoader(t), nil, nil, repo)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	sub := map[string]any{"Status": "active"}
+	require.NoError(t, svc.OnSubscriptionChange(ctx, "user1", 100, true, "subscription_created", sub))
+	require.NoError(t, svc.OnSubscriptionChange(ctx, "user1", 100, true, "subscription_cancelled", nil))
+	require.NoError(t, svc.OnSubscriptionChange(ctx, "user1", 0, false, "subscription_expired", nil))
+	require.NoError(t, svc.OnSubscriptionChange(ctx, "user2", 100, true, "subscription_created", nil))
+
+	history, err := repo.ListTransitions(ctx, "user1", nil, 10)
+	require.NoError(t, err)
+	require.Len(t, history, 3)
+
+	assert.Equal(t, "subscription_expired", history[0].Cause)
+	assert.Equal(t, "pro", history[0].PrevPlan)
+	assert.Equal(t, "free", history[0].NewPlan)
+	assert.False(t, history[0].Active)
+
+	assert.Equal(t, "subscription_cancelled", history[1].Cause)
+	assert.Equal(t, "pro", history[1].PrevPlan)
+	assert.Equal(t, "pro", history[1].NewPlan)
+
+	assert.Equal(t, "free", history[2].PrevPlan)
+	assert.Equal(t, "pro", history[2].NewPlan)
+	var snapshot map[string]any
+	require.NoError(t, json.Unmarshal(history[2].Subscription, &snapshot))
+	assert.Equal(t, "active", snapshot["Status"])
+
+	cursor := history[0].Cursor()
+	page, err := repo.ListTransitions(ctx, "user1", &cursor, 1)
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, history[1].ID, page[0].ID)
+}
+
+func TestListTransitions_OrderedByChangeTime(t *testing.T) {
+	repo := entitlements.NewRepo(newTestDB(t))
+	ctx := context.Background()
+
+	// IDs sort in insertion order, the opposite of the change times.
+	for _, tr := range []entitlements.PlanTransition{
+		{ID: "01", UserID: "user1", Cause: "subscription_updated", CreatedAt: 3000},
+		{ID: "02", UserID: "user1", Cause: "subscription_created", CreatedAt: 1000},
+		{ID: "03", UserID: "user1", Cause: "subscription_cancelled", CreatedAt: 2000},
+		{ID: "04", UserID: "user1", Cause: "subscription_resumed", CreatedAt: 2000},
+	} {
+		require.NoError(t, repo.InsertTransition(ctx, &tr))
+	}
+
+	var ids []string
+	var cursor *entitlements.TransitionCursor
+	for {
+		page, err := repo.ListTransitions(ctx, "user1", cursor, 1)
+		require.NoError(t, err)
+		if len(page) == 0 {
+			break
+		}
+		ids = append(ids, page[0].ID)
+		next := page[0].Cursor()
+		cursor = &next
+	}
+	assert.Equal(t, []string{"01", "04", "03", "02"}, ids)
+}
+
+func TestParseTransitionCursor(t *testing.T) {
+	cursor := entitlements.TransitionCursor{CreatedAt: 1718000000, ID: "0190-abc"}
+	parsed, err := entitlements.ParseTransitionCursor(cursor.String())
+	require.NoError(t, err)
+	assert.Equal(t, cursor, *parsed)
+
+	for _, s := range []string{"0190-abc", "abc_0190", "1718000000_"} {
+		_, err := entitlements.ParseTransitionCursor(s)
+		assert.Error(t, err, s)
+	}
+}
+
+// providerSubscription is a subscription last changed by its provider at
+// UpdatedAt.
+type providerSubscription struct {
+	Status    string
+	UpdatedAt int64
+}
+
+func (s *providerSubscription) ChangedAt() int64 { return s.UpdatedAt }
+
+func TestOnSubscriptionChange_HistoryDatedByProvider(t *testing.T) {
+	repo := entitlements.NewRepo(newTestDB(t))
+	svc, err := entitlements.NewService(testEntitlementsConfig(), testProducts(), newEmptyLoader(t), nil, nil, repo)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	// The expiry is delivered first, though the provider made it later.
+	expired := &providerSubscription{Status: "expired", UpdatedAt: 2000}
+	created := &providerSubscription{Status: "active", UpdatedAt: 1000}
+	require.NoError(t, svc.OnSubscriptionChange(ctx, "user1", 100, false, "subscription_expired", expired))
+	require.NoError(t, svc.OnSubscriptionChange(ctx, "user1", 100, true, "subscription_created", created))
+
+	history, err := repo.ListTransitions(ctx, "user1", nil, 10)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	times := map[string]int64{}
+	for _, tr := range history {
+		times[tr.Cause] = tr.CreatedAt
+	}
+	assert.Equal(t, map[string]int64{"subscription_expired": 2000, "subscription_created": 1000}, times)
+}
+
+func TestOnSubscriptionChange_HistoryDiscardedOnRollback(t *testing.T) {
+	database := newTestDB(t)
+	repo := entitlements.NewRepo(database)
+
+	svc, err := entitlements.NewService(testEntitlementsConfig(), testProducts(), newEmptyLoader(t), nil, nil, repo)
+	require.NoError(t, err)
+
+	err = database.InTx(context.Background(), func(ctx context.Context) error {
+		require.NoError(t, svc.OnSubscriptionChange(ctx, "user1", 100, true, "subscription_created", nil))
+		return assert.AnError
+	})
+	require.ErrorIs(t, err, assert.AnError)
+
+	history, err := repo.ListTransitions(context.Background(), "user1", nil, 10)
+	require.NoError(t, err)
+	assert.Empty(t, history)
+}
+
+func TestCheckFeatureAt(t *testing.T) {
+	repo := entitlements.NewRepo(newTestDB(t))
+	ctx := context.Background()
+
+	// Before the current config, pro did not include the api feature.
+	require.NoError(t, repo.SaveConfigVersion(ctx, &entitlements.ConfigVersion{
+		ID:          "v1",
+		Hash:        "v1",
+		DefaultPlan: "free",
+		Plans:       map[string][]string{"free": {"dashboard"}, "pro": {"dashboard", "sso"}},
+		CreatedAt:   1000,
+	}))
+	for _, tr := range []entitlements.PlanTransition{
+		{ID: "01", UserID: "user1", PrevPlan: "free", NewPlan: "pro", Active: true, CreatedAt: 1500},
+		{ID: "02", UserID: "user1", PrevPlan: "pro", NewPlan: "free", CreatedAt: 3000},
+	} {
+		require.NoError(t, repo.InsertTransition(ctx, &tr))
+	}
+
+	loader := mocks.NewMockSubscriptionLoader(t)
+	loader.EXPECT().GetActiveUserPlans(mock.Anything).Return(map[string]int{"user2": 100}, nil)
+	svc, err := entitlements.NewService(testEntitlementsConfig(), testProducts(), loader, nil, nil, repo)
+	require.NoError(t, err)
+	now := time.Now().Unix()
+
+	tests := []struct {
+		name    string
+		userID  string
+		feature string
+		at      int64
+		plan    string
+		allowed bool
+		reason  entitlements.CheckReason
+	}{
+		{"before first config version", "user1", "sso", 500, "free", false, entitlements.ReasonInsufficientPlan},
+		{"before upgrade", "user1", "dashboard", 1200, "free", true, entitlements.ReasonDefaultPlan},
+		{"after upgrade", "user1", "sso", 2000, "pro", true, entitlements.ReasonFeatureInPlan},
+		{"feature not in plan yet", "user1", "api", 2000, "pro", false, entitlements.ReasonInsufficientPlan},
+		{"after downgrade", "user1", "api", now, "free", false, entitlements.ReasonInsufficientPlan},
+		{"no history, old config", "user2", "api", 2000, "pro", false, entitlements.ReasonInsufficientPlan},
+		{"no history, current config", "user2", "api", now, "pro", true, entitlements.ReasonFeatureInPlan},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := svc.CheckFeatureAt(ctx, tt.userID, tt.feature, tt.at)
+			require.NoError(t, err)
+			assert.Equal(t, tt.plan, result.PlanID)
+			assert.Equal(t, tt.allowed, result.Allowed)
+			assert.Equal(t, tt.reason, result.Reason)
+		})
+	}
+}
+
+func TestNewService_SavesConfigVersionOnChange(t *testing.T) {
+	repo := entitlements.NewRepo(newTestDB(t))
+	ctx := context.Background()
+
+	_, err := entitlements.NewService(testEntitlementsConfig(), testProducts(), newEmptyLoader(t), nil, nil, repo)
+	require.NoError(t, err)
+	first, err := repo.ConfigVersionAt(ctx, time.Now().Unix())
+	require.NoError(t, err)
+	require.NotNil(t, first)
+	assert.Equal(t, []string{"dashboard", "api", "sso"}, first.Plans["pro"])
+
+	_, err = entitlements.NewService(testEntitlementsConfig(), testProducts(), newEmptyLoader(t), nil, nil, repo)
+	require.NoError(t, err)
+	second, err := repo.ConfigVersionAt(ctx, time.Now().Unix())
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, second.ID)
+}
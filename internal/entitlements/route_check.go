@@ -3,12 +3,15 @@ package entitlements
 import (
 	"net/http"
 	"slices"
+	"time"
 
 	"github.com/iamolegga/valmid"
 	"github.com/swaggest/openapi-go"
 	"github.com/swaggest/openapi-go/openapi31"
 
+	"github.com/grantsy/grantsy/internal/auth"
 	"github.com/grantsy/grantsy/internal/httptools"
+	"github.com/grantsy/grantsy/internal/infra/logger"
 	"github.com/grantsy/grantsy/internal/infra/metrics"
 	oa "github.com/grantsy/grantsy/internal/openapi"
 )
@@ -34,6 +37,7 @@ type CheckRequest struct {
 	UserID  string        `in:"query=user_id" query:"user_id" validate:"required"                              description:"User ID to check access for"`
 	Feature string        `in:"query=feature" query:"feature" validate:"required"                              description:"Feature ID to check access for"`
 	Expand  []CheckExpand `in:"query=expand"  query:"expand"  validate:"dive,oneof=feature plan plan.features" description:"Fields to expand (use ?expand=feature&expand=plan&expand=plan.features)"`
+	At      int64         `in:"query=at"      query:"at"      validate:"min=0"                                 description:"Unix timestamp to check access at instead of now, from the user's plan history"`
 }
 
 type CheckResponse struct {
@@ -62,9 +66,11 @@ func NewRouteCheck(service *Service) *RouteCheck {
 }
 
 func (route *RouteCheck) Register(mux *http.ServeMux, r *openapi31.Reflector) {
-	mux.Handle("GET /v1/check",
-		valmid.Middleware[CheckRequest]()(route.Handler()),
-	)
+	mux.Handle("GET /v1/check", httptools.Wrap(
+		route.Handler(),
+		auth.RequireUserScope(auth.ScopeCheckRead, auth.UserIDFromQuery),
+		valmid.Middleware[CheckRequest](),
+	))
 	RegisterCheckSchema(r)
 }
 
@@ -82,10 +88,12 @@ func RegisterCheckSchema(r *openapi31.Reflector) {
 	oa.AddErrorResponses(op)
 	op.SetSummary("Check feature access")
 	op.SetDescription(
-		"Check if a user has access to a specific feature based on their subscription plan. Use ?expand=feature&expand=plan&expand=plan.features to include additional details.",
+		"Check if a user has access to a specific feature based on their subscription plan. Use ?expand=feature&expand=plan&expand=plan.features to include additional details. Pass at to check access at a past time instead, reconstructed from the user's plan history and the config in effect then; expanded details are always from the current config. Publishable keys can only check the user in their user token.",
 	)
 	op.SetTags("Entitlements")
-	op.AddSecurity("ApiKeyAuth")
+	op.AddSecurity("ApiKeyAuth", auth.ScopeCheckRead)
+	op.AddSecurity("BearerAuth", auth.ScopeCheckRead)
+	oa.AddUserTokenSecurity(op)
 	r.AddOperation(op)
 }
 
@@ -93,8 +101,24 @@ func (route *RouteCheck) Handler() http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		input := valmid.Get[CheckRequest](r)
 
-		result := route.service.CheckFeature(input.UserID, input.Feature)
-		metrics.RecordEntitlementCheck(result.FeatureID, result.Allowed)
+		var result *CheckResult
+		if input.At != 0 {
+			if input.At > time.Now().Unix() {
+				httptools.BadRequest(w, r, "at must not be in the future")
+				return
+			}
+			var err error
+			result, err = route.service.CheckFeatureAt(r.Context(), input.UserID, input.Feature, input.At)
+			if err != nil {
+				logger.FromContext(r.Context()).
+					Error("failed to check feature at time", "error", err, "user_id", input.UserID)
+				httptools.InternalError(w, r)
+				return
+			}
+		} else {
+			result = route.service.CheckFeature(input.UserID, input.Feature)
+			metrics.RecordEntitlementCheck(result.FeatureID, result.Allowed)
+		}
 
 		resp := CheckResponse{
 			Allowed: result.Allowed,
@@ -1,16 +1,21 @@
 package entitlements_test
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/swaggest/openapi-go/openapi31"
 
+	"github.com/grantsy/grantsy/internal/auth"
+	"github.com/grantsy/grantsy/internal/auth/authtest"
 	"github.com/grantsy/grantsy/internal/entitlements"
 	"github.com/grantsy/grantsy/internal/entitlements/mocks"
 	"github.com/grantsy/grantsy/internal/httptools"
@@ -18,7 +23,7 @@ import (
 	_ "github.com/grantsy/grantsy/internal/infra/validation"
 )
 
-func newCheckMux(t *testing.T) (*http.ServeMux, *entitlements.Service) {
+func newCheckMux(t *testing.T) (http.Handler, *entitlements.Service) {
 	t.Helper()
 	loader := mocks.NewMockSubscriptionLoader(t)
 	loader.EXPECT().GetActiveUserPlans(mock.Anything).Return(map[string]int{"prouser": 100}, nil)
@@ -27,7 +32,7 @@ func newCheckMux(t *testing.T) (*http.ServeMux, *entitlements.Service) {
 	route := entitlements.NewRouteCheck(svc)
 	mux := http.NewServeMux()
 	route.Register(mux, openapi31.NewReflector())
-	return mux, svc
+	return authtest.Handler(mux), svc
 }
 
 func TestRouteCheck_AllowedFeature(t *testing.T) {
@@ -130,3 +135,66 @@ func TestRouteCheck_MissingBothParams(t *testing.T) {
 
 	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
 }
+
+func TestRouteCheck_At(t *testing.T) {
+	repo := entitlements.NewRepo(newTestDB(t))
+	require.NoError(t, repo.InsertTransition(context.Background(), &entitlements.PlanTransition{
+		ID: "01", UserID: "user1", PrevPlan: "free", NewPlan: "pro", Active: true, CreatedAt: 1500,
+	}))
+	svc, err := entitlements.NewService(testEntitlementsConfig(), testProducts(), newEmptyLoader(t), nil, nil, repo)
+	require.NoError(t, err)
+	mux := http.NewServeMux()
+	entitlements.NewRouteCheck(svc).Register(mux, openapi31.NewReflector())
+	handler := authtest.Handler(mux)
+
+	tests := []struct {
+		name    string
+		at      int64
+		code    int
+		allowed bool
+	}{
+		{"before upgrade", 1000, http.StatusOK, false},
+		{"after upgrade", 2000, http.StatusOK, true},
+		{"future", time.Now().Add(time.Hour).Unix(), http.StatusBadRequest, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := fmt.Sprintf("/v1/check?user_id=user1&feature=api&at=%d", tt.at)
+			req := httptest.NewRequest(http.MethodGet, target, nil)
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, tt.code, w.Code)
+			if tt.code != http.StatusOK {
+				return
+			}
+			var resp httptools.Response
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			assert.Equal(t, tt.allowed, resp.Data.(map[string]any)["allowed"])
+		})
+	}
+}
+
+func TestRouteCheck_Scopes(t *testing.T) {
+	svc := newTestService(t, newEmptyLoader(t), nil)
+	mux := http.NewServeMux()
+	entitlements.NewRouteCheck(svc).Register(mux, openapi31.NewReflector())
+
+	tests := []struct {
+		name   string
+		scopes []string
+		want   int
+	}{
+		{"check scope", []string{auth.ScopeCheckRead}, http.StatusOK},
+		{"other scopes", []string{auth.ScopePlansRead, auth.ScopeUsersRead}, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v1/check?user_id=user1&feature=api", nil)
+			w := httptest.NewRecorder()
+			authtest.Handler(mux, tt.scopes...).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/swaggest/openapi-go"
 	"github.com/swaggest/openapi-go/openapi31"
 
+	"github.com/grantsy/grantsy/internal/auth"
 	"github.com/grantsy/grantsy/internal/httptools"
 	oa "github.com/grantsy/grantsy/internal/openapi"
 )
@@ -29,9 +30,11 @@ func NewRouteFeature(service *Service) *RouteFeature {
 }
 
 func (route *RouteFeature) Register(mux *http.ServeMux, r *openapi31.Reflector) {
-	mux.Handle("GET /v1/features/{feature_id}",
-		valmid.Middleware[FeatureRequest]()(route.Handler()),
-	)
+	mux.Handle("GET /v1/features/{feature_id}", httptools.Wrap(
+		route.Handler(),
+		auth.RequireScope(auth.ScopePlansRead),
+		valmid.Middleware[FeatureRequest](),
+	))
 	RegisterFeatureSchema(r)
 }
 
@@ -50,7 +53,8 @@ func RegisterFeatureSchema(r *openapi31.Reflector) {
 	op.SetSummary("Get feature by ID")
 	op.SetDescription("Get details of a specific feature by its identifier")
 	op.SetTags("Features")
-	op.AddSecurity("ApiKeyAuth")
+	op.AddSecurity("ApiKeyAuth", auth.ScopePlansRead)
+	op.AddSecurity("BearerAuth", auth.ScopePlansRead)
 	r.AddOperation(op)
 }
 
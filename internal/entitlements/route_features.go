@@ -6,6 +6,7 @@ import (
 	"github.com/swaggest/openapi-go"
 	"github.com/swaggest/openapi-go/openapi31"
 
+	"github.com/grantsy/grantsy/internal/auth"
 	"github.com/grantsy/grantsy/internal/httptools"
 	oa "github.com/grantsy/grantsy/internal/openapi"
 )
@@ -23,7 +24,9 @@ func NewRouteFeatures(service *Service) *RouteFeatures {
 }
 
 func (route *RouteFeatures) Register(mux *http.ServeMux, r *openapi31.Reflector) {
-	mux.Handle("GET /v1/features", route.Handler())
+	mux.Handle("GET /v1/features",
+		auth.RequireScope(auth.ScopePlansRead)(route.Handler()),
+	)
 	RegisterFeaturesSchema(r)
 }
 
@@ -41,7 +44,8 @@ func RegisterFeaturesSchema(r *openapi31.Reflector) {
 	op.SetSummary("List all features")
 	op.SetDescription("Get all available feature definitions")
 	op.SetTags("Features")
-	op.AddSecurity("ApiKeyAuth")
+	op.AddSecurity("ApiKeyAuth", auth.ScopePlansRead)
+	op.AddSecurity("BearerAuth", auth.ScopePlansRead)
 	r.AddOperation(op)
 }
 
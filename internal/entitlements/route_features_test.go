@@ -10,19 +10,20 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/swaggest/openapi-go/openapi31"
 
+	"github.com/grantsy/grantsy/internal/auth/authtest"
 	"github.com/grantsy/grantsy/internal/entitlements"
 	"github.com/grantsy/grantsy/internal/httptools"
 
 	_ "github.com/grantsy/grantsy/internal/infra/validation"
 )
 
-func newFeaturesMux(t *testing.T) *http.ServeMux {
+func newFeaturesMux(t *testing.T) http.Handler {
 	t.Helper()
 	svc := newTestService(t, newEmptyLoader(t), nil)
 	route := entitlements.NewRouteFeatures(svc)
 	mux := http.NewServeMux()
 	route.Register(mux, openapi31.NewReflector())
-	return mux
+	return authtest.Handler(mux)
 }
 
 func TestRouteFeatures_ListAll(t *testing.T) {
@@ -9,6 +9,7 @@ import (
 	"github.com/swaggest/openapi-go"
 	"github.com/swaggest/openapi-go/openapi31"
 
+	"github.com/grantsy/grantsy/internal/auth"
 	"github.com/grantsy/grantsy/internal/httptools"
 	oa "github.com/grantsy/grantsy/internal/openapi"
 )
@@ -29,8 +30,10 @@ const (
 )
 
 type PlanRequest struct {
-	PlanID string       `in:"path=plan_id" path:"plan_id" validate:"required"            description:"Plan ID to look up"`
-	Expand []PlanExpand `in:"query=expand"                validate:"dive,oneof=features" description:"Fields to expand (use ?expand=features)" query:"expand"`
+	PlanID   string       `in:"path=plan_id" path:"plan_id" validate:"required"            description:"Plan ID to look up"`
+	Expand   []PlanExpand `in:"query=expand"                validate:"dive,oneof=features"          description:"Fields to expand (use ?expand=features)"                           query:"expand"`
+	Currency string       `in:"query=currency"              validate:"omitempty,iso4217"            description:"Show prices in this ISO 4217 currency where a price point exists" query:"currency"`
+	Locale   string       `in:"query=locale"                validate:"omitempty,bcp47_language_tag" description:"BCP 47 locale for formatted prices (defaults to en)"              query:"locale"`
 }
 
 type PlanResponse struct {
@@ -47,9 +50,11 @@ func NewRoutePlan(service *Service, pricing PricingProvider) *RoutePlan {
 }
 
 func (route *RoutePlan) Register(mux *http.ServeMux, r *openapi31.Reflector) {
-	mux.Handle("GET /v1/plans/{plan_id}",
-		valmid.Middleware[PlanRequest]()(route.Handler()),
-	)
+	mux.Handle("GET /v1/plans/{plan_id}", httptools.Wrap(
+		route.Handler(),
+		auth.RequireScope(auth.ScopePlansRead),
+		valmid.Middleware[PlanRequest](),
+	))
 	RegisterPlanSchema(r)
 }
 
@@ -67,10 +72,11 @@ func RegisterPlanSchema(r *openapi31.Reflector) {
 	oa.AddErrorResponses(op)
 	op.SetSummary("Get plan by ID")
 	op.SetDescription(
-		"Get details of a specific plan by its identifier. Use ?expand=features to include feature details.",
+		"Get details of a specific plan by its identifier. Use ?expand=features to include feature details, ?currency to pick a price point and ?locale to format prices.",
 	)
 	op.SetTags("Plans")
-	op.AddSecurity("ApiKeyAuth")
+	op.AddSecurity("ApiKeyAuth", auth.ScopePlansRead)
+	op.AddSecurity("BearerAuth", auth.ScopePlansRead)
 	r.AddOperation(op)
 }
 
@@ -84,7 +90,7 @@ func (route *RoutePlan) Handler() http.Handler {
 			return
 		}
 
-		variants := route.pricing.GetPlanVariants(input.PlanID)
+		variants := LocalizeVariants(route.pricing.GetPlanVariants(input.PlanID), input.Currency, input.Locale)
 
 		var planDTO Plan
 		if slices.Contains(input.Expand, PlanExpandFeatures) {
@@ -8,6 +8,7 @@ import (
 	"github.com/swaggest/openapi-go"
 	"github.com/swaggest/openapi-go/openapi31"
 
+	"github.com/grantsy/grantsy/internal/auth"
 	"github.com/grantsy/grantsy/internal/httptools"
 	"github.com/grantsy/grantsy/internal/infra/config"
 	oa "github.com/grantsy/grantsy/internal/openapi"
@@ -46,7 +47,9 @@ const (
 )
 
 type PlansRequest struct {
-	Expand []PlansExpand `in:"query=expand" query:"expand" validate:"dive,oneof=features" description:"Fields to expand (use ?expand=features)"`
+	Expand   []PlansExpand `in:"query=expand"   query:"expand"   validate:"dive,oneof=features"  description:"Fields to expand (use ?expand=features)"`
+	Currency string        `in:"query=currency" query:"currency" validate:"omitempty,iso4217" description:"Show prices in this ISO 4217 currency where a price point exists"`
+	Locale   string        `in:"query=locale"   query:"locale"   validate:"omitempty,bcp47_language_tag" description:"BCP 47 locale for formatted prices (defaults to en)"`
 }
 
 type PlansResponse struct {
@@ -68,15 +71,26 @@ type Feature struct {
 }
 
 type Variant struct {
-	ID                 int    `json:"id"                             description:"Variant identifier"                    required:"true"`
-	Name               string `json:"name"                           description:"Variant display name"                  required:"true"`
-	Price              int    `json:"price"                          description:"Price in cents"                        required:"true"`
-	Interval           string `json:"interval"                       description:"Billing interval (month, year, etc.)"  required:"true"`
-	IntervalCount      int    `json:"interval_count"                 description:"Number of intervals between billings"  required:"true"`
-	HasFreeTrial       bool   `json:"has_free_trial"                 description:"Whether this variant has a free trial" required:"true"`
-	TrialInterval      string `json:"trial_interval,omitempty"       description:"Trial billing interval"`
-	TrialIntervalCount int    `json:"trial_interval_count,omitempty" description:"Trial duration in intervals"`
-	Sort               int    `json:"sort"                           description:"Display order"                         required:"true"`
+	ID                 int            `json:"id"                             description:"Variant identifier"                                   required:"true"`
+	Name               string         `json:"name"                           description:"Variant display name"                                 required:"true"`
+	Price              int            `json:"price"                          description:"Price in the smallest unit of the currency (e.g. cents)" required:"true"`
+	Currency           string         `json:"currency"                       description:"ISO 4217 currency code of the price"                  required:"true"`
+	FormattedPrice     string         `json:"formatted_price"                description:"Price formatted for display in the requested locale"  required:"true"`
+	TaxInclusive       bool           `json:"tax_inclusive"                  description:"Whether the price includes tax"                       required:"true"`
+	Prices             []VariantPrice `json:"prices,omitempty"               description:"Price points in other currencies"`
+	Interval           string         `json:"interval"                       description:"Billing interval (month, year, etc.)"                 required:"true"`
+	IntervalCount      int            `json:"interval_count"                 description:"Number of intervals between billings"                 required:"true"`
+	HasFreeTrial       bool           `json:"has_free_trial"                 description:"Whether this variant has a free trial"                required:"true"`
+	TrialInterval      string         `json:"trial_interval,omitempty"       description:"Trial billing interval"`
+	TrialIntervalCount int            `json:"trial_interval_count,omitempty" description:"Trial duration in intervals"`
+	Sort               int            `json:"sort"                           description:"Display order"                                        required:"true"`
+}
+
+// VariantPrice is a variant's price in one currency.
+type VariantPrice struct {
+	Currency       string `json:"currency"        description:"ISO 4217 currency code"                             required:"true"`
+	Price          int    `json:"price"           description:"Price in the smallest unit of the currency"         required:"true"`
+	FormattedPrice string `json:"formatted_price" description:"Price formatted for display in the requested locale" required:"true"`
 }
 
 type RoutePlans struct {
@@ -89,9 +103,11 @@ func NewRoutePlans(service *Service, pricing PricingProvider) *RoutePlans {
 }
 
 func (route *RoutePlans) Register(mux *http.ServeMux, r *openapi31.Reflector) {
-	mux.Handle("GET /v1/plans",
-		valmid.Middleware[PlansRequest]()(route.Handler()),
-	)
+	mux.Handle("GET /v1/plans", httptools.Wrap(
+		route.Handler(),
+		auth.RequireScope(auth.ScopePlansRead),
+		valmid.Middleware[PlansRequest](),
+	))
 	RegisterPlansSchema(r)
 }
 
@@ -109,10 +125,11 @@ func RegisterPlansSchema(r *openapi31.Reflector) {
 	oa.AddErrorResponses(op)
 	op.SetSummary("List all plans")
 	op.SetDescription(
-		"Get all available subscription plans with their pricing variants. Use ?expand=features to include features.",
+		"Get all available subscription plans with their pricing variants. Use ?expand=features to include features, ?currency to pick a price point and ?locale to format prices.",
 	)
 	op.SetTags("Plans")
-	op.AddSecurity("ApiKeyAuth")
+	op.AddSecurity("ApiKeyAuth", auth.ScopePlansRead)
+	op.AddSecurity("BearerAuth", auth.ScopePlansRead)
 	r.AddOperation(op)
 }
 
@@ -126,10 +143,11 @@ func (route *RoutePlans) Handler() http.Handler {
 
 		planDTOs := make([]Plan, len(plans))
 		for i, p := range plans {
+			variants := LocalizeVariants(route.pricing.GetPlanVariants(p.ID), input.Currency, input.Locale)
 			if includeFeatures {
-				planDTOs[i] = ToPlan(p, features, route.pricing.GetPlanVariants(p.ID))
+				planDTOs[i] = ToPlan(p, features, variants)
 			} else {
-				planDTOs[i] = ToPlanSummary(p, route.pricing.GetPlanVariants(p.ID))
+				planDTOs[i] = ToPlanSummary(p, variants)
 			}
 		}
 
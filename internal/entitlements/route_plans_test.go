@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/swaggest/openapi-go/openapi31"
 
+	"github.com/grantsy/grantsy/internal/auth/authtest"
 	"github.com/grantsy/grantsy/internal/entitlements"
 	"github.com/grantsy/grantsy/internal/entitlements/mocks"
 	"github.com/grantsy/grantsy/internal/httptools"
@@ -18,13 +19,13 @@ import (
 	_ "github.com/grantsy/grantsy/internal/infra/validation"
 )
 
-func newPlansMux(t *testing.T, pricing entitlements.PricingProvider) *http.ServeMux {
+func newPlansMux(t *testing.T, pricing entitlements.PricingProvider) http.Handler {
 	t.Helper()
 	svc := newTestService(t, newEmptyLoader(t), nil)
 	route := entitlements.NewRoutePlans(svc, pricing)
 	mux := http.NewServeMux()
 	route.Register(mux, openapi31.NewReflector())
-	return mux
+	return authtest.Handler(mux)
 }
 
 func TestRoutePlans_BasicList(t *testing.T) {
@@ -111,3 +112,54 @@ func TestRoutePlans_EmptyVariants(t *testing.T) {
 		assert.False(t, hasVariants, "plan %s should not have variants", plan["id"])
 	}
 }
+
+func TestRoutePlans_CurrencyAndLocale(t *testing.T) {
+	pricing := mocks.NewMockPricingProvider(t)
+	pricing.EXPECT().GetPlanVariants("free").Return(nil)
+	pricing.EXPECT().GetPlanVariants("pro").Return([]entitlements.Variant{
+		{
+			ID: 1, Name: "Monthly", Price: 999, Currency: "USD", Interval: "month", IntervalCount: 1,
+			Prices: []entitlements.VariantPrice{
+				{Currency: "USD", Price: 999},
+				{Currency: "EUR", Price: 899},
+			},
+		},
+		{ID: 2, Name: "Yearly", Price: 9900, Currency: "USD", Interval: "year", IntervalCount: 1},
+	})
+
+	mux := newPlansMux(t, pricing)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/plans?currency=EUR&locale=de-DE", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp httptools.Response
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+
+	plans := resp.Data.(map[string]any)["plans"].([]any)
+	variants := plans[1].(map[string]any)["variants"].([]any)
+	require.Len(t, variants, 2)
+
+	monthly := variants[0].(map[string]any)
+	assert.Equal(t, "EUR", monthly["currency"])
+	assert.InDelta(t, 899, monthly["price"], 0)
+	assert.Equal(t, "€ 8,99", monthly["formatted_price"])
+	assert.Len(t, monthly["prices"], 2)
+
+	// No EUR price point, default currency is kept
+	yearly := variants[1].(map[string]any)
+	assert.Equal(t, "USD", yearly["currency"])
+	assert.Equal(t, "$ 99,00", yearly["formatted_price"])
+}
+
+func TestRoutePlans_InvalidCurrency(t *testing.T) {
+	mux := newPlansMux(t, mocks.NewMockPricingProvider(t))
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/plans?currency=EURO", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
@@ -3,13 +3,19 @@ package entitlements
 import (
 	"context"
 	_ "embed"
+	"encoding/json"
 	"fmt"
+	"log/slog"
+	"slices"
 	"sync"
+	"time"
 
 	"github.com/casbin/casbin/v2"
 	"github.com/casbin/casbin/v2/model"
+	"github.com/google/uuid"
 
 	"github.com/grantsy/grantsy/internal/infra/config"
+	"github.com/grantsy/grantsy/internal/infra/db"
 	"github.com/grantsy/grantsy/internal/infra/metrics"
 )
 
@@ -25,13 +31,24 @@ type PricingProvider interface {
 	GetPlanVariants(planID string) []Variant
 }
 
-// PlanUpdateNotifier is called when a user's plan changes
+// PlanUpdateNotifier is called when a user's plan or billing state changes
 type PlanUpdateNotifier interface {
 	NotifyPlanUpdated(
 		ctx context.Context,
 		userID, activePlan, prevPlan string,
+		active bool,
 		subscription any,
 	) error
+	NotifyPayment(
+		ctx context.Context,
+		userID, activePlan, outcome string,
+		subscription, payment any,
+	) error
+}
+
+// PlanChangeRecorder records plan changes in the audit log.
+type PlanChangeRecorder interface {
+	RecordPlanChange(ctx context.Context, userID, prevPlan, activePlan string, active bool) error
 }
 
 //go:embed casbin_model.conf
@@ -42,6 +59,8 @@ type Service struct {
 	ent                 *config.EntitlementsConfig
 	subLoader           SubscriptionLoader
 	notifier            PlanUpdateNotifier
+	auditor             PlanChangeRecorder
+	history             HistoryStore
 	mu                  sync.RWMutex
 	plansByID           map[string]*config.PlanConfig
 	featuresByID        map[string]*config.FeatureConfig
@@ -71,6 +90,8 @@ func NewService(
 	products []config.ProductMapping,
 	subLoader SubscriptionLoader,
 	notifier PlanUpdateNotifier,
+	auditor PlanChangeRecorder,
+	history HistoryStore,
 ) (*Service, error) {
 	m, err := model.NewModelFromString(casbinModel)
 	if err != nil {
@@ -87,6 +108,8 @@ func NewService(
 		ent:       ent,
 		subLoader: subLoader,
 		notifier:  notifier,
+		auditor:   auditor,
+		history:   history,
 		plansByID: make(map[string]*config.PlanConfig, len(ent.Plans)),
 		featuresByID: make(
 			map[string]*config.FeatureConfig,
@@ -102,6 +125,12 @@ func NewService(
 		return nil, fmt.Errorf("entitlements: failed to load policies: %w", err)
 	}
 
+	if history != nil {
+		if err := history.SaveConfigVersion(context.Background(), s.configVersion()); err != nil {
+			return nil, fmt.Errorf("entitlements: failed to save config version: %w", err)
+		}
+	}
+
 	if err := s.loadSubscriptions(context.Background()); err != nil {
 		return nil, fmt.Errorf(
 			"entitlements: failed to load subscriptions: %w",
@@ -172,28 +201,28 @@ func (s *Service) CheckFeature(userID, featureID string) *CheckResult {
 		_, allowed = s.defaultPlanFeatures[featureID]
 	}
 
-	var reason CheckReason
-	if planID == "" {
-		reason = ReasonNoSubscription
-	} else if allowed {
-		if s.ent.DefaultPlan != "" && planID == s.ent.DefaultPlan {
-			reason = ReasonDefaultPlan
-		} else {
-			reason = ReasonFeatureInPlan
-		}
-	} else {
-		reason = ReasonInsufficientPlan
-	}
-
 	return &CheckResult{
 		Allowed:   allowed,
 		FeatureID: featureID,
 		UserID:    userID,
 		PlanID:    planID,
-		Reason:    reason,
+		Reason:    checkReason(planID, s.ent.DefaultPlan, allowed),
 	}
 }
 
+func checkReason(planID, defaultPlan string, allowed bool) CheckReason {
+	if planID == "" {
+		return ReasonNoSubscription
+	}
+	if !allowed {
+		return ReasonInsufficientPlan
+	}
+	if defaultPlan != "" && planID == defaultPlan {
+		return ReasonDefaultPlan
+	}
+	return ReasonFeatureInPlan
+}
+
 func (s *Service) getUserPlan(userID string) string {
 	roles, _ := s.enforcer.GetRolesForUser(userID)
 	if len(roles) > 0 {
@@ -208,6 +237,23 @@ func (s *Service) GetUserPlan(userID string) string {
 	return s.getUserPlan(userID)
 }
 
+// GetPlanUsers returns the users a subscription assigned planID to, sorted by
+// ID. ok is false for the default plan, which users have without being
+// assigned it.
+func (s *Service) GetPlanUsers(planID string) (userIDs []string, ok bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if planID == s.ent.DefaultPlan {
+		return nil, false
+	}
+
+	// The enforcer reports an error for a plan nobody was assigned.
+	users, _ := s.enforcer.GetUsersForRole(planID)
+	slices.Sort(users)
+	return users, true
+}
+
 func (s *Service) GetUserFeatures(userID string) []string {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -220,6 +266,33 @@ func (s *Service) GetUserFeatures(userID string) []string {
 	return plan.Features
 }
 
+// DiffPlanFeatures returns the features gained and lost when moving from one
+// plan to another, in the order they are listed in the plans' config.
+// An unknown plan is treated as having no features.
+func (s *Service) DiffPlanFeatures(fromPlanID, toPlanID string) (gained, lost []string) {
+	var from, to []string
+	if plan := s.GetPlan(fromPlanID); plan != nil {
+		from = plan.Features
+	}
+	if plan := s.GetPlan(toPlanID); plan != nil {
+		to = plan.Features
+	}
+
+	gained = []string{}
+	for _, f := range to {
+		if !slices.Contains(from, f) {
+			gained = append(gained, f)
+		}
+	}
+	lost = []string{}
+	for _, f := range from {
+		if !slices.Contains(to, f) {
+			lost = append(lost, f)
+		}
+	}
+	return gained, lost
+}
+
 // OnSubscriptionChange handles subscription state changes.
 // Implements subscriptions.SubscriptionObserver interface.
 func (s *Service) OnSubscriptionChange(
@@ -227,38 +300,136 @@ func (s *Service) OnSubscriptionChange(
 	userID string,
 	productID int,
 	active bool,
+	cause string,
 	subscription any,
 ) error {
 	// Get previous plan before any changes
 	prevPlan := s.GetUserPlan(userID)
 
+	activePlan := prevPlan
 	if active && productID != 0 {
-		if err := s.activateUser(userID, productID); err != nil {
-			return err
+		activePlan = s.ResolvePlanFromProduct(productID)
+		if activePlan == "" {
+			activePlan = s.ent.DefaultPlan
 		}
 	} else if !active {
-		// Expired - deactivate
-		if err := s.deactivateUser(userID); err != nil {
-			return err
-		}
+		activePlan = s.ent.DefaultPlan
 	}
 
-	// Get current plan after update
-	activePlan := s.GetUserPlan(userID)
-
 	// Notify webhooks
 	if s.notifier != nil {
-		return s.notifier.NotifyPlanUpdated(
+		if err := s.notifier.NotifyPlanUpdated(
 			ctx,
 			userID,
 			activePlan,
 			prevPlan,
+			active,
 			subscription,
-		)
+		); err != nil {
+			return err
+		}
+	}
+
+	// Recorded in the same transaction as the subscription change.
+	if s.auditor != nil && activePlan != prevPlan {
+		if err := s.auditor.RecordPlanChange(ctx, userID, prevPlan, activePlan, active); err != nil {
+			return err
+		}
+	}
+	if s.history != nil {
+		if err := s.recordTransition(ctx, userID, prevPlan, activePlan, active, cause, subscription); err != nil {
+			return err
+		}
+	}
+
+	// Applied within the transaction, so a failure rolls back the subscription
+	// change and the webhook is retried. A rollback restores the user's
+	// previous plans; until the commit, checks may already see the new one.
+	var applyErr error
+	if active && productID != 0 {
+		s.restoreOnRollback(ctx, userID)
+		applyErr = s.activateUser(userID, productID)
+	} else if !active {
+		// Expired - deactivate
+		s.restoreOnRollback(ctx, userID)
+		applyErr = s.deactivateUser(userID)
+	}
+	if applyErr != nil {
+		return fmt.Errorf("entitlements: failed to apply plan change: %w", applyErr)
 	}
 	return nil
 }
 
+// restoreOnRollback puts back the user's current plans if the transaction in
+// ctx rolls back.
+func (s *Service) restoreOnRollback(ctx context.Context, userID string) {
+	s.mu.RLock()
+	plans, _ := s.enforcer.GetRolesForUser(userID)
+	s.mu.RUnlock()
+
+	db.AfterRollback(ctx, func() {
+		if err := s.restoreUser(userID, plans); err != nil {
+			slog.ErrorContext(ctx, "failed to restore plan after rollback", "user_id", userID, "error", err)
+		}
+	})
+}
+
+// changedAter is implemented by subscriptions that know when their provider
+// last changed them, such as *subscriptions.Subscription.
+type changedAter interface {
+	ChangedAt() int64
+}
+
+// recordTransition adds the subscription change to the user's plan history,
+// dated when the provider made it, so delayed or retried webhooks don't move
+// it later in the history.
+func (s *Service) recordTransition(
+	ctx context.Context,
+	userID, prevPlan, activePlan string,
+	active bool,
+	cause string,
+	subscription any,
+) error {
+	snapshot, err := json.Marshal(subscription)
+	if err != nil {
+		return fmt.Errorf("entitlements: failed to marshal subscription: %w", err)
+	}
+	changedAt := time.Now().Unix()
+	if sub, ok := subscription.(changedAter); ok && sub.ChangedAt() != 0 {
+		changedAt = sub.ChangedAt()
+	}
+	return s.history.InsertTransition(ctx, &PlanTransition{
+		ID:           uuid.Must(uuid.NewV7()).String(),
+		UserID:       userID,
+		PrevPlan:     prevPlan,
+		NewPlan:      activePlan,
+		Active:       active,
+		Cause:        cause,
+		Subscription: snapshot,
+		CreatedAt:    changedAt,
+	})
+}
+
+// OnPayment forwards a subscription payment event along with the user's plan.
+// Implements subscriptions.SubscriptionObserver interface.
+func (s *Service) OnPayment(
+	ctx context.Context,
+	userID, outcome string,
+	subscription, payment any,
+) error {
+	if s.notifier == nil {
+		return nil
+	}
+	return s.notifier.NotifyPayment(
+		ctx,
+		userID,
+		s.GetUserPlan(userID),
+		outcome,
+		subscription,
+		payment,
+	)
+}
+
 // activateUser assigns a plan to a user based on productID.
 func (s *Service) activateUser(userID string, productID int) error {
 	s.mu.Lock()
@@ -293,6 +464,23 @@ func (s *Service) deactivateUser(userID string) error {
 	return nil
 }
 
+// restoreUser replaces the user's plan assignments with plans.
+func (s *Service) restoreUser(userID string, plans []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.enforcer.DeleteRolesForUser(userID); err != nil {
+		return fmt.Errorf("failed to delete roles for user %s: %w", userID, err)
+	}
+	for _, planID := range plans {
+		if _, err := s.enforcer.AddGroupingPolicy(userID, planID); err != nil {
+			return fmt.Errorf("failed to add grouping for user %s: %w", userID, err)
+		}
+	}
+	s.updateSubscriptionMetrics()
+	return nil
+}
+
 func (s *Service) GetPlans() []config.PlanConfig {
 	return s.ent.Plans
 }
@@ -3,6 +3,7 @@ package entitlements_test
 import (
 	"context"
 	"errors"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -12,6 +13,7 @@ import (
 	"github.com/grantsy/grantsy/internal/entitlements"
 	"github.com/grantsy/grantsy/internal/entitlements/mocks"
 	"github.com/grantsy/grantsy/internal/infra/config"
+	"github.com/grantsy/grantsy/internal/infra/db"
 )
 
 func testEntitlementsConfig() *config.EntitlementsConfig {
@@ -41,7 +43,7 @@ func newTestService(
 	notifier entitlements.PlanUpdateNotifier,
 ) *entitlements.Service {
 	t.Helper()
-	svc, err := entitlements.NewService(testEntitlementsConfig(), testProducts(), loader, notifier)
+	svc, err := entitlements.NewService(testEntitlementsConfig(), testProducts(), loader, notifier, nil, nil)
 	require.NoError(t, err)
 	return svc
 }
@@ -69,7 +71,7 @@ func TestNewService_SubscriptionLoaderError(t *testing.T) {
 	loader := mocks.NewMockSubscriptionLoader(t)
 	loader.EXPECT().GetActiveUserPlans(mock.Anything).Return(nil, errors.New("db error"))
 
-	_, err := entitlements.NewService(testEntitlementsConfig(), testProducts(), loader, nil)
+	_, err := entitlements.NewService(testEntitlementsConfig(), testProducts(), loader, nil, nil, nil)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to load subscriptions")
 }
@@ -133,7 +135,7 @@ func TestCheckFeature_DeniedNoDefaultPlan(t *testing.T) {
 	cfg.DefaultPlan = ""
 	loader := newEmptyLoader(t)
 
-	svc, err := entitlements.NewService(cfg, testProducts(), loader, nil)
+	svc, err := entitlements.NewService(cfg, testProducts(), loader, nil, nil, nil)
 	require.NoError(t, err)
 
 	result := svc.CheckFeature("user1", "dashboard")
@@ -162,12 +164,31 @@ func TestGetUserPlan_NoDefaultPlan(t *testing.T) {
 	cfg.DefaultPlan = ""
 	loader := newEmptyLoader(t)
 
-	svc, err := entitlements.NewService(cfg, testProducts(), loader, nil)
+	svc, err := entitlements.NewService(cfg, testProducts(), loader, nil, nil, nil)
 	require.NoError(t, err)
 
 	assert.Equal(t, "", svc.GetUserPlan("user1"))
 }
 
+// --- GetPlanUsers ---
+
+func TestGetPlanUsers(t *testing.T) {
+	loader := mocks.NewMockSubscriptionLoader(t)
+	loader.EXPECT().GetActiveUserPlans(mock.Anything).Return(map[string]int{"user2": 100, "user1": 100}, nil)
+	svc := newTestService(t, loader, nil)
+
+	users, ok := svc.GetPlanUsers("pro")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"user1", "user2"}, users)
+
+	users, ok = svc.GetPlanUsers("enterprise")
+	assert.True(t, ok)
+	assert.Empty(t, users)
+
+	_, ok = svc.GetPlanUsers("free")
+	assert.False(t, ok, "default plan users are not assigned it")
+}
+
 // --- GetUserFeatures ---
 
 func TestGetUserFeatures_WithPlan(t *testing.T) {
@@ -190,19 +211,42 @@ func TestGetUserFeatures_UnknownPlan(t *testing.T) {
 	cfg.DefaultPlan = ""
 	loader := newEmptyLoader(t)
 
-	svc, err := entitlements.NewService(cfg, testProducts(), loader, nil)
+	svc, err := entitlements.NewService(cfg, testProducts(), loader, nil, nil, nil)
 	require.NoError(t, err)
 
 	features := svc.GetUserFeatures("user1")
 	assert.Equal(t, []string{}, features)
 }
 
+// --- DiffPlanFeatures ---
+
+func TestDiffPlanFeatures_Upgrade(t *testing.T) {
+	svc := newTestService(t, newEmptyLoader(t), nil)
+	gained, lost := svc.DiffPlanFeatures("free", "pro")
+	assert.Equal(t, []string{"api", "sso"}, gained)
+	assert.Equal(t, []string{}, lost)
+}
+
+func TestDiffPlanFeatures_Downgrade(t *testing.T) {
+	svc := newTestService(t, newEmptyLoader(t), nil)
+	gained, lost := svc.DiffPlanFeatures("pro", "free")
+	assert.Equal(t, []string{}, gained)
+	assert.Equal(t, []string{"api", "sso"}, lost)
+}
+
+func TestDiffPlanFeatures_UnknownFromPlan(t *testing.T) {
+	svc := newTestService(t, newEmptyLoader(t), nil)
+	gained, lost := svc.DiffPlanFeatures("", "free")
+	assert.Equal(t, []string{"dashboard"}, gained)
+	assert.Equal(t, []string{}, lost)
+}
+
 // --- OnSubscriptionChange ---
 
 func TestOnSubscriptionChange_Activate(t *testing.T) {
 	svc := newTestService(t, newEmptyLoader(t), nil)
 
-	err := svc.OnSubscriptionChange(context.Background(), "user1", 100, true, nil)
+	err := svc.OnSubscriptionChange(context.Background(), "user1", 100, true, "subscription_created", nil)
 	require.NoError(t, err)
 
 	assert.Equal(t, "pro", svc.GetUserPlan("user1"))
@@ -211,11 +255,11 @@ func TestOnSubscriptionChange_Activate(t *testing.T) {
 func TestOnSubscriptionChange_Deactivate(t *testing.T) {
 	svc := newTestService(t, newEmptyLoader(t), nil)
 
-	err := svc.OnSubscriptionChange(context.Background(), "user1", 100, true, nil)
+	err := svc.OnSubscriptionChange(context.Background(), "user1", 100, true, "subscription_created", nil)
 	require.NoError(t, err)
 	assert.Equal(t, "pro", svc.GetUserPlan("user1"))
 
-	err = svc.OnSubscriptionChange(context.Background(), "user1", 0, false, nil)
+	err = svc.OnSubscriptionChange(context.Background(), "user1", 0, false, "subscription_expired", nil)
 	require.NoError(t, err)
 	assert.Equal(t, "free", svc.GetUserPlan("user1"))
 }
@@ -223,19 +267,19 @@ func TestOnSubscriptionChange_Deactivate(t *testing.T) {
 func TestOnSubscriptionChange_NotifierCalled(t *testing.T) {
 	notifier := mocks.NewMockPlanUpdateNotifier(t)
 	notifier.EXPECT().
-		NotifyPlanUpdated(mock.Anything, "user1", "pro", "free", mock.Anything).
+		NotifyPlanUpdated(mock.Anything, "user1", "pro", "free", true, mock.Anything).
 		Return(nil)
 
 	svc := newTestService(t, newEmptyLoader(t), notifier)
 
-	err := svc.OnSubscriptionChange(context.Background(), "user1", 100, true, nil)
+	err := svc.OnSubscriptionChange(context.Background(), "user1", 100, true, "subscription_created", nil)
 	require.NoError(t, err)
 }
 
 func TestOnSubscriptionChange_NotifierNil(t *testing.T) {
 	svc := newTestService(t, newEmptyLoader(t), nil)
 
-	err := svc.OnSubscriptionChange(context.Background(), "user1", 100, true, nil)
+	err := svc.OnSubscriptionChange(context.Background(), "user1", 100, true, "subscription_created", nil)
 	require.NoError(t, err)
 	assert.Equal(t, "pro", svc.GetUserPlan("user1"))
 }
@@ -243,20 +287,127 @@ func TestOnSubscriptionChange_NotifierNil(t *testing.T) {
 func TestOnSubscriptionChange_NotifierError(t *testing.T) {
 	notifier := mocks.NewMockPlanUpdateNotifier(t)
 	notifier.EXPECT().
-		NotifyPlanUpdated(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		NotifyPlanUpdated(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 		Return(errors.New("webhook error"))
 
 	svc := newTestService(t, newEmptyLoader(t), notifier)
 
-	err := svc.OnSubscriptionChange(context.Background(), "user1", 100, true, nil)
+	err := svc.OnSubscriptionChange(context.Background(), "user1", 100, true, "subscription_created", nil)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "webhook error")
+	assert.Equal(t, "free", svc.GetUserPlan("user1"))
+}
+
+func newTestDB(t *testing.T) *db.DB {
+	t.Helper()
+
+	dsn := filepath.Join(t.TempDir(), "test.db")
+	require.NoError(t, db.Migrate("sqlite", dsn, ""), "sqlite migration failed")
+
+	database, err := db.New("sqlite", dsn, "")
+	require.NoError(t, err, "sqlite connection failed")
+	t.Cleanup(func() { database.Close() })
+
+	return database
+}
+
+func TestOnSubscriptionChange_InTx_Committed(t *testing.T) {
+	svc := newTestService(t, newEmptyLoader(t), nil)
+	database := newTestDB(t)
+
+	err := database.InTx(context.Background(), func(ctx context.Context) error {
+		return svc.OnSubscriptionChange(ctx, "user1", 100, true, "subscription_created", nil)
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "pro", svc.GetUserPlan("user1"))
+}
+
+func TestOnSubscriptionChange_InTx_RestoredOnRollback(t *testing.T) {
+	loader := mocks.NewMockSubscriptionLoader(t)
+	loader.EXPECT().GetActiveUserPlans(mock.Anything).Return(map[string]int{"user1": 100}, nil)
+	svc := newTestService(t, loader, nil)
+	database := newTestDB(t)
+
+	err := database.InTx(context.Background(), func(ctx context.Context) error {
+		require.NoError(t, svc.OnSubscriptionChange(ctx, "user1", 0, false, "subscription_expired", nil))
+		require.NoError(t, svc.OnSubscriptionChange(ctx, "user2", 100, true, "subscription_created", nil))
+		return errors.New("rollback")
+	})
+	require.Error(t, err)
+
+	assert.Equal(t, "pro", svc.GetUserPlan("user1"))
+	assert.Equal(t, "free", svc.GetUserPlan("user2"))
+}
+
+func TestOnSubscriptionChange_NotifierExpired(t *testing.T) {
+	loader := mocks.NewMockSubscriptionLoader(t)
+	loader.EXPECT().GetActiveUserPlans(mock.Anything).Return(map[string]int{"user1": 100}, nil)
+	notifier := mocks.NewMockPlanUpdateNotifier(t)
+	notifier.EXPECT().
+		NotifyPlanUpdated(mock.Anything, "user1", "free", "pro", false, mock.Anything).
+		Return(nil)
+
+	svc := newTestService(t, loader, notifier)
+
+	err := svc.OnSubscriptionChange(context.Background(), "user1", 100, false, "subscription_expired", nil)
+	require.NoError(t, err)
+}
+
+func TestOnSubscriptionChange_AuditRecorded(t *testing.T) {
+	auditor := mocks.NewMockPlanChangeRecorder(t)
+	auditor.EXPECT().RecordPlanChange(mock.Anything, "user1", "free", "pro", true).Return(nil).Once()
+	auditor.EXPECT().RecordPlanChange(mock.Anything, "user1", "pro", "free", false).Return(nil).Once()
+
+	svc, err := entitlements.NewService(testEntitlementsConfig(), testProducts(), newEmptyLoader(t), nil, auditor, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, svc.OnSubscriptionChange(context.Background(), "user1", 100, true, "subscription_created", nil))
+	// Renewal on the same plan is not a plan change.
+	require.NoError(t, svc.OnSubscriptionChange(context.Background(), "user1", 100, true, "subscription_updated", nil))
+	require.NoError(t, svc.OnSubscriptionChange(context.Background(), "user1", 0, false, "subscription_expired", nil))
+}
+
+func TestOnSubscriptionChange_AuditError(t *testing.T) {
+	auditor := mocks.NewMockPlanChangeRecorder(t)
+	auditor.EXPECT().RecordPlanChange(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(errors.New("db error"))
+
+	svc, err := entitlements.NewService(testEntitlementsConfig(), testProducts(), newEmptyLoader(t), nil, auditor, nil)
+	require.NoError(t, err)
+
+	err = svc.OnSubscriptionChange(context.Background(), "user1", 100, true, "subscription_created", nil)
+	require.Error(t, err)
+	assert.Equal(t, "free", svc.GetUserPlan("user1"))
+}
+
+// --- OnPayment ---
+
+func TestOnPayment_NotifiesWithUserPlan(t *testing.T) {
+	loader := mocks.NewMockSubscriptionLoader(t)
+	loader.EXPECT().GetActiveUserPlans(mock.Anything).Return(map[string]int{"user1": 100}, nil)
+	notifier := mocks.NewMockPlanUpdateNotifier(t)
+	notifier.EXPECT().
+		NotifyPayment(mock.Anything, "user1", "pro", "failed", mock.Anything, mock.Anything).
+		Return(nil)
+
+	svc := newTestService(t, loader, notifier)
+
+	err := svc.OnPayment(context.Background(), "user1", "failed", nil, nil)
+	require.NoError(t, err)
+}
+
+func TestOnPayment_NotifierNil(t *testing.T) {
+	svc := newTestService(t, newEmptyLoader(t), nil)
+
+	err := svc.OnPayment(context.Background(), "user1", "failed", nil, nil)
+	require.NoError(t, err)
 }
 
 func TestOnSubscriptionChange_UnknownProduct(t *testing.T) {
 	svc := newTestService(t, newEmptyLoader(t), nil)
 
-	err := svc.OnSubscriptionChange(context.Background(), "user1", 999, true, nil)
+	err := svc.OnSubscriptionChange(context.Background(), "user1", 999, true, "subscription_created", nil)
 	require.NoError(t, err)
 
 	assert.Equal(t, "free", svc.GetUserPlan("user1"))
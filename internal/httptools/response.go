@@ -2,7 +2,9 @@ package httptools
 
 import (
 	"encoding/json"
+	"math"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/grantsy/grantsy/internal/infra/tracing"
@@ -14,7 +16,10 @@ const (
 	ErrTypeValidationFailed = "https://grantsy.example/errors/validation-failed"
 	ErrTypeBadRequest       = "https://grantsy.example/errors/bad-request"
 	ErrTypeNotFound         = "https://grantsy.example/errors/not-found"
+	ErrTypeConflict         = "https://grantsy.example/errors/conflict"
 	ErrTypeUnauthorized     = "https://grantsy.example/errors/unauthorized"
+	ErrTypeForbidden        = "https://grantsy.example/errors/forbidden"
+	ErrTypeRateLimited      = "https://grantsy.example/errors/rate-limited"
 	ErrTypeInternalError    = "https://grantsy.example/errors/internal-error"
 )
 
@@ -34,7 +39,7 @@ type ErrorResponse struct {
 }
 
 type ProblemDetails struct {
-	Type      string       `json:"type"             enum:"https://grantsy.example/errors/validation-failed,https://grantsy.example/errors/bad-request,https://grantsy.example/errors/not-found,https://grantsy.example/errors/unauthorized,https://grantsy.example/errors/internal-error" required:"true"`
+	Type      string       `json:"type"             enum:"https://grantsy.example/errors/validation-failed,https://grantsy.example/errors/bad-request,https://grantsy.example/errors/not-found,https://grantsy.example/errors/conflict,https://grantsy.example/errors/unauthorized,https://grantsy.example/errors/forbidden,https://grantsy.example/errors/rate-limited,https://grantsy.example/errors/internal-error" required:"true"`
 	Title     string       `json:"title"       required:"true"`
 	Detail    string       `json:"detail"      required:"true"`
 	Status    int          `json:"status"      required:"true"`
@@ -128,6 +133,26 @@ func NotFound(w http.ResponseWriter, r *http.Request, detail string) {
 	)
 }
 
+func Conflict(w http.ResponseWriter, r *http.Request, detail string) {
+	Error(w, r, http.StatusConflict,
+		ErrTypeConflict,
+		"Conflict",
+		detail,
+	)
+}
+
 func WriteStatus(w http.ResponseWriter, status int) {
 	w.WriteHeader(status)
 }
+
+// TooManyRequests writes a 429 response with a Retry-After header telling the
+// client how many seconds to wait, rounded up.
+func TooManyRequests(w http.ResponseWriter, r *http.Request, retryAfter time.Duration, detail string) {
+	seconds := max(int64(math.Ceil(retryAfter.Seconds())), 1)
+	w.Header().Set("Retry-After", strconv.FormatInt(seconds, 10))
+	Error(w, r, http.StatusTooManyRequests,
+		ErrTypeRateLimited,
+		"Too Many Requests",
+		detail,
+	)
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/grantsy/grantsy/internal/httptools"
 	"github.com/stretchr/testify/assert"
@@ -164,6 +165,40 @@ func TestBadRequest(t *testing.T) {
 	assert.Equal(t, "missing parameter", resp.Error.Detail)
 }
 
+func TestConflict(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	httptools.Conflict(w, r, "already exists")
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	var resp struct {
+		Error struct {
+			Type   string `json:"type"`
+			Detail string `json:"detail"`
+		} `json:"error"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, httptools.ErrTypeConflict, resp.Error.Type)
+	assert.Equal(t, "already exists", resp.Error.Detail)
+}
+
+func TestTooManyRequests_RetryAfterRoundsUp(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	httptools.TooManyRequests(w, r, 1500*time.Millisecond, "slow down")
+
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "2", w.Header().Get("Retry-After"))
+
+	var resp httptools.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, httptools.ErrTypeRateLimited, resp.Error.Type)
+	assert.Equal(t, "slow down", resp.Error.Detail)
+}
+
 func TestWriteStatus(t *testing.T) {
 	w := httptest.NewRecorder()
 
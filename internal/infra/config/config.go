@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"gopkg.in/yaml.v3"
@@ -18,6 +19,7 @@ type Config struct {
 	Webhooks     OutgoingWebhooks   `yaml:"webhooks"`
 	Log          LogConfig          `yaml:"log"`
 	Metrics      MetricsConfig      `yaml:"metrics"`
+	RateLimit    RateLimitConfig    `yaml:"rate_limit"`
 	SyncPeriod   string             `yaml:"sync_period"`
 }
 
@@ -32,13 +34,101 @@ type DatabaseConfig struct {
 	Namespace string `yaml:"namespace"`
 }
 
+// AuthConfig lists the API keys and bearer tokens accepted by the API. APIKey
+// is a single key with every scope, kept for configs written before named keys.
 type AuthConfig struct {
-	APIKey string `yaml:"api_key" validate:"required"`
+	APIKey          string            `yaml:"api_key"          validate:"required_without_all=APIKeys Bearer"`
+	APIKeys         []APIKey          `yaml:"api_keys"         validate:"required_without_all=APIKey Bearer,unique=Name,dive"`
+	PublishableKeys []PublishableKey  `yaml:"publishable_keys" validate:"unique=Name,dive"`
+	UserTokens      *UserTokensConfig `yaml:"user_tokens"      validate:"required_with=PublishableKeys,omitempty"`
+	Bearer          *BearerConfig     `yaml:"bearer"           validate:"omitempty"`
+}
+
+// APIKey is a named API key and the scopes it grants.
+type APIKey struct {
+	Name   string   `yaml:"name"   validate:"required"`
+	Key    string   `yaml:"key"    validate:"required"`
+	Scopes []string `yaml:"scopes" validate:"required,min=1,dive,oneof=check:read plans:read users:read admin:write audit:read"`
+}
+
+// PublishableKey is a key that can be shipped in browsers and mobile apps.
+// Requests made with it must carry a user token and can only read that user.
+type PublishableKey struct {
+	Name string `yaml:"name" validate:"required"`
+	Key  string `yaml:"key"  validate:"required"`
+}
+
+// UserTokensConfig configures how the user tokens sent with publishable keys
+// are verified: HS256 tokens signed with HMACSecret, or tokens signed by a key
+// from the JWKS at JWKSURL, which must also carry Issuer and Audience.
+type UserTokensConfig struct {
+	HMACSecret string `yaml:"hmac_secret" validate:"required_without=JWKSURL,omitempty,min=32"`
+	JWKSURL    string `yaml:"jwks_url"    validate:"required_without=HMACSecret,omitempty,url"`
+	Issuer     string `yaml:"issuer"      validate:"required_with=JWKSURL"`
+	Audience   string `yaml:"audience"    validate:"required_with=JWKSURL"`
+	MaxTTL     string `yaml:"max_ttl"`
+}
+
+// BearerConfig configures authentication with JWTs in the Authorization
+// header, issued by Issuer and signed by a key from the JWKS at JWKSURL or in
+// JWKSFile. Scopes are granted from the token's ScopesClaim and by
+// ScopeMappings.
+type BearerConfig struct {
+	Issuer        string         `yaml:"issuer"         validate:"required"`
+	Audience      string         `yaml:"audience"       validate:"required"`
+	JWKSURL       string         `yaml:"jwks_url"       validate:"required_without=JWKSFile,excluded_with=JWKSFile,omitempty,url"`
+	JWKSFile      string         `yaml:"jwks_file"      validate:"required_without=JWKSURL"`
+	NameClaim     string         `yaml:"name_claim"`
+	ScopesClaim   string         `yaml:"scopes_claim"`
+	ScopeMappings []ScopeMapping `yaml:"scope_mappings" validate:"dive"`
+}
+
+// ScopeMapping grants Scopes to tokens whose Claim is, or contains, Value.
+type ScopeMapping struct {
+	Claim  string   `yaml:"claim"  validate:"required"`
+	Value  string   `yaml:"value"  validate:"required"`
+	Scopes []string `yaml:"scopes" validate:"required,min=1,dive,oneof=check:read plans:read users:read admin:write audit:read"`
+}
+
+// RateLimitConfig limits requests per client IP and per API key with token
+// buckets, kept in memory or, with Shared, in the database so every replica
+// enforces the same limits.
+type RateLimitConfig struct {
+	Enable      bool           `yaml:"enable"`
+	Shared      bool           `yaml:"shared"`
+	IPHeader    string         `yaml:"ip_header"`
+	TrustedHops int            `yaml:"trusted_hops" validate:"omitempty,min=1"`
+	PerIP       *RateLimit     `yaml:"per_ip"       validate:"omitempty"`
+	PerKey      *RateLimit     `yaml:"per_key"      validate:"omitempty"`
+	Keys        []KeyRateLimit `yaml:"keys"         validate:"unique=Name,dive"`
+}
+
+// RateLimit allows Requests per Period on average, and bursts of up to
+// Burst requests (Requests if unset).
+type RateLimit struct {
+	Requests int    `yaml:"requests" validate:"required,min=1"`
+	Period   string `yaml:"period"   validate:"required"`
+	Burst    int    `yaml:"burst"    validate:"omitempty,min=1"`
+}
+
+// KeyRateLimit overrides the per-key limit for the key called Name.
+type KeyRateLimit struct {
+	Name      string `yaml:"name" validate:"required"`
+	RateLimit `yaml:",inline"`
 }
 
 type LogConfig struct {
-	Level  string `yaml:"level"  validate:"omitempty,oneof=debug info warn error"`
-	Format string `yaml:"format" validate:"omitempty,oneof=json text"`
+	Level  string       `yaml:"level"  validate:"omitempty,oneof=debug info warn error"`
+	Format string       `yaml:"format" validate:"omitempty,oneof=json text"`
+	Redact RedactConfig `yaml:"redact"`
+}
+
+// RedactConfig names headers, query parameters and JSON fields whose values
+// are replaced in request logs, in addition to the built-in defaults.
+type RedactConfig struct {
+	Headers     []string `yaml:"headers"`
+	QueryParams []string `yaml:"query_params"`
+	JSONFields  []string `yaml:"json_fields"`
 }
 
 type MetricsConfig struct {
@@ -73,9 +163,19 @@ type ProvidersConfig struct {
 
 // LemonSqueezyConfig contains LemonSqueezy-specific settings
 type LemonSqueezyConfig struct {
-	APIKey   string                      `yaml:"api_key"  validate:"required"`
-	Products []ProductMapping            `yaml:"products" validate:"dive"`
-	Webhook  LemonSqueezyIncomingWebhook `yaml:"webhook"`
+	APIKey       string                      `yaml:"api_key"       validate:"required"`
+	StoreID      int                         `yaml:"store_id"      validate:"omitempty,min=1"`
+	TaxInclusive bool                        `yaml:"tax_inclusive"`
+	Products     []ProductMapping            `yaml:"products"      validate:"dive"`
+	PricePoints  []PricePoint                `yaml:"price_points"  validate:"dive"`
+	Webhook      LemonSqueezyIncomingWebhook `yaml:"webhook"`
+}
+
+// PricePoint is a variant's price in a currency other than the store currency.
+type PricePoint struct {
+	VariantID int    `yaml:"variant_id" validate:"required"`
+	Currency  string `yaml:"currency"   validate:"required,iso4217"`
+	Price     int    `yaml:"price"      validate:"min=0"`
 }
 
 type ProductMapping struct {
@@ -95,8 +195,30 @@ type OutgoingWebhooks struct {
 
 // WebhookEndpoint defines a single outgoing webhook destination
 type WebhookEndpoint struct {
-	URL    string `yaml:"url"    validate:"required,url"`
-	Secret string `yaml:"secret" validate:"required"`
+	URL    string       `yaml:"url"    validate:"required,url"`
+	Secret string       `yaml:"secret" validate:"required"`
+	Events []string     `yaml:"events" validate:"dive,oneof=user.plan_changed subscription.updated subscription.payment_succeeded subscription.payment_failed subscription.payment_recovered grant.expired"`
+	Plans  []string     `yaml:"plans"`
+	Retry  WebhookRetry `yaml:"retry"`
+}
+
+// WebhookRetry configures how failed deliveries to an endpoint are retried.
+// The delay doubles after every attempt, from InitialInterval up to
+// MaxInterval, with random jitter. A message is moved to the dead-letter
+// queue after MaxAttempts attempts or once it is older than MaxAge.
+type WebhookRetry struct {
+	MaxAttempts     int           `yaml:"max_attempts"     validate:"min=1"`
+	InitialInterval time.Duration `yaml:"initial_interval" validate:"min=1s"`
+	MaxInterval     time.Duration `yaml:"max_interval"     validate:"gtefield=InitialInterval"`
+	MaxAge          time.Duration `yaml:"max_age"          validate:"min=1m"`
+}
+
+// DefaultWebhookRetry is the retry policy used for settings an endpoint leaves unset.
+var DefaultWebhookRetry = WebhookRetry{
+	MaxAttempts:     8,
+	InitialInterval: 30 * time.Second,
+	MaxInterval:     time.Hour,
+	MaxAge:          24 * time.Hour,
 }
 
 func Load(path string) (*Config, error) {
@@ -142,4 +264,19 @@ func applyDefaults(cfg *Config) {
 	if cfg.Metrics.Path == "" {
 		cfg.Metrics.Path = "/metrics"
 	}
+	for i := range cfg.Webhooks.Endpoints {
+		retry := &cfg.Webhooks.Endpoints[i].Retry
+		if retry.MaxAttempts == 0 {
+			retry.MaxAttempts = DefaultWebhookRetry.MaxAttempts
+		}
+		if retry.InitialInterval == 0 {
+			retry.InitialInterval = DefaultWebhookRetry.InitialInterval
+		}
+		if retry.MaxInterval == 0 {
+			retry.MaxInterval = DefaultWebhookRetry.MaxInterval
+		}
+		if retry.MaxAge == 0 {
+			retry.MaxAge = DefaultWebhookRetry.MaxAge
+		}
+	}
 }
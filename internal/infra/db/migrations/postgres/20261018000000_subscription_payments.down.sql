@@ -0,0 +1,4 @@
+-- LemonSqueezy subscription payment history
+
+DROP INDEX IF EXISTS idx_subscription_payments_lemonsqueezy_subscription;
+DROP TABLE IF EXISTS subscription_payments_lemonsqueezy;
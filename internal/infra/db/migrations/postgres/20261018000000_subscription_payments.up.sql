@@ -0,0 +1,23 @@
+-- LemonSqueezy subscription payment history
+CREATE TABLE IF NOT EXISTS subscription_payments_lemonsqueezy (
+    invoice_id      INTEGER NOT NULL,
+    event_name      TEXT NOT NULL,
+    subscription_id INTEGER NOT NULL,
+    user_id         TEXT NOT NULL DEFAULT '',
+    billing_reason  TEXT NOT NULL DEFAULT '',
+    status          TEXT NOT NULL DEFAULT '',
+    currency        TEXT NOT NULL DEFAULT '',
+    subtotal        INTEGER NOT NULL DEFAULT 0,
+    discount_total  INTEGER NOT NULL DEFAULT 0,
+    tax             INTEGER NOT NULL DEFAULT 0,
+    total           INTEGER NOT NULL DEFAULT 0,
+    total_usd       INTEGER NOT NULL DEFAULT 0,
+    refunded        BOOLEAN NOT NULL DEFAULT FALSE,
+    invoice_url     TEXT NOT NULL DEFAULT '',
+    created_at      INTEGER NOT NULL DEFAULT 0,
+    updated_at      INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (invoice_id, event_name)
+);
+
+CREATE INDEX IF NOT EXISTS idx_subscription_payments_lemonsqueezy_subscription
+ON subscription_payments_lemonsqueezy(subscription_id);
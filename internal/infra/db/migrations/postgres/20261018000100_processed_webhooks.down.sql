@@ -0,0 +1,3 @@
+-- Processed incoming webhooks, keyed by payload hash for deduplication
+
+DROP TABLE IF EXISTS processed_webhooks;
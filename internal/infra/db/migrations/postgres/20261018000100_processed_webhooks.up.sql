@@ -0,0 +1,6 @@
+-- Processed incoming webhooks, keyed by payload hash for deduplication
+CREATE TABLE IF NOT EXISTS processed_webhooks (
+    payload_hash TEXT PRIMARY KEY,
+    event_name   TEXT NOT NULL DEFAULT '',
+    processed_at INTEGER NOT NULL DEFAULT 0
+);
@@ -0,0 +1,5 @@
+-- Raw incoming provider webhooks with their processing outcome
+
+DROP INDEX IF EXISTS idx_incoming_webhook_events_result;
+DROP INDEX IF EXISTS idx_incoming_webhook_events_event_name;
+DROP TABLE IF EXISTS incoming_webhook_events;
@@ -0,0 +1,5 @@
+-- Outgoing webhook delivery attempts
+
+DROP INDEX IF EXISTS idx_webhook_deliveries_event_type;
+DROP INDEX IF EXISTS idx_webhook_deliveries_endpoint;
+DROP TABLE IF EXISTS webhook_deliveries;
@@ -0,0 +1,14 @@
+-- Outgoing webhooks that exhausted their retries
+CREATE TABLE IF NOT EXISTS webhook_dead_letters (
+    id              TEXT PRIMARY KEY,
+    endpoint        TEXT NOT NULL,
+    event_type      TEXT NOT NULL DEFAULT '',
+    user_id         TEXT NOT NULL DEFAULT '',
+    payload         TEXT NOT NULL DEFAULT '',
+    attempts        INTEGER NOT NULL DEFAULT 0,
+    last_error      TEXT NOT NULL DEFAULT '',
+    first_queued_at INTEGER NOT NULL DEFAULT 0,
+    created_at      INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_dead_letters_endpoint ON webhook_dead_letters(endpoint);
@@ -0,0 +1,3 @@
+-- Last outgoing webhook sequence number per user
+
+DROP TABLE IF EXISTS webhook_sequences;
@@ -0,0 +1,5 @@
+-- Last outgoing webhook sequence number per user
+CREATE TABLE IF NOT EXISTS webhook_sequences (
+    user_id       TEXT PRIMARY KEY,
+    last_sequence INTEGER NOT NULL DEFAULT 0
+);
@@ -0,0 +1,3 @@
+-- Outgoing webhook endpoints managed through the API
+
+DROP TABLE IF EXISTS webhook_endpoints;
@@ -0,0 +1,12 @@
+-- Outgoing webhook endpoints managed through the API
+CREATE TABLE IF NOT EXISTS webhook_endpoints (
+    id                     TEXT PRIMARY KEY,
+    url                    TEXT NOT NULL UNIQUE,
+    secret                 TEXT NOT NULL,
+    prev_secret            TEXT NOT NULL DEFAULT '',
+    prev_secret_expires_at INTEGER NOT NULL DEFAULT 0,
+    events                 TEXT NOT NULL DEFAULT '[]',
+    plans                  TEXT NOT NULL DEFAULT '[]',
+    created_at             INTEGER NOT NULL DEFAULT 0,
+    updated_at             INTEGER NOT NULL DEFAULT 0
+);
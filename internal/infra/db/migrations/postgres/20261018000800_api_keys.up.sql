@@ -0,0 +1,16 @@
+-- API keys managed through the API, stored as salted hashes
+CREATE TABLE IF NOT EXISTS api_keys (
+    id                   TEXT PRIMARY KEY,
+    name                 TEXT NOT NULL,
+    scopes               TEXT NOT NULL DEFAULT '[]',
+    salt                 TEXT NOT NULL,
+    hash                 TEXT NOT NULL,
+    prev_salt            TEXT NOT NULL DEFAULT '',
+    prev_hash            TEXT NOT NULL DEFAULT '',
+    prev_hash_expires_at INTEGER NOT NULL DEFAULT 0,
+    expires_at           INTEGER,
+    last_used_at         INTEGER,
+    revoked_at           INTEGER,
+    created_at           INTEGER NOT NULL DEFAULT 0,
+    updated_at           INTEGER NOT NULL DEFAULT 0
+);
@@ -0,0 +1,3 @@
+-- Token buckets shared by every replica when rate_limit.shared is enabled
+
+DROP TABLE IF EXISTS rate_limit_buckets;
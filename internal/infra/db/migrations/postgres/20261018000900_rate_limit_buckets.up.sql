@@ -0,0 +1,6 @@
+-- Token buckets shared by every replica when rate_limit.shared is enabled
+CREATE TABLE IF NOT EXISTS rate_limit_buckets (
+    bucket     TEXT PRIMARY KEY,
+    tokens     DOUBLE PRECISION NOT NULL,
+    updated_at BIGINT NOT NULL
+);
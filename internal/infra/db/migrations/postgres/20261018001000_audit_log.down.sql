@@ -0,0 +1,4 @@
+-- Append-only record of plan changes and admin actions
+
+DROP TABLE IF EXISTS audit_log;
+DROP FUNCTION IF EXISTS audit_log_append_only();
@@ -0,0 +1,13 @@
+-- Plan transitions of each user, with the subscription state that caused them
+CREATE TABLE IF NOT EXISTS plan_history (
+    id           TEXT PRIMARY KEY,
+    user_id      TEXT NOT NULL,
+    prev_plan    TEXT NOT NULL DEFAULT '',
+    new_plan     TEXT NOT NULL DEFAULT '',
+    active       BOOLEAN NOT NULL DEFAULT FALSE,
+    cause        TEXT NOT NULL DEFAULT '',
+    subscription TEXT NOT NULL DEFAULT 'null',
+    created_at   BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_plan_history_user_id ON plan_history(user_id, id);
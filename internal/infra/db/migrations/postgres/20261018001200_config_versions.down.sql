@@ -0,0 +1,3 @@
+-- Plans and their features as loaded from the config file over time
+
+DROP TABLE IF EXISTS config_versions;
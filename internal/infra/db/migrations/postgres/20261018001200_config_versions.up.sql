@@ -0,0 +1,10 @@
+-- Plans and their features as loaded from the config file over time
+CREATE TABLE IF NOT EXISTS config_versions (
+    id           TEXT PRIMARY KEY,
+    hash         TEXT NOT NULL,
+    default_plan TEXT NOT NULL DEFAULT '',
+    plans        TEXT NOT NULL DEFAULT '{}',
+    created_at   BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_config_versions_created_at ON config_versions(created_at);
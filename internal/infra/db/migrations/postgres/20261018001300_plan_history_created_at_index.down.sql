@@ -0,0 +1,4 @@
+-- Index plan history in the order it is listed, by change time
+
+DROP INDEX IF EXISTS idx_plan_history_user_created_at;
+CREATE INDEX IF NOT EXISTS idx_plan_history_user_id ON plan_history(user_id, id);
@@ -0,0 +1,4 @@
+-- LemonSqueezy subscription payment history
+
+DROP INDEX IF EXISTS idx_{ns}subscription_payments_lemonsqueezy_subscription;
+DROP TABLE IF EXISTS {ns}subscription_payments_lemonsqueezy;
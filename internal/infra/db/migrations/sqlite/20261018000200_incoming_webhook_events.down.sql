@@ -0,0 +1,5 @@
+-- Raw incoming provider webhooks with their processing outcome
+
+DROP INDEX IF EXISTS idx_{ns}incoming_webhook_events_result;
+DROP INDEX IF EXISTS idx_{ns}incoming_webhook_events_event_name;
+DROP TABLE IF EXISTS {ns}incoming_webhook_events;
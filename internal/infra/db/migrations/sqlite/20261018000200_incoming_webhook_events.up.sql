@@ -0,0 +1,18 @@
+-- Raw incoming provider webhooks with their processing outcome
+CREATE TABLE IF NOT EXISTS {ns}incoming_webhook_events (
+    id           TEXT PRIMARY KEY,
+    provider     TEXT NOT NULL,
+    event_name   TEXT NOT NULL DEFAULT '',
+    payload_hash TEXT NOT NULL DEFAULT '',
+    headers      TEXT NOT NULL DEFAULT '{}',
+    body         TEXT NOT NULL DEFAULT '',
+    result       TEXT NOT NULL DEFAULT 'pending',
+    status_code  INTEGER NOT NULL DEFAULT 0,
+    error        TEXT NOT NULL DEFAULT '',
+    attempts     INTEGER NOT NULL DEFAULT 0,
+    received_at  INTEGER NOT NULL DEFAULT 0,
+    processed_at INTEGER
+);
+
+CREATE INDEX IF NOT EXISTS idx_{ns}incoming_webhook_events_event_name ON {ns}incoming_webhook_events(event_name);
+CREATE INDEX IF NOT EXISTS idx_{ns}incoming_webhook_events_result ON {ns}incoming_webhook_events(result);
@@ -0,0 +1,3 @@
+-- LemonSqueezy prices, cached for subscription enrichment
+
+DROP TABLE IF EXISTS {ns}prices_lemonsqueezy;
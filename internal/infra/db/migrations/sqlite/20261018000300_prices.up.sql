@@ -0,0 +1,16 @@
+-- LemonSqueezy prices, cached for subscription enrichment
+CREATE TABLE IF NOT EXISTS {ns}prices_lemonsqueezy (
+    price_id                  INTEGER PRIMARY KEY,
+    variant_id                INTEGER NOT NULL DEFAULT 0,
+    unit_price                INTEGER NOT NULL DEFAULT 0,
+    renewal_interval_unit     TEXT NOT NULL DEFAULT '',
+    renewal_interval_quantity INTEGER NOT NULL DEFAULT 0,
+    updated_at                INTEGER NOT NULL DEFAULT 0
+);
+
+-- Seed from prices already seen in subscription webhooks
+INSERT INTO {ns}prices_lemonsqueezy (price_id, variant_id, unit_price, renewal_interval_unit, renewal_interval_quantity)
+SELECT price_id, variant_id, unit_price, renewal_interval_unit, renewal_interval_quantity
+FROM {ns}subscriptions_lemonsqueezy
+WHERE price_id <> 0
+ON CONFLICT (price_id) DO NOTHING;
@@ -0,0 +1,17 @@
+-- Outgoing webhook delivery attempts
+CREATE TABLE IF NOT EXISTS {ns}webhook_deliveries (
+    id          TEXT PRIMARY KEY,
+    endpoint    TEXT NOT NULL,
+    event_type  TEXT NOT NULL DEFAULT '',
+    user_id     TEXT NOT NULL DEFAULT '',
+    payload     TEXT NOT NULL DEFAULT '',
+    success     BOOLEAN NOT NULL DEFAULT FALSE,
+    status_code INTEGER NOT NULL DEFAULT 0,
+    duration_ms INTEGER NOT NULL DEFAULT 0,
+    response    TEXT NOT NULL DEFAULT '',
+    error       TEXT NOT NULL DEFAULT '',
+    created_at  INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_{ns}webhook_deliveries_endpoint ON {ns}webhook_deliveries(endpoint);
+CREATE INDEX IF NOT EXISTS idx_{ns}webhook_deliveries_event_type ON {ns}webhook_deliveries(event_type);
@@ -0,0 +1,4 @@
+-- Outgoing webhooks that exhausted their retries
+
+DROP INDEX IF EXISTS idx_{ns}webhook_dead_letters_endpoint;
+DROP TABLE IF EXISTS {ns}webhook_dead_letters;
@@ -0,0 +1,3 @@
+-- API keys managed through the API, stored as salted hashes
+
+DROP TABLE IF EXISTS {ns}api_keys;
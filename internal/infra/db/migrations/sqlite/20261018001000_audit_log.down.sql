@@ -0,0 +1,3 @@
+-- Append-only record of plan changes and admin actions
+
+DROP TABLE IF EXISTS {ns}audit_log;
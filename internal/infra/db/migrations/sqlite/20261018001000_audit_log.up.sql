@@ -0,0 +1,30 @@
+-- Append-only record of plan changes and admin actions
+CREATE TABLE IF NOT EXISTS {ns}audit_log (
+    id          TEXT PRIMARY KEY,
+    action      TEXT NOT NULL,
+    actor_type  TEXT NOT NULL,
+    actor       TEXT NOT NULL DEFAULT '',
+    actor_id    TEXT NOT NULL DEFAULT '',
+    user_id     TEXT NOT NULL DEFAULT '',
+    plan_before TEXT NOT NULL DEFAULT '',
+    plan_after  TEXT NOT NULL DEFAULT '',
+    request_id  TEXT NOT NULL DEFAULT '',
+    details     TEXT NOT NULL DEFAULT '{}',
+    created_at  INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_{ns}audit_log_user_id ON {ns}audit_log(user_id);
+CREATE INDEX IF NOT EXISTS idx_{ns}audit_log_actor ON {ns}audit_log(actor);
+CREATE INDEX IF NOT EXISTS idx_{ns}audit_log_action ON {ns}audit_log(action);
+
+CREATE TRIGGER IF NOT EXISTS {ns}audit_log_no_update
+    BEFORE UPDATE ON {ns}audit_log
+BEGIN
+    SELECT RAISE(ABORT, 'audit_log is append-only');
+END;
+
+CREATE TRIGGER IF NOT EXISTS {ns}audit_log_no_delete
+    BEFORE DELETE ON {ns}audit_log
+BEGIN
+    SELECT RAISE(ABORT, 'audit_log is append-only');
+END;
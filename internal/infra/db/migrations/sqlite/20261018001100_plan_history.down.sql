@@ -0,0 +1,3 @@
+-- Plan transitions of each user, with the subscription state that caused them
+
+DROP TABLE IF EXISTS {ns}plan_history;
@@ -0,0 +1,10 @@
+-- Plans and their features as loaded from the config file over time
+CREATE TABLE IF NOT EXISTS {ns}config_versions (
+    id           TEXT PRIMARY KEY,
+    hash         TEXT NOT NULL,
+    default_plan TEXT NOT NULL DEFAULT '',
+    plans        TEXT NOT NULL DEFAULT '{}',
+    created_at   INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_{ns}config_versions_created_at ON {ns}config_versions(created_at);
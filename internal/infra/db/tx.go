@@ -0,0 +1,103 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+type txKey struct{}
+
+type txState struct {
+	tx            *sql.Tx
+	afterCommit   []func()
+	afterRollback []func()
+}
+
+// InTx runs fn in a transaction. Queries made through the DB with the context
+// passed to fn join the transaction; an error from fn rolls it back. Calls
+// nested in fn join the outer transaction.
+func (d *DB) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if Tx(ctx) != nil {
+		return fn(ctx)
+	}
+
+	tx, err := d.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("db: failed to begin transaction: %w", err)
+	}
+	// Rollback is a no-op once the transaction is committed.
+	defer tx.Rollback()
+
+	state := &txState{tx: tx}
+	if err := fn(context.WithValue(ctx, txKey{}, state)); err != nil {
+		state.rolledBack()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		state.rolledBack()
+		return fmt.Errorf("db: failed to commit transaction: %w", err)
+	}
+
+	for _, f := range state.afterCommit {
+		f()
+	}
+	return nil
+}
+
+// rolledBack runs the AfterRollback functions, the latest first.
+func (s *txState) rolledBack() {
+	for i := len(s.afterRollback) - 1; i >= 0; i-- {
+		s.afterRollback[i]()
+	}
+}
+
+// Tx returns the transaction started by InTx for ctx, or nil outside of one.
+func Tx(ctx context.Context) *sql.Tx {
+	if state, ok := ctx.Value(txKey{}).(*txState); ok {
+		return state.tx
+	}
+	return nil
+}
+
+// AfterCommit runs fn once the transaction in ctx is committed, or right away
+// outside of a transaction. fn is dropped if the transaction rolls back.
+func AfterCommit(ctx context.Context, fn func()) {
+	if state, ok := ctx.Value(txKey{}).(*txState); ok {
+		state.afterCommit = append(state.afterCommit, fn)
+		return
+	}
+	fn()
+}
+
+// AfterRollback runs fn if the transaction in ctx rolls back, to undo changes
+// made outside the database within it. Outside of a transaction fn is dropped.
+func AfterRollback(ctx context.Context, fn func()) {
+	if state, ok := ctx.Value(txKey{}).(*txState); ok {
+		state.afterRollback = append(state.afterRollback, fn)
+	}
+}
+
+// ExecContext executes a query in the context's transaction, if any.
+func (d *DB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	if tx := Tx(ctx); tx != nil {
+		return tx.ExecContext(ctx, query, args...)
+	}
+	return d.DB.ExecContext(ctx, query, args...)
+}
+
+// QueryContext runs a query in the context's transaction, if any.
+func (d *DB) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	if tx := Tx(ctx); tx != nil {
+		return tx.QueryContext(ctx, query, args...)
+	}
+	return d.DB.QueryContext(ctx, query, args...)
+}
+
+// QueryRowContext runs a single-row query in the context's transaction, if any.
+func (d *DB) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	if tx := Tx(ctx); tx != nil {
+		return tx.QueryRowContext(ctx, query, args...)
+	}
+	return d.DB.QueryRowContext(ctx, query, args...)
+}
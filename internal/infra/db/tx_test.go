@@ -0,0 +1,90 @@
+package db_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grantsy/grantsy/internal/infra/db"
+)
+
+func newTestDB(t *testing.T) *db.DB {
+	t.Helper()
+	database, err := db.New("sqlite", filepath.Join(t.TempDir(), "test.db"), "")
+	require.NoError(t, err)
+	t.Cleanup(func() { database.Close() })
+
+	_, err = database.ExecContext(context.Background(), `CREATE TABLE items (name TEXT)`)
+	require.NoError(t, err)
+	return database
+}
+
+func countItems(t *testing.T, database *db.DB) int {
+	t.Helper()
+	var n int
+	require.NoError(t, database.QueryRowContext(context.Background(), `SELECT COUNT(*) FROM items`).Scan(&n))
+	return n
+}
+
+func TestInTx_Commit(t *testing.T) {
+	database := newTestDB(t)
+	committed := false
+
+	err := database.InTx(context.Background(), func(ctx context.Context) error {
+		db.AfterCommit(ctx, func() { committed = true })
+		db.AfterRollback(ctx, func() { t.Error("rollback hook ran after commit") })
+		_, err := database.ExecContext(ctx, `INSERT INTO items (name) VALUES ('a')`)
+		assert.False(t, committed)
+		return err
+	})
+
+	require.NoError(t, err)
+	assert.True(t, committed)
+	assert.Equal(t, 1, countItems(t, database))
+}
+
+func TestInTx_Rollback(t *testing.T) {
+	database := newTestDB(t)
+	committed := false
+	var undone []string
+	boom := errors.New("boom")
+
+	err := database.InTx(context.Background(), func(ctx context.Context) error {
+		db.AfterCommit(ctx, func() { committed = true })
+		db.AfterRollback(ctx, func() { undone = append(undone, "first") })
+		db.AfterRollback(ctx, func() { undone = append(undone, "second") })
+		if _, err := database.ExecContext(ctx, `INSERT INTO items (name) VALUES ('a')`); err != nil {
+			return err
+		}
+		return boom
+	})
+
+	assert.ErrorIs(t, err, boom)
+	assert.False(t, committed)
+	assert.Equal(t, []string{"second", "first"}, undone, "undone latest first")
+	assert.Equal(t, 0, countItems(t, database))
+}
+
+func TestInTx_NestedJoinsOuter(t *testing.T) {
+	database := newTestDB(t)
+
+	err := database.InTx(context.Background(), func(ctx context.Context) error {
+		outer := db.Tx(ctx)
+		return database.InTx(ctx, func(ctx context.Context) error {
+			assert.Same(t, outer, db.Tx(ctx))
+			return nil
+		})
+	})
+
+	require.NoError(t, err)
+}
+
+func TestAfterCommit_OutsideTx(t *testing.T) {
+	ran := false
+	db.AfterCommit(context.Background(), func() { ran = true })
+	assert.True(t, ran)
+}
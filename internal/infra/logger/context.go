@@ -7,15 +7,30 @@ import (
 
 type loggerContextKey struct{}
 
+// loggerRef lets With replace the request logger for the whole request,
+// including the request log written by Middleware.
+type loggerRef struct {
+	logger *slog.Logger
+}
+
 // FromContext gets logger from context, returns slog.Default() if not found
 func FromContext(ctx context.Context) *slog.Logger {
-	if logger, ok := ctx.Value(loggerContextKey{}).(*slog.Logger); ok {
-		return logger
+	if ref, ok := ctx.Value(loggerContextKey{}).(*loggerRef); ok {
+		return ref.logger
 	}
 	return slog.Default()
 }
 
 // WithLogger adds logger to context
 func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
-	return context.WithValue(ctx, loggerContextKey{}, logger)
+	return context.WithValue(ctx, loggerContextKey{}, &loggerRef{logger: logger})
+}
+
+// With adds attributes to the logger in context. Unlike WithLogger, the
+// attributes are also seen by callers up the chain holding the same context,
+// e.g. the request log. Does nothing if the context has no logger.
+func With(ctx context.Context, args ...any) {
+	if ref, ok := ctx.Value(loggerContextKey{}).(*loggerRef); ok {
+		ref.logger = ref.logger.With(args...)
+	}
 }
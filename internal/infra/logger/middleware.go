@@ -5,6 +5,7 @@ import (
 	"log/slog"
 	"net/http"
 	"runtime/debug"
+	"strings"
 	"time"
 
 	"github.com/go-http-utils/headers"
@@ -12,47 +13,51 @@ import (
 	"github.com/zenazn/goji/web/mutil"
 )
 
-// Middleware creates HTTP logging middleware
-func Middleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		start := time.Now()
-
-		// Create request-scoped logger with request data
-		log := slog.Default().With(
-			slog.Group("request",
-				slog.String("method", r.Method),
-				slog.String("url", r.URL.String()),
-				slog.String("ip", r.RemoteAddr),
-				slog.Any("headers", r.Header),
-				slog.String("request_id", tracing.GetRequestID(r.Context())),
-			),
-		)
-
-		// Store logger in context
-		ctx := WithLogger(r.Context(), log)
-		r = r.WithContext(ctx)
-
-		// Wrap response writer to capture status and size
-		lw := mutil.WrapWriter(w)
-		buf := bytes.NewBuffer(nil)
-		lw.Tee(buf)
-
-		// Serve request
-		next.ServeHTTP(lw, r)
-
-		// Log response
-		duration := time.Since(start)
-		status := lw.Status()
-		size := lw.BytesWritten()
-		body := buf.String()
-
-		responseDataHandlerCallback(w, r, status, size, duration, body)
-	})
+// Middleware creates HTTP logging middleware. Headers, query parameters and
+// response bodies are logged with sensitive values replaced by redactor.
+func Middleware(redactor *Redactor) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+
+			// Create request-scoped logger with request data
+			log := slog.Default().With(
+				slog.Group("request",
+					slog.String("method", r.Method),
+					slog.String("url", redactor.URL(r.URL)),
+					slog.String("ip", r.RemoteAddr),
+					slog.Any("headers", redactor.Header(r.Header)),
+					slog.String("request_id", tracing.GetRequestID(r.Context())),
+				),
+			)
+
+			// Store logger in context
+			ctx := WithLogger(r.Context(), log)
+			r = r.WithContext(ctx)
+
+			// Wrap response writer to capture status and size
+			lw := mutil.WrapWriter(w)
+			buf := bytes.NewBuffer(nil)
+			lw.Tee(buf)
+
+			// Serve request
+			next.ServeHTTP(lw, r)
+
+			// Log response
+			duration := time.Since(start)
+			status := lw.Status()
+			size := lw.BytesWritten()
+			body := buf.String()
+
+			responseDataHandlerCallback(w, r, redactor, status, size, duration, body)
+		})
+	}
 }
 
 func responseDataHandlerCallback(
 	w http.ResponseWriter,
 	r *http.Request,
+	redactor *Redactor,
 	status, size int,
 	duration time.Duration,
 	body string,
@@ -78,14 +83,15 @@ func responseDataHandlerCallback(
 		slog.Int("status", status),
 		slog.Int("size", size),
 		slog.Duration("duration", duration),
-		slog.Any("headers", w.Header()),
+		slog.Any("headers", redactor.Header(w.Header())),
 	}
 
 	// Include body at debug level
 	if log.Enabled(r.Context(), slog.LevelDebug) {
-		switch w.Header().Get(headers.ContentType) {
+		contentType, _, _ := strings.Cut(w.Header().Get(headers.ContentType), ";")
+		switch contentType {
 		case "application/json":
-			responseAttrs = append(responseAttrs, slog.String("body", body))
+			responseAttrs = append(responseAttrs, slog.String("body", redactor.JSON(body)))
 		case "application/zip":
 			responseAttrs = append(responseAttrs, slog.String("body", "<zip file>"))
 		default:
@@ -0,0 +1,168 @@
+package logger
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// redacted replaces sensitive values in logs.
+const redacted = "REDACTED"
+
+// Values always redacted from request logs, whatever the config adds.
+var (
+	defaultRedactHeaders = []string{
+		"Authorization",
+		"Cookie",
+		"Proxy-Authorization",
+		"Set-Cookie",
+		"Webhook-Signature",
+		"X-Api-Key",
+		"X-Signature",
+		"X-User-Token",
+	}
+	defaultRedactQueryParams = []string{"access_token", "api_key", "signature", "token"}
+	defaultRedactJSONFields  = []string{
+		"api_key",
+		"card_brand",
+		"card_last_four",
+		"customer_email",
+		"email",
+		"key",
+		"password",
+		"secret",
+		"token",
+		"user_email",
+		"user_name",
+	}
+)
+
+// Redactor removes secrets and personal data from the headers, query
+// parameters and JSON bodies written to request logs. Names are matched
+// case-insensitively.
+type Redactor struct {
+	headers     map[string]bool
+	queryParams map[string]bool
+	jsonFields  map[string]bool
+}
+
+// NewRedactor creates a redactor for the default names plus the given ones.
+func NewRedactor(headers, queryParams, jsonFields []string) *Redactor {
+	return &Redactor{
+		headers:     nameSet(defaultRedactHeaders, headers),
+		queryParams: nameSet(defaultRedactQueryParams, queryParams),
+		jsonFields:  nameSet(defaultRedactJSONFields, jsonFields),
+	}
+}
+
+func nameSet(lists ...[]string) map[string]bool {
+	set := make(map[string]bool)
+	for _, list := range lists {
+		for _, name := range list {
+			set[strings.ToLower(name)] = true
+		}
+	}
+	return set
+}
+
+// Header returns a copy of h with sensitive values replaced.
+func (rd *Redactor) Header(h http.Header) http.Header {
+	out := h.Clone()
+	for name, values := range out {
+		if rd.headers[strings.ToLower(name)] {
+			out[name] = redactAll(values)
+		}
+	}
+	return out
+}
+
+// URL returns u as a string with sensitive query parameter values replaced.
+func (rd *Redactor) URL(u *url.URL) string {
+	if u.RawQuery == "" {
+		return u.String()
+	}
+	query := u.Query()
+	changed := false
+	for name, values := range query {
+		if rd.queryParams[strings.ToLower(name)] {
+			query[name] = redactAll(values)
+			changed = true
+		}
+	}
+	if !changed {
+		return u.String()
+	}
+	clean := *u
+	clean.RawQuery = query.Encode()
+	return clean.String()
+}
+
+// JSON returns body with the values of sensitive fields replaced, at any
+// depth. Header names are redacted as fields too, for logged header maps, and
+// strings holding JSON, such as stored provider payloads, are redacted in
+// place. A body that isn't valid JSON is dropped rather than logged as is.
+func (rd *Redactor) JSON(body string) string {
+	if body == "" {
+		return body
+	}
+	var v any
+	if err := json.Unmarshal([]byte(body), &v); err != nil {
+		return "<invalid JSON>"
+	}
+	out, err := json.Marshal(rd.redactValue(v))
+	if err != nil {
+		return "<invalid JSON>"
+	}
+	return string(out)
+}
+
+func (rd *Redactor) redactValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, item := range v {
+			name := strings.ToLower(k)
+			if rd.jsonFields[name] || rd.headers[name] {
+				v[k] = redacted
+			} else {
+				v[k] = rd.redactValue(item)
+			}
+		}
+		return v
+	case []any:
+		for i, item := range v {
+			v[i] = rd.redactValue(item)
+		}
+		return v
+	case string:
+		return rd.redactEmbeddedJSON(v)
+	default:
+		return v
+	}
+}
+
+// redactEmbeddedJSON redacts s if it is a JSON object or array, and returns
+// other strings unchanged.
+func (rd *Redactor) redactEmbeddedJSON(s string) string {
+	trimmed := strings.TrimSpace(s)
+	if !strings.HasPrefix(trimmed, "{") && !strings.HasPrefix(trimmed, "[") {
+		return s
+	}
+	var inner any
+	if err := json.Unmarshal([]byte(trimmed), &inner); err != nil {
+		return s
+	}
+	out, err := json.Marshal(rd.redactValue(inner))
+	if err != nil {
+		return redacted
+	}
+	return string(out)
+}
+
+func redactAll(values []string) []string {
+	out := make([]string, len(values))
+	for i := range out {
+		out[i] = redacted
+	}
+	return out
+}
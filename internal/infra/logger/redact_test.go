@@ -0,0 +1,116 @@
+package logger_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grantsy/grantsy/internal/infra/logger"
+)
+
+func TestRedactor_Header(t *testing.T) {
+	rd := logger.NewRedactor([]string{"X-Internal-Token"}, nil, nil)
+	h := http.Header{
+		"X-Api-Key":        {"secret"},
+		"Authorization":    {"Bearer jwt"},
+		"X-Internal-Token": {"internal"},
+		"Accept":           {"application/json"},
+	}
+
+	got := rd.Header(h)
+
+	assert.Equal(t, []string{"REDACTED"}, got["X-Api-Key"])
+	assert.Equal(t, []string{"REDACTED"}, got["Authorization"])
+	assert.Equal(t, []string{"REDACTED"}, got["X-Internal-Token"])
+	assert.Equal(t, []string{"application/json"}, got["Accept"])
+	assert.Equal(t, []string{"secret"}, h["X-Api-Key"], "the request headers are untouched")
+}
+
+func TestRedactor_URL(t *testing.T) {
+	rd := logger.NewRedactor(nil, []string{"Session"}, nil)
+
+	u, err := url.Parse("/v1/check?user_id=u1&token=abc&session=xyz")
+	require.NoError(t, err)
+	assert.Equal(t, "/v1/check?session=REDACTED&token=REDACTED&user_id=u1", rd.URL(u))
+
+	u, err = url.Parse("/v1/check?user_id=u1&feature=f")
+	require.NoError(t, err)
+	assert.Equal(t, "/v1/check?user_id=u1&feature=f", rd.URL(u), "URLs without sensitive parameters keep their order")
+}
+
+func TestRedactor_JSON(t *testing.T) {
+	rd := logger.NewRedactor(nil, nil, []string{"url"})
+
+	got := rd.JSON(`{"data":{"subscription":{"card_brand":"visa","card_last_four":"4242","status":"active"},` +
+		`"endpoints":[{"Secret":"whsec_x","url":"https://example.com"}]}}`)
+
+	var v map[string]any
+	require.NoError(t, json.Unmarshal([]byte(got), &v))
+	data := v["data"].(map[string]any)
+	sub := data["subscription"].(map[string]any)
+	assert.Equal(t, "REDACTED", sub["card_brand"])
+	assert.Equal(t, "REDACTED", sub["card_last_four"])
+	assert.Equal(t, "active", sub["status"])
+	endpoint := data["endpoints"].([]any)[0].(map[string]any)
+	assert.Equal(t, "REDACTED", endpoint["Secret"])
+	assert.Equal(t, "REDACTED", endpoint["url"])
+
+	assert.Equal(t, "<invalid JSON>", rd.JSON(`{"key":"gsk_`))
+}
+
+func TestRedactor_JSON_WebhookEvent(t *testing.T) {
+	rd := logger.NewRedactor(nil, nil, nil)
+
+	payload := `{"data":{"attributes":{"user_email":"jane@example.com","user_name":"Jane","card_last_four":"4242","status":"active"}}}`
+	event, err := json.Marshal(map[string]any{
+		"data": map[string]any{
+			"headers": map[string][]string{"X-Signature": {"sig"}, "Content-Type": {"application/json"}},
+			"body":    payload,
+			"note":    "{not json",
+		},
+	})
+	require.NoError(t, err)
+
+	got := rd.JSON(string(event))
+
+	for _, secret := range []string{"sig", "jane@example.com", "Jane", "4242"} {
+		assert.NotContains(t, got, secret)
+	}
+	var v map[string]any
+	require.NoError(t, json.Unmarshal([]byte(got), &v))
+	data := v["data"].(map[string]any)
+	assert.Equal(t, "REDACTED", data["headers"].(map[string]any)["X-Signature"])
+	assert.Equal(t, []any{"application/json"}, data["headers"].(map[string]any)["Content-Type"])
+	assert.Contains(t, data["body"], `"status":"active"`)
+	assert.Equal(t, "{not json", data["note"])
+}
+
+func TestMiddleware_RedactsLogs(t *testing.T) {
+	var buf bytes.Buffer
+	prev := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	t.Cleanup(func() { slog.SetDefault(prev) })
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":{"key":"gsk_01_secret"}}`))
+	})
+	handler := logger.Middleware(logger.NewRedactor(nil, nil, nil))(next)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/api-keys?api_key=query-secret", nil)
+	req.Header.Set("X-Api-Key", "header-secret")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	logs := buf.String()
+	assert.NotContains(t, logs, "header-secret")
+	assert.NotContains(t, logs, "query-secret")
+	assert.NotContains(t, logs, "gsk_01_secret")
+	assert.Contains(t, logs, "REDACTED")
+}
@@ -19,7 +19,16 @@ var (
 			Name:      "http_requests_total",
 			Help:      "Total number of HTTP requests",
 		},
-		[]string{"method", "path", "status_code"},
+		[]string{"method", "path", "status_code", "api_key"},
+	)
+
+	httpRequestsRateLimited = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_rate_limited_total",
+			Help:      "Total number of HTTP requests rejected by a rate limit",
+		},
+		[]string{"limit", "api_key"},
 	)
 
 	httpRequestDuration = prometheus.NewHistogramVec(
@@ -81,6 +90,34 @@ var (
 		[]string{"endpoint"},
 	)
 
+	webhookMessagesDeadLettered = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "webhook_messages_dead_lettered_total",
+			Help:      "Total webhook messages moved to the dead-letter queue",
+		},
+		[]string{"endpoint"},
+	)
+
+	webhookDeadLetters = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "webhook_dead_letters",
+			Help:      "Number of webhook messages in the dead-letter queue",
+		},
+		[]string{"endpoint"},
+	)
+
+	// Incoming webhook metrics
+	incomingWebhooksDropped = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "incoming_webhooks_dropped_total",
+			Help:      "Total incoming provider webhooks dropped without being applied",
+		},
+		[]string{"reason"},
+	)
+
 	registry *prometheus.Registry
 )
 
@@ -91,12 +128,16 @@ func Init(goMetrics bool) http.Handler {
 
 	registry.MustRegister(
 		httpRequestsTotal,
+		httpRequestsRateLimited,
 		httpRequestDuration,
 		entitlementChecksTotal,
 		activeSubscriptions,
 		webhookMessagesQueued,
 		webhookDeliveryAttempts,
 		webhookDeliveryDuration,
+		webhookMessagesDeadLettered,
+		webhookDeadLetters,
+		incomingWebhooksDropped,
 	)
 
 	if goMetrics {
@@ -112,8 +153,13 @@ func Init(goMetrics bool) http.Handler {
 }
 
 // recordHTTPRequest records an HTTP request metric.
-func recordHTTPRequest(method, path, statusCode string) {
-	httpRequestsTotal.WithLabelValues(method, path, statusCode).Inc()
+func recordHTTPRequest(method, path, statusCode, apiKey string) {
+	httpRequestsTotal.WithLabelValues(method, path, statusCode, apiKey).Inc()
+}
+
+// RecordRateLimited records a request rejected by the "ip" or "key" rate limit.
+func RecordRateLimited(limit, apiKey string) {
+	httpRequestsRateLimited.WithLabelValues(limit, apiKey).Inc()
 }
 
 // recordHTTPDuration records an HTTP request duration metric.
@@ -149,3 +195,23 @@ func RecordWebhookDelivery(endpoint string, success bool, duration time.Duration
 	webhookDeliveryAttempts.WithLabelValues(endpoint, status).Inc()
 	webhookDeliveryDuration.WithLabelValues(endpoint).Observe(duration.Seconds())
 }
+
+// RecordWebhookDeadLettered records a webhook message moved to the dead-letter queue.
+func RecordWebhookDeadLettered(endpoint string) {
+	webhookMessagesDeadLettered.WithLabelValues(endpoint).Inc()
+}
+
+// SetWebhookDeadLetters sets the dead-letter queue depth per endpoint.
+// Endpoints missing from counts are removed.
+func SetWebhookDeadLetters(counts map[string]int) {
+	webhookDeadLetters.Reset()
+	for endpoint, count := range counts {
+		webhookDeadLetters.WithLabelValues(endpoint).Set(float64(count))
+	}
+}
+
+// RecordIncomingWebhookDropped records an incoming webhook that was acknowledged
+// but not applied (e.g. "duplicate" or "out_of_order").
+func RecordIncomingWebhookDropped(reason string) {
+	incomingWebhooksDropped.WithLabelValues(reason).Inc()
+}
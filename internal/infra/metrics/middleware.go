@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"github.com/zenazn/goji/web/mutil"
+
+	"github.com/grantsy/grantsy/internal/auth"
 )
 
 // Middleware returns HTTP middleware that records request metrics.
@@ -23,8 +25,12 @@ func Middleware(next http.Handler) http.Handler {
 		duration := time.Since(start).Seconds()
 		status := strconv.Itoa(lw.Status())
 		path := r.Pattern
+		var apiKey string
+		if key := auth.KeyFromContext(r.Context()); key != nil {
+			apiKey = key.Name
+		}
 
-		recordHTTPRequest(r.Method, path, status)
+		recordHTTPRequest(r.Method, path, status, apiKey)
 		recordHTTPDuration(r.Method, path, status, duration)
 	})
 }
@@ -32,6 +32,17 @@ func NewReflector() *openapi31.Reflector {
 		"header",
 		"API key for authentication",
 	)
+	r.Spec.SetAPIKeySecurity(
+		"UserTokenAuth",
+		"X-User-Token",
+		"header",
+		"Short-lived token for the signed-in user, required with publishable keys",
+	)
+	r.Spec.SetHTTPBearerTokenSecurity(
+		"BearerAuth",
+		"JWT",
+		"JWT from the configured issuer, with scopes mapped from its claims",
+	)
 
 	// Wrap nullable $ref fields in anyOf instead of injecting null into shared definitions.
 	r.DefaultOptions = append(r.DefaultOptions,
@@ -45,10 +56,13 @@ func NewReflector() *openapi31.Reflector {
 		InterceptDefName(func(t reflect.Type, defaultDefName string) string {
 			// Remove package prefix (e.g., "Entitlements", "Httptools", "Subscriptions")
 			prefixes := []string{
+				"Audit",
+				"Auth",
 				"Entitlements",
 				"Httptools",
 				"Subscriptions",
 				"Users",
+				"Webhooks",
 			}
 			for _, prefix := range prefixes {
 				if after, ok := strings.CutPrefix(defaultDefName, prefix); ok {
@@ -79,6 +93,13 @@ func AddErrorResponses(op openapi.OperationContext) {
 			cu.Description = "Unauthorized - missing or invalid API key"
 		},
 	)
+	op.AddRespStructure(
+		new(httptools.ErrorResponse),
+		func(cu *openapi.ContentUnit) {
+			cu.HTTPStatus = http.StatusForbidden
+			cu.Description = "Forbidden - API key is missing the required scope"
+		},
+	)
 	op.AddRespStructure(
 		new(httptools.ErrorResponse),
 		func(cu *openapi.ContentUnit) {
@@ -86,6 +107,13 @@ func AddErrorResponses(op openapi.OperationContext) {
 			cu.Description = "Validation Failed"
 		},
 	)
+	op.AddRespStructure(
+		new(httptools.ErrorResponse),
+		func(cu *openapi.ContentUnit) {
+			cu.HTTPStatus = http.StatusTooManyRequests
+			cu.Description = "Too Many Requests - rate limit exceeded, retry after the Retry-After header's seconds"
+		},
+	)
 	op.AddRespStructure(
 		new(httptools.ErrorResponse),
 		func(cu *openapi.ContentUnit) {
@@ -94,3 +122,14 @@ func AddErrorResponses(op openapi.OperationContext) {
 		},
 	)
 }
+
+// AddUserTokenSecurity documents that op also accepts a publishable key
+// together with a user token.
+func AddUserTokenSecurity(op openapi.OperationContext) {
+	if o, ok := op.(openapi31.OperationExposer); ok {
+		o.Operation().Security = append(o.Operation().Security, map[string][]string{
+			"ApiKeyAuth":    {},
+			"UserTokenAuth": {},
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/grantsy/grantsy/internal/auth"
+	"github.com/grantsy/grantsy/internal/httptools"
+	"github.com/grantsy/grantsy/internal/infra/config"
+	"github.com/grantsy/grantsy/internal/infra/logger"
+	"github.com/grantsy/grantsy/internal/infra/metrics"
+)
+
+// cleanupInterval is how often idle buckets are removed.
+const cleanupInterval = 10 * time.Minute
+
+// limit is a token bucket's refill rate in tokens per second and capacity.
+type limit struct {
+	rate  float64
+	burst float64
+}
+
+func parseLimit(l *config.RateLimit) (*limit, error) {
+	if l == nil {
+		return nil, nil
+	}
+	period, err := time.ParseDuration(l.Period)
+	if err != nil || period <= 0 {
+		return nil, fmt.Errorf("ratelimit: invalid period %q", l.Period)
+	}
+	burst := l.Burst
+	if burst == 0 {
+		burst = l.Requests
+	}
+	return &limit{
+		rate:  float64(l.Requests) / period.Seconds(),
+		burst: float64(burst),
+	}, nil
+}
+
+// refillTime is how long an empty bucket takes to fill up. A bucket idle for
+// longer is full, the same as a missing one.
+func (l *limit) refillTime() time.Duration {
+	return time.Duration(l.burst / l.rate * float64(time.Second))
+}
+
+// Limiter rate limits requests per client IP and per API key.
+type Limiter struct {
+	store       Store
+	ipHeader    string
+	trustedHops int
+	perIP       *limit
+	perKey      *limit
+	keys        map[string]*limit
+}
+
+// NewLimiter creates a limiter enforcing cfg with buckets in store.
+func NewLimiter(cfg config.RateLimitConfig, store Store) (*Limiter, error) {
+	l := &Limiter{
+		store:       store,
+		ipHeader:    cfg.IPHeader,
+		trustedHops: max(cfg.TrustedHops, 1),
+		keys:        make(map[string]*limit, len(cfg.Keys)),
+	}
+	var err error
+	if l.perIP, err = parseLimit(cfg.PerIP); err != nil {
+		return nil, err
+	}
+	if l.perKey, err = parseLimit(cfg.PerKey); err != nil {
+		return nil, err
+	}
+	for _, k := range cfg.Keys {
+		if l.keys[k.Name], err = parseLimit(&k.RateLimit); err != nil {
+			return nil, err
+		}
+	}
+	return l, nil
+}
+
+// Start removes idle buckets periodically until ctx is done.
+func (l *Limiter) Start(ctx context.Context) {
+	ticker := time.NewTicker(cleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			before := time.Now().Add(-l.maxRefillTime())
+			if err := l.store.DeleteIdle(ctx, before); err != nil {
+				logger.FromContext(ctx).Error("failed to delete idle rate limit buckets", "error", err)
+			}
+		}
+	}
+}
+
+func (l *Limiter) maxRefillTime() time.Duration {
+	longest := cleanupInterval
+	limits := slices.Collect(maps.Values(l.keys))
+	for _, lim := range append(limits, l.perIP, l.perKey) {
+		if lim != nil {
+			longest = max(longest, lim.refillTime())
+		}
+	}
+	return longest
+}
+
+// IPMiddleware limits requests per client IP. It runs before authentication,
+// so it also slows down clients guessing API keys.
+func (l *Limiter) IPMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if l.perIP == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if l.allow(w, r, "ip:"+l.clientIP(r), l.perIP, "ip") {
+			next.ServeHTTP(w, r)
+		}
+	})
+}
+
+// KeyMiddleware limits requests per API key, with the key's own limit if one
+// is configured. It runs after authentication; requests without a key pass.
+func (l *Limiter) KeyMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := auth.KeyFromContext(r.Context())
+		if key == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		lim, ok := l.keys[key.Name]
+		if !ok {
+			lim = l.perKey
+		}
+		if lim == nil || l.allow(w, r, bucketKey(key), lim, "key") {
+			next.ServeHTTP(w, r)
+		}
+	})
+}
+
+// bucketKey names the bucket of key. Stored keys are told apart by ID, since
+// their names need not be unique; other keys by name within their source, so
+// a bearer token named after a configured key doesn't share its bucket.
+func bucketKey(key *auth.Key) string {
+	switch {
+	case key.ID != "":
+		return "key:stored:" + key.ID
+	case key.Bearer:
+		return "key:bearer:" + key.Name
+	default:
+		return "key:config:" + key.Name
+	}
+}
+
+// allow takes a token from bucket, or writes a 429 response and returns
+// false if it is empty. If the store fails, the request is let through:
+// rate limiting protects the service, it shouldn't take it down.
+func (l *Limiter) allow(w http.ResponseWriter, r *http.Request, bucket string, lim *limit, kind string) bool {
+	ok, retryAfter, err := l.store.Take(r.Context(), bucket, lim.rate, lim.burst, time.Now())
+	if err != nil {
+		logger.FromContext(r.Context()).Error("failed to check rate limit", "error", err, "bucket", bucket)
+		return true
+	}
+	if ok {
+		return true
+	}
+
+	var apiKey string
+	if key := auth.KeyFromContext(r.Context()); key != nil {
+		apiKey = key.Name
+	}
+	metrics.RecordRateLimited(kind, apiKey)
+	httptools.TooManyRequests(w, r, retryAfter, fmt.Sprintf("Rate limit per %s exceeded", describe(kind)))
+	return false
+}
+
+func describe(kind string) string {
+	if kind == "ip" {
+		return "IP address"
+	}
+	return "API key"
+}
+
+// clientIP returns the client's IP from the configured header, or the
+// connection's remote address. Each trusted proxy appends the address it
+// received the request from, so the client's is trustedHops from the right;
+// entries further left are sent by the client and can be anything.
+func (l *Limiter) clientIP(r *http.Request) string {
+	if l.ipHeader != "" {
+		var addrs []string
+		for _, value := range r.Header.Values(l.ipHeader) {
+			addrs = append(addrs, strings.Split(value, ",")...)
+		}
+		if len(addrs) >= l.trustedHops {
+			if addr := strings.TrimSpace(addrs[len(addrs)-l.trustedHops]); addr != "" {
+				return addr
+			}
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
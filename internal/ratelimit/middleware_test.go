@@ -0,0 +1,151 @@
+package ratelimit_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grantsy/grantsy/internal/auth"
+	"github.com/grantsy/grantsy/internal/httptools"
+	"github.com/grantsy/grantsy/internal/infra/config"
+	"github.com/grantsy/grantsy/internal/ratelimit"
+	"github.com/grantsy/grantsy/internal/ratelimit/mocks"
+)
+
+var okHandler = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusOK)
+})
+
+func newLimiter(t *testing.T, cfg config.RateLimitConfig) *ratelimit.Limiter {
+	t.Helper()
+	limiter, err := ratelimit.NewLimiter(cfg, ratelimit.NewMemoryStore())
+	require.NoError(t, err)
+	return limiter
+}
+
+// serveAs serves a request authenticated with the named key.
+func serveAs(handler http.Handler, keyName string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/v1/check", nil)
+	req = req.WithContext(auth.WithKey(req.Context(), &auth.Key{Name: keyName}))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestKeyMiddleware(t *testing.T) {
+	limiter := newLimiter(t, config.RateLimitConfig{
+		PerKey: &config.RateLimit{Requests: 2, Period: "1m"},
+		Keys: []config.KeyRateLimit{
+			{Name: "pricing-page", RateLimit: config.RateLimit{Requests: 1, Period: "1h"}},
+		},
+	})
+	handler := limiter.KeyMiddleware(okHandler)
+
+	for range 2 {
+		assert.Equal(t, http.StatusOK, serveAs(handler, "backend").Code)
+	}
+	rec := serveAs(handler, "backend")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "30", rec.Header().Get("Retry-After"))
+	assert.Contains(t, rec.Body.String(), httptools.ErrTypeRateLimited)
+
+	assert.Equal(t, http.StatusOK, serveAs(handler, "pricing-page").Code, "keys have separate buckets")
+	rec = serveAs(handler, "pricing-page")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "3600", rec.Header().Get("Retry-After"), "the key's own limit applies")
+}
+
+func TestIPMiddleware(t *testing.T) {
+	limiter := newLimiter(t, config.RateLimitConfig{
+		IPHeader: "X-Forwarded-For",
+		PerIP:    &config.RateLimit{Requests: 1, Period: "1s"},
+	})
+	handler := limiter.IPMiddleware(okHandler)
+
+	assert.Equal(t, http.StatusOK, serveFrom(handler, "10.0.0.1:1234", ""))
+	assert.Equal(t, http.StatusTooManyRequests, serveFrom(handler, "10.0.0.1:5678", ""))
+	// The proxy appended 203.0.113.7; the entries before it came from the client.
+	assert.Equal(t, http.StatusOK, serveFrom(handler, "10.0.0.2:1234", "198.51.100.1, 203.0.113.7"))
+	assert.Equal(t, http.StatusTooManyRequests, serveFrom(handler, "10.0.0.3:1234", "198.51.100.2, 203.0.113.7"))
+	assert.Equal(t, http.StatusTooManyRequests, serveFrom(handler, "10.0.0.3:1234", "203.0.113.7"))
+}
+
+func TestIPMiddleware_TrustedHops(t *testing.T) {
+	limiter := newLimiter(t, config.RateLimitConfig{
+		IPHeader:    "X-Forwarded-For",
+		TrustedHops: 2,
+		PerIP:       &config.RateLimit{Requests: 1, Period: "1s"},
+	})
+	handler := limiter.IPMiddleware(okHandler)
+
+	// A CDN appends the client, then the load balancer appends the CDN.
+	assert.Equal(t, http.StatusOK, serveFrom(handler, "10.0.0.1:1234", "spoofed, 203.0.113.7, 192.0.2.1"))
+	assert.Equal(t, http.StatusTooManyRequests, serveFrom(handler, "10.0.0.1:1234", "other, 203.0.113.7, 192.0.2.2"))
+	// Too few entries to skip the trusted hops: the connection's address is used.
+	assert.Equal(t, http.StatusOK, serveFrom(handler, "10.0.0.2:1234", "203.0.113.7"))
+}
+
+func serveFrom(handler http.Handler, remoteAddr, forwardedFor string) int {
+	req := httptest.NewRequest(http.MethodGet, "/v1/check", nil)
+	req.RemoteAddr = remoteAddr
+	if forwardedFor != "" {
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+func TestKeyMiddleware_StoreErrorLetsRequestsThrough(t *testing.T) {
+	store := mocks.NewMockStore(t)
+	store.EXPECT().
+		Take(mock.Anything, "key:config:backend", mock.Anything, mock.Anything, mock.Anything).
+		Return(false, 0, errors.New("database is down"))
+
+	limiter, err := ratelimit.NewLimiter(config.RateLimitConfig{
+		PerKey: &config.RateLimit{Requests: 1, Period: "1s"},
+	}, store)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, serveAs(limiter.KeyMiddleware(okHandler), "backend").Code)
+}
+
+func TestNewLimiter_InvalidPeriod(t *testing.T) {
+	_, err := ratelimit.NewLimiter(config.RateLimitConfig{
+		PerIP: &config.RateLimit{Requests: 1, Period: "daily"},
+	}, ratelimit.NewMemoryStore())
+	require.Error(t, err)
+}
+
+func TestKeyMiddleware_SeparateBucketsPerKeySource(t *testing.T) {
+	limiter := newLimiter(t, config.RateLimitConfig{
+		PerKey: &config.RateLimit{Requests: 1, Period: "1m"},
+	})
+	handler := limiter.KeyMiddleware(okHandler)
+	serve := func(key *auth.Key) int {
+		req := httptest.NewRequest(http.MethodGet, "/v1/check", nil)
+		req = req.WithContext(auth.WithKey(req.Context(), key))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// Keys sharing a name, from each source and two stored ones.
+	keys := []*auth.Key{
+		{Name: "backend"},
+		{Name: "backend", Bearer: true},
+		{ID: "key-1", Name: "backend"},
+		{ID: "key-2", Name: "backend"},
+	}
+	for _, key := range keys {
+		assert.Equal(t, http.StatusOK, serve(key))
+	}
+	for _, key := range keys {
+		assert.Equal(t, http.StatusTooManyRequests, serve(key))
+	}
+}
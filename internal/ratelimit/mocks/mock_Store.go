@@ -0,0 +1,152 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MockStore is an autogenerated mock type for the Store type
+type MockStore struct {
+	mock.Mock
+}
+
+type MockStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockStore) EXPECT() *MockStore_Expecter {
+	return &MockStore_Expecter{mock: &_m.Mock}
+}
+
+// DeleteIdle provides a mock function with given fields: ctx, before
+func (_m *MockStore) DeleteIdle(ctx context.Context, before time.Time) error {
+	ret := _m.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteIdle")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) error); ok {
+		r0 = rf(ctx, before)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_DeleteIdle_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteIdle'
+type MockStore_DeleteIdle_Call struct {
+	*mock.Call
+}
+
+// DeleteIdle is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+func (_e *MockStore_Expecter) DeleteIdle(ctx interface{}, before interface{}) *MockStore_DeleteIdle_Call {
+	return &MockStore_DeleteIdle_Call{Call: _e.mock.On("DeleteIdle", ctx, before)}
+}
+
+func (_c *MockStore_DeleteIdle_Call) Run(run func(ctx context.Context, before time.Time)) *MockStore_DeleteIdle_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockStore_DeleteIdle_Call) Return(_a0 error) *MockStore_DeleteIdle_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_DeleteIdle_Call) RunAndReturn(run func(context.Context, time.Time) error) *MockStore_DeleteIdle_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Take provides a mock function with given fields: ctx, bucket, rate, burst, now
+func (_m *MockStore) Take(ctx context.Context, bucket string, rate float64, burst float64, now time.Time) (bool, time.Duration, error) {
+	ret := _m.Called(ctx, bucket, rate, burst, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Take")
+	}
+
+	var r0 bool
+	var r1 time.Duration
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, float64, float64, time.Time) (bool, time.Duration, error)); ok {
+		return rf(ctx, bucket, rate, burst, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, float64, float64, time.Time) bool); ok {
+		r0 = rf(ctx, bucket, rate, burst, now)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, float64, float64, time.Time) time.Duration); ok {
+		r1 = rf(ctx, bucket, rate, burst, now)
+	} else {
+		r1 = ret.Get(1).(time.Duration)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, float64, float64, time.Time) error); ok {
+		r2 = rf(ctx, bucket, rate, burst, now)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockStore_Take_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Take'
+type MockStore_Take_Call struct {
+	*mock.Call
+}
+
+// Take is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bucket string
+//   - rate float64
+//   - burst float64
+//   - now time.Time
+func (_e *MockStore_Expecter) Take(ctx interface{}, bucket interface{}, rate interface{}, burst interface{}, now interface{}) *MockStore_Take_Call {
+	return &MockStore_Take_Call{Call: _e.mock.On("Take", ctx, bucket, rate, burst, now)}
+}
+
+func (_c *MockStore_Take_Call) Run(run func(ctx context.Context, bucket string, rate float64, burst float64, now time.Time)) *MockStore_Take_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(float64), args[3].(float64), args[4].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockStore_Take_Call) Return(_a0 bool, _a1 time.Duration, _a2 error) *MockStore_Take_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockStore_Take_Call) RunAndReturn(run func(context.Context, string, float64, float64, time.Time) (bool, time.Duration, error)) *MockStore_Take_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockStore creates a new instance of MockStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockStore {
+	mock := &MockStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,82 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/grantsy/grantsy/internal/infra/db"
+)
+
+// Repo keeps token buckets in the database, shared by every replica.
+// Timestamps are stored in milliseconds.
+type Repo struct {
+	db *db.DB
+}
+
+func NewRepo(database *db.DB) *Repo {
+	return &Repo{db: database}
+}
+
+// Take refills the bucket in one upsert and takes a token with a conditional
+// decrement, so concurrent requests on any replica can't take the same token.
+func (r *Repo) Take(ctx context.Context, bucket string, rate, burst float64, now time.Time) (bool, time.Duration, error) {
+	table := r.db.TableName("rate_limit_buckets")
+	least := "LEAST"
+	if r.db.Driver() == "sqlite" {
+		least = "MIN"
+	}
+
+	refill := r.db.Rebind(fmt.Sprintf(`
+		INSERT INTO %s (bucket, tokens, updated_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (bucket) DO UPDATE SET
+			tokens = %s(
+				CAST($4 AS DOUBLE PRECISION),
+				tokens + ($5 - updated_at) * CAST($6 AS DOUBLE PRECISION)
+			),
+			updated_at = $7
+		RETURNING tokens
+	`, table, least))
+
+	ms := now.UnixMilli()
+	var tokens float64
+	if err := r.db.QueryRowContext(ctx, refill,
+		bucket, burst, ms,
+		burst, ms, rate/1000, ms,
+	).Scan(&tokens); err != nil {
+		return false, 0, fmt.Errorf("ratelimit: failed to refill bucket: %w", err)
+	}
+
+	take := r.db.Rebind(fmt.Sprintf(`
+		UPDATE %s SET tokens = tokens - 1 WHERE bucket = $1 AND tokens >= 1
+	`, table))
+	res, err := r.db.ExecContext(ctx, take, bucket)
+	if err != nil {
+		return false, 0, fmt.Errorf("ratelimit: failed to take token: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, 0, fmt.Errorf("ratelimit: failed to take token: %w", err)
+	}
+	if n == 1 {
+		return true, 0, nil
+	}
+	// If another request took the last token since the refill, wait for a
+	// whole token.
+	if tokens >= 1 {
+		tokens = 0
+	}
+	return false, wait(tokens, rate), nil
+}
+
+// DeleteIdle removes buckets last used before before.
+func (r *Repo) DeleteIdle(ctx context.Context, before time.Time) error {
+	table := r.db.TableName("rate_limit_buckets")
+	query := r.db.Rebind(fmt.Sprintf(`DELETE FROM %s WHERE updated_at < $1`, table))
+
+	if _, err := r.db.ExecContext(ctx, query, before.UnixMilli()); err != nil {
+		return fmt.Errorf("ratelimit: failed to delete idle buckets: %w", err)
+	}
+	return nil
+}
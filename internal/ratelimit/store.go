@@ -0,0 +1,68 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Store keeps token buckets.
+type Store interface {
+	// Take removes a token from bucket, refilled at rate tokens per second up
+	// to burst tokens. If the bucket is empty it reports how long until a
+	// token is available.
+	Take(ctx context.Context, bucket string, rate, burst float64, now time.Time) (bool, time.Duration, error)
+	// DeleteIdle removes buckets last used before before.
+	DeleteIdle(ctx context.Context, before time.Time) error
+}
+
+// MemoryStore keeps token buckets in memory, per process.
+type MemoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: make(map[string]*bucket)}
+}
+
+func (s *MemoryStore) Take(_ context.Context, name string, rate, burst float64, now time.Time) (bool, time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, ok := s.buckets[name]
+	if !ok {
+		b = &bucket{tokens: burst, updatedAt: now}
+		s.buckets[name] = b
+	}
+	b.tokens = min(burst, b.tokens+now.Sub(b.updatedAt).Seconds()*rate)
+	b.updatedAt = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0, nil
+	}
+	return false, wait(b.tokens, rate), nil
+}
+
+func (s *MemoryStore) DeleteIdle(_ context.Context, before time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for name, b := range s.buckets {
+		if b.updatedAt.Before(before) {
+			delete(s.buckets, name)
+		}
+	}
+	return nil
+}
+
+// wait returns how long a bucket holding tokens takes to refill to one token.
+func wait(tokens, rate float64) time.Duration {
+	return time.Duration((1 - tokens) / rate * float64(time.Second))
+}
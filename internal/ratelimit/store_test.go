@@ -0,0 +1,102 @@
+package ratelimit_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grantsy/grantsy/internal/infra/db"
+	"github.com/grantsy/grantsy/internal/ratelimit"
+)
+
+func newSQLiteRepo(t *testing.T) *ratelimit.Repo {
+	t.Helper()
+
+	dsn := filepath.Join(t.TempDir(), "test.db")
+	require.NoError(t, db.Migrate("sqlite", dsn, ""), "sqlite migration failed")
+
+	database, err := db.New("sqlite", dsn, "")
+	require.NoError(t, err, "sqlite connection failed")
+	t.Cleanup(func() { database.Close() })
+
+	return ratelimit.NewRepo(database)
+}
+
+func TestStores_Take(t *testing.T) {
+	stores := map[string]ratelimit.Store{
+		"memory": ratelimit.NewMemoryStore(),
+		"sqlite": newSQLiteRepo(t),
+	}
+
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			now := time.Unix(1700000000, 0)
+
+			// 2 tokens per second, bursts of 3.
+			for range 3 {
+				ok, _, err := store.Take(ctx, "key:a", 2, 3, now)
+				require.NoError(t, err)
+				assert.True(t, ok)
+			}
+
+			ok, retryAfter, err := store.Take(ctx, "key:a", 2, 3, now)
+			require.NoError(t, err)
+			assert.False(t, ok)
+			assert.Equal(t, 500*time.Millisecond, retryAfter)
+
+			ok, _, err = store.Take(ctx, "key:b", 2, 3, now)
+			require.NoError(t, err)
+			assert.True(t, ok, "buckets are independent")
+
+			ok, _, err = store.Take(ctx, "key:a", 2, 3, now.Add(500*time.Millisecond))
+			require.NoError(t, err)
+			assert.True(t, ok, "a token is refilled after 500ms")
+
+			ok, _, err = store.Take(ctx, "key:a", 2, 3, now.Add(time.Hour))
+			require.NoError(t, err)
+			assert.True(t, ok)
+			for range 2 {
+				ok, _, err = store.Take(ctx, "key:a", 2, 3, now.Add(time.Hour))
+				require.NoError(t, err)
+				assert.True(t, ok)
+			}
+			ok, _, err = store.Take(ctx, "key:a", 2, 3, now.Add(time.Hour))
+			require.NoError(t, err)
+			assert.False(t, ok, "refill is capped at the burst")
+
+			ok, retryAfter, err = store.Take(ctx, "key:a", 2, 3, now.Add(time.Hour+250*time.Millisecond))
+			require.NoError(t, err)
+			assert.False(t, ok)
+			assert.Equal(t, 250*time.Millisecond, retryAfter, "only the missing part of a token is waited for")
+		})
+	}
+}
+
+func TestStores_DeleteIdle(t *testing.T) {
+	stores := map[string]ratelimit.Store{
+		"memory": ratelimit.NewMemoryStore(),
+		"sqlite": newSQLiteRepo(t),
+	}
+
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			now := time.Unix(1700000000, 0)
+
+			ok, _, err := store.Take(ctx, "ip:1", 1, 1, now)
+			require.NoError(t, err)
+			require.True(t, ok)
+
+			require.NoError(t, store.DeleteIdle(ctx, now.Add(time.Second)))
+
+			ok, _, err = store.Take(ctx, "ip:1", 1, 1, now)
+			require.NoError(t, err)
+			assert.True(t, ok, "a deleted bucket starts full")
+		})
+	}
+}
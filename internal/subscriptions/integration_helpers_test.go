@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"testing"
 	"time"
@@ -73,3 +74,34 @@ func testSub(id int, userID string, status string) *subscriptions.Subscription {
 		UpdatedAt:          now,
 	}
 }
+
+func testPayment(invoiceID, subscriptionID int, eventName string, updatedAt int64) *subscriptions.Payment {
+	return &subscriptions.Payment{
+		InvoiceID:      invoiceID,
+		EventName:      eventName,
+		SubscriptionID: subscriptionID,
+		UserID:         "user-1",
+		BillingReason:  "renewal",
+		Status:         "paid",
+		Currency:       "USD",
+		Subtotal:       999,
+		Total:          999,
+		TotalUSD:       999,
+		InvoiceURL:     "https://example.com/invoice",
+		CreatedAt:      updatedAt,
+		UpdatedAt:      updatedAt,
+	}
+}
+
+func testWebhookEvent(id, eventName, result string) *subscriptions.WebhookEvent {
+	return &subscriptions.WebhookEvent{
+		ID:          id,
+		Provider:    "lemonsqueezy",
+		EventName:   eventName,
+		PayloadHash: "hash-" + id,
+		Headers:     http.Header{"X-Event-Name": {eventName}},
+		Body:        []byte(`{"meta":{}}`),
+		Result:      result,
+		ReceivedAt:  time.Now().Unix(),
+	}
+}
@@ -7,6 +7,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/grantsy/grantsy/internal/subscriptions"
 )
 
 func TestRepoIntegration(t *testing.T) {
@@ -53,6 +55,23 @@ func TestRepoIntegration(t *testing.T) {
 					assert.Equal(t, endsAt, *got.EndsAt)
 				})
 
+				t.Run("older_update_rejected", func(t *testing.T) {
+					repo := drv.newDB(t)
+					ctx := context.Background()
+
+					sub := testSub(1, "user-1", "cancelled")
+					require.NoError(t, repo.UpsertSubscription(ctx, sub))
+
+					stale := testSub(1, "user-1", "active")
+					stale.UpdatedAt = sub.UpdatedAt - 60
+					err := repo.UpsertSubscription(ctx, stale)
+					require.ErrorIs(t, err, subscriptions.ErrStaleSubscription)
+
+					got, err := repo.GetSubscriptionByUserID(ctx, "user-1")
+					require.NoError(t, err)
+					assert.Equal(t, "cancelled", got.Status)
+				})
+
 				t.Run("all_fields_roundtrip", func(t *testing.T) {
 					repo := drv.newDB(t)
 					ctx := context.Background()
@@ -239,6 +258,206 @@ func TestRepoIntegration(t *testing.T) {
 					assert.False(t, exists)
 				})
 			})
+
+			t.Run("ListUserSubscriptions", func(t *testing.T) {
+				repo := drv.newDB(t)
+				ctx := context.Background()
+
+				require.NoError(t, repo.UpsertSubscription(ctx, testSub(1, "user-a", "active")))
+				require.NoError(t, repo.UpsertSubscription(ctx, testSub(2, "user-b", "past_due")))
+				require.NoError(t, repo.UpsertSubscription(ctx, testSub(3, "user-c", "expired")))
+				// user-c's expired subscription is superseded by an active one.
+				require.NoError(t, repo.UpsertSubscription(ctx, testSub(4, "user-c", "active")))
+				require.NoError(t, repo.UpsertSubscription(ctx, testSub(5, "user-d", "past_due")))
+
+				tests := []struct {
+					name   string
+					filter subscriptions.UserFilter
+					want   []int
+				}{
+					{"all", subscriptions.UserFilter{}, []int{1, 2, 4, 5}},
+					{"status", subscriptions.UserFilter{Status: "past_due"}, []int{2, 5}},
+					{"superseded_status", subscriptions.UserFilter{Status: "expired"}, nil},
+					{"cursor", subscriptions.UserFilter{Cursor: "user-b", Limit: 1}, []int{4}},
+					{"cursor_and_status", subscriptions.UserFilter{Status: "past_due", Cursor: "user-b"}, []int{5}},
+					{"users", subscriptions.UserFilter{UserIDs: []string{"user-d", "user-a", "user-x"}}, []int{1, 5}},
+					{"users_and_cursor", subscriptions.UserFilter{Cursor: "user-a", UserIDs: []string{"user-a", "user-c"}}, []int{4}},
+					{"no_users", subscriptions.UserFilter{UserIDs: []string{}}, nil},
+				}
+				for _, tt := range tests {
+					t.Run(tt.name, func(t *testing.T) {
+						if tt.filter.Limit == 0 {
+							tt.filter.Limit = 10
+						}
+						subs, err := repo.ListUserSubscriptions(ctx, tt.filter)
+						require.NoError(t, err)
+
+						var ids []int
+						for _, sub := range subs {
+							ids = append(ids, sub.ID)
+						}
+						assert.Equal(t, tt.want, ids)
+					})
+				}
+			})
+
+			t.Run("ProcessedWebhooks", func(t *testing.T) {
+				repo := drv.newDB(t)
+				ctx := context.Background()
+
+				processed, err := repo.IsWebhookProcessed(ctx, "hash-1")
+				require.NoError(t, err)
+				assert.False(t, processed)
+
+				require.NoError(t, repo.MarkWebhookProcessed(ctx, "hash-1", "subscription_updated"))
+				require.NoError(t, repo.MarkWebhookProcessed(ctx, "hash-1", "subscription_updated"))
+
+				processed, err = repo.IsWebhookProcessed(ctx, "hash-1")
+				require.NoError(t, err)
+				assert.True(t, processed)
+			})
+
+			t.Run("PriceCache", func(t *testing.T) {
+				repo := drv.newDB(t)
+				ctx := context.Background()
+
+				got, err := repo.GetCachedPrice(ctx, 555)
+				require.NoError(t, err)
+				assert.Nil(t, got)
+
+				p := &subscriptions.Price{
+					PriceID: 555,
+					PriceInfo: subscriptions.PriceInfo{
+						VariantID:               7,
+						UnitPrice:               999,
+						RenewalIntervalUnit:     "month",
+						RenewalIntervalQuantity: 1,
+					},
+					UpdatedAt: 100,
+				}
+				require.NoError(t, repo.UpsertPrice(ctx, p))
+
+				p.UnitPrice = 1999
+				p.UpdatedAt = 200
+				require.NoError(t, repo.UpsertPrice(ctx, p))
+
+				got, err = repo.GetCachedPrice(ctx, 555)
+				require.NoError(t, err)
+				assert.Equal(t, p, got)
+			})
+
+			t.Run("WebhookEvents", func(t *testing.T) {
+				t.Run("insert_update_get", func(t *testing.T) {
+					repo := drv.newDB(t)
+					ctx := context.Background()
+
+					e := testWebhookEvent("01", "subscription_updated", subscriptions.WebhookResultPending)
+					require.NoError(t, repo.InsertWebhookEvent(ctx, e))
+
+					processedAt := e.ReceivedAt + 1
+					e.Result = subscriptions.WebhookResultFailed
+					e.StatusCode = 500
+					e.Error = "boom"
+					e.Attempts = 1
+					e.ProcessedAt = &processedAt
+					require.NoError(t, repo.UpdateWebhookEventResult(ctx, e))
+
+					got, err := repo.GetWebhookEvent(ctx, "01")
+					require.NoError(t, err)
+					assert.Equal(t, e, got)
+				})
+
+				t.Run("get_not_found", func(t *testing.T) {
+					repo := drv.newDB(t)
+
+					got, err := repo.GetWebhookEvent(context.Background(), "missing")
+					require.NoError(t, err)
+					assert.Nil(t, got)
+				})
+
+				t.Run("list_filter_and_cursor", func(t *testing.T) {
+					repo := drv.newDB(t)
+					ctx := context.Background()
+
+					for _, e := range []*subscriptions.WebhookEvent{
+						testWebhookEvent("01", "subscription_created", subscriptions.WebhookResultProcessed),
+						testWebhookEvent("02", "subscription_updated", subscriptions.WebhookResultFailed),
+						testWebhookEvent("03", "subscription_updated", subscriptions.WebhookResultProcessed),
+						testWebhookEvent("04", "subscription_updated", subscriptions.WebhookResultProcessed),
+					} {
+						require.NoError(t, repo.InsertWebhookEvent(ctx, e))
+					}
+
+					page, err := repo.ListWebhookEvents(ctx, subscriptions.WebhookEventFilter{
+						EventName: "subscription_updated",
+						Limit:     2,
+					})
+					require.NoError(t, err)
+					require.Len(t, page, 2)
+					assert.Equal(t, "04", page[0].ID)
+					assert.Equal(t, "03", page[1].ID)
+
+					page, err = repo.ListWebhookEvents(ctx, subscriptions.WebhookEventFilter{
+						EventName: "subscription_updated",
+						Cursor:    "03",
+						Limit:     2,
+					})
+					require.NoError(t, err)
+					require.Len(t, page, 1)
+					assert.Equal(t, "02", page[0].ID)
+
+					page, err = repo.ListWebhookEvents(ctx, subscriptions.WebhookEventFilter{
+						Result: subscriptions.WebhookResultProcessed,
+						Limit:  10,
+					})
+					require.NoError(t, err)
+					assert.Len(t, page, 3)
+				})
+			})
+
+			t.Run("InsertPayment", func(t *testing.T) {
+				t.Run("history_in_order", func(t *testing.T) {
+					repo := drv.newDB(t)
+					ctx := context.Background()
+
+					failed := testPayment(10, 1, "subscription_payment_failed", 100)
+					recovered := testPayment(10, 1, "subscription_payment_recovered", 200)
+					require.NoError(t, repo.InsertPayment(ctx, recovered))
+					require.NoError(t, repo.InsertPayment(ctx, failed))
+
+					got, err := repo.ListPayments(ctx, 1)
+					require.NoError(t, err)
+					require.Len(t, got, 2)
+					assert.Equal(t, *failed, got[0])
+					assert.Equal(t, *recovered, got[1])
+				})
+
+				t.Run("duplicate_ignored", func(t *testing.T) {
+					repo := drv.newDB(t)
+					ctx := context.Background()
+
+					p := testPayment(10, 1, "subscription_payment_success", 100)
+					require.NoError(t, repo.InsertPayment(ctx, p))
+					require.NoError(t, repo.InsertPayment(ctx, p))
+
+					got, err := repo.ListPayments(ctx, 1)
+					require.NoError(t, err)
+					assert.Len(t, got, 1)
+				})
+
+				t.Run("other_subscription_excluded", func(t *testing.T) {
+					repo := drv.newDB(t)
+					ctx := context.Background()
+
+					require.NoError(t, repo.InsertPayment(ctx, testPayment(10, 1, "subscription_payment_success", 100)))
+					require.NoError(t, repo.InsertPayment(ctx, testPayment(11, 2, "subscription_payment_success", 100)))
+
+					got, err := repo.ListPayments(ctx, 2)
+					require.NoError(t, err)
+					require.Len(t, got, 1)
+					assert.Equal(t, 11, got[0].InvoiceID)
+				})
+			})
 		})
 	}
 }
@@ -0,0 +1,96 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	subscriptions "github.com/grantsy/grantsy/internal/subscriptions"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockCheckoutCreator is an autogenerated mock type for the CheckoutCreator type
+type MockCheckoutCreator struct {
+	mock.Mock
+}
+
+type MockCheckoutCreator_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCheckoutCreator) EXPECT() *MockCheckoutCreator_Expecter {
+	return &MockCheckoutCreator_Expecter{mock: &_m.Mock}
+}
+
+// CreateCheckout provides a mock function with given fields: ctx, params
+func (_m *MockCheckoutCreator) CreateCheckout(ctx context.Context, params subscriptions.CheckoutParams) (*subscriptions.Checkout, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCheckout")
+	}
+
+	var r0 *subscriptions.Checkout
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, subscriptions.CheckoutParams) (*subscriptions.Checkout, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, subscriptions.CheckoutParams) *subscriptions.Checkout); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*subscriptions.Checkout)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, subscriptions.CheckoutParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCheckoutCreator_CreateCheckout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateCheckout'
+type MockCheckoutCreator_CreateCheckout_Call struct {
+	*mock.Call
+}
+
+// CreateCheckout is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params subscriptions.CheckoutParams
+func (_e *MockCheckoutCreator_Expecter) CreateCheckout(ctx interface{}, params interface{}) *MockCheckoutCreator_CreateCheckout_Call {
+	return &MockCheckoutCreator_CreateCheckout_Call{Call: _e.mock.On("CreateCheckout", ctx, params)}
+}
+
+func (_c *MockCheckoutCreator_CreateCheckout_Call) Run(run func(ctx context.Context, params subscriptions.CheckoutParams)) *MockCheckoutCreator_CreateCheckout_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(subscriptions.CheckoutParams))
+	})
+	return _c
+}
+
+func (_c *MockCheckoutCreator_CreateCheckout_Call) Return(_a0 *subscriptions.Checkout, _a1 error) *MockCheckoutCreator_CreateCheckout_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCheckoutCreator_CreateCheckout_Call) RunAndReturn(run func(context.Context, subscriptions.CheckoutParams) (*subscriptions.Checkout, error)) *MockCheckoutCreator_CreateCheckout_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockCheckoutCreator creates a new instance of MockCheckoutCreator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCheckoutCreator(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCheckoutCreator {
+	mock := &MockCheckoutCreator{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
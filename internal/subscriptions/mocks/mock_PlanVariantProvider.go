@@ -0,0 +1,83 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	entitlements "github.com/grantsy/grantsy/internal/entitlements"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockPlanVariantProvider is an autogenerated mock type for the PlanVariantProvider type
+type MockPlanVariantProvider struct {
+	mock.Mock
+}
+
+type MockPlanVariantProvider_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockPlanVariantProvider) EXPECT() *MockPlanVariantProvider_Expecter {
+	return &MockPlanVariantProvider_Expecter{mock: &_m.Mock}
+}
+
+// GetPlanVariants provides a mock function with given fields: planID
+func (_m *MockPlanVariantProvider) GetPlanVariants(planID string) []entitlements.Variant {
+	ret := _m.Called(planID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPlanVariants")
+	}
+
+	var r0 []entitlements.Variant
+	if rf, ok := ret.Get(0).(func(string) []entitlements.Variant); ok {
+		r0 = rf(planID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entitlements.Variant)
+		}
+	}
+
+	return r0
+}
+
+// MockPlanVariantProvider_GetPlanVariants_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPlanVariants'
+type MockPlanVariantProvider_GetPlanVariants_Call struct {
+	*mock.Call
+}
+
+// GetPlanVariants is a helper method to define mock.On call
+//   - planID string
+func (_e *MockPlanVariantProvider_Expecter) GetPlanVariants(planID interface{}) *MockPlanVariantProvider_GetPlanVariants_Call {
+	return &MockPlanVariantProvider_GetPlanVariants_Call{Call: _e.mock.On("GetPlanVariants", planID)}
+}
+
+func (_c *MockPlanVariantProvider_GetPlanVariants_Call) Run(run func(planID string)) *MockPlanVariantProvider_GetPlanVariants_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockPlanVariantProvider_GetPlanVariants_Call) Return(_a0 []entitlements.Variant) *MockPlanVariantProvider_GetPlanVariants_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPlanVariantProvider_GetPlanVariants_Call) RunAndReturn(run func(string) []entitlements.Variant) *MockPlanVariantProvider_GetPlanVariants_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockPlanVariantProvider creates a new instance of MockPlanVariantProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockPlanVariantProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockPlanVariantProvider {
+	mock := &MockPlanVariantProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
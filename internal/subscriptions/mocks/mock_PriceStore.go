@@ -0,0 +1,143 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	subscriptions "github.com/grantsy/grantsy/internal/subscriptions"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockPriceStore is an autogenerated mock type for the PriceStore type
+type MockPriceStore struct {
+	mock.Mock
+}
+
+type MockPriceStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockPriceStore) EXPECT() *MockPriceStore_Expecter {
+	return &MockPriceStore_Expecter{mock: &_m.Mock}
+}
+
+// GetCachedPrice provides a mock function with given fields: ctx, priceID
+func (_m *MockPriceStore) GetCachedPrice(ctx context.Context, priceID int) (*subscriptions.Price, error) {
+	ret := _m.Called(ctx, priceID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCachedPrice")
+	}
+
+	var r0 *subscriptions.Price
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*subscriptions.Price, error)); ok {
+		return rf(ctx, priceID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *subscriptions.Price); ok {
+		r0 = rf(ctx, priceID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*subscriptions.Price)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, priceID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPriceStore_GetCachedPrice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCachedPrice'
+type MockPriceStore_GetCachedPrice_Call struct {
+	*mock.Call
+}
+
+// GetCachedPrice is a helper method to define mock.On call
+//   - ctx context.Context
+//   - priceID int
+func (_e *MockPriceStore_Expecter) GetCachedPrice(ctx interface{}, priceID interface{}) *MockPriceStore_GetCachedPrice_Call {
+	return &MockPriceStore_GetCachedPrice_Call{Call: _e.mock.On("GetCachedPrice", ctx, priceID)}
+}
+
+func (_c *MockPriceStore_GetCachedPrice_Call) Run(run func(ctx context.Context, priceID int)) *MockPriceStore_GetCachedPrice_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockPriceStore_GetCachedPrice_Call) Return(_a0 *subscriptions.Price, _a1 error) *MockPriceStore_GetCachedPrice_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPriceStore_GetCachedPrice_Call) RunAndReturn(run func(context.Context, int) (*subscriptions.Price, error)) *MockPriceStore_GetCachedPrice_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpsertPrice provides a mock function with given fields: ctx, p
+func (_m *MockPriceStore) UpsertPrice(ctx context.Context, p *subscriptions.Price) error {
+	ret := _m.Called(ctx, p)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertPrice")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *subscriptions.Price) error); ok {
+		r0 = rf(ctx, p)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockPriceStore_UpsertPrice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertPrice'
+type MockPriceStore_UpsertPrice_Call struct {
+	*mock.Call
+}
+
+// UpsertPrice is a helper method to define mock.On call
+//   - ctx context.Context
+//   - p *subscriptions.Price
+func (_e *MockPriceStore_Expecter) UpsertPrice(ctx interface{}, p interface{}) *MockPriceStore_UpsertPrice_Call {
+	return &MockPriceStore_UpsertPrice_Call{Call: _e.mock.On("UpsertPrice", ctx, p)}
+}
+
+func (_c *MockPriceStore_UpsertPrice_Call) Run(run func(ctx context.Context, p *subscriptions.Price)) *MockPriceStore_UpsertPrice_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*subscriptions.Price))
+	})
+	return _c
+}
+
+func (_c *MockPriceStore_UpsertPrice_Call) Return(_a0 error) *MockPriceStore_UpsertPrice_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPriceStore_UpsertPrice_Call) RunAndReturn(run func(context.Context, *subscriptions.Price) error) *MockPriceStore_UpsertPrice_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockPriceStore creates a new instance of MockPriceStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockPriceStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockPriceStore {
+	mock := &MockPriceStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
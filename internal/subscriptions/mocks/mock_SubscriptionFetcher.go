@@ -0,0 +1,96 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	subscriptions "github.com/grantsy/grantsy/internal/subscriptions"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockSubscriptionFetcher is an autogenerated mock type for the SubscriptionFetcher type
+type MockSubscriptionFetcher struct {
+	mock.Mock
+}
+
+type MockSubscriptionFetcher_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSubscriptionFetcher) EXPECT() *MockSubscriptionFetcher_Expecter {
+	return &MockSubscriptionFetcher_Expecter{mock: &_m.Mock}
+}
+
+// GetSubscription provides a mock function with given fields: ctx, subscriptionID
+func (_m *MockSubscriptionFetcher) GetSubscription(ctx context.Context, subscriptionID int) (*subscriptions.Subscription, error) {
+	ret := _m.Called(ctx, subscriptionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSubscription")
+	}
+
+	var r0 *subscriptions.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*subscriptions.Subscription, error)); ok {
+		return rf(ctx, subscriptionID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *subscriptions.Subscription); ok {
+		r0 = rf(ctx, subscriptionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*subscriptions.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, subscriptionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionFetcher_GetSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSubscription'
+type MockSubscriptionFetcher_GetSubscription_Call struct {
+	*mock.Call
+}
+
+// GetSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subscriptionID int
+func (_e *MockSubscriptionFetcher_Expecter) GetSubscription(ctx interface{}, subscriptionID interface{}) *MockSubscriptionFetcher_GetSubscription_Call {
+	return &MockSubscriptionFetcher_GetSubscription_Call{Call: _e.mock.On("GetSubscription", ctx, subscriptionID)}
+}
+
+func (_c *MockSubscriptionFetcher_GetSubscription_Call) Run(run func(ctx context.Context, subscriptionID int)) *MockSubscriptionFetcher_GetSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionFetcher_GetSubscription_Call) Return(_a0 *subscriptions.Subscription, _a1 error) *MockSubscriptionFetcher_GetSubscription_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionFetcher_GetSubscription_Call) RunAndReturn(run func(context.Context, int) (*subscriptions.Subscription, error)) *MockSubscriptionFetcher_GetSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSubscriptionFetcher creates a new instance of MockSubscriptionFetcher. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSubscriptionFetcher(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSubscriptionFetcher {
+	mock := &MockSubscriptionFetcher{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -21,17 +21,67 @@ func (_m *MockSubscriptionObserver) EXPECT() *MockSubscriptionObserver_Expecter
 	return &MockSubscriptionObserver_Expecter{mock: &_m.Mock}
 }
 
-// OnSubscriptionChange provides a mock function with given fields: ctx, userID, productID, active, subscription
-func (_m *MockSubscriptionObserver) OnSubscriptionChange(ctx context.Context, userID string, productID int, active bool, subscription interface{}) error {
-	ret := _m.Called(ctx, userID, productID, active, subscription)
+// OnPayment provides a mock function with given fields: ctx, userID, outcome, subscription, payment
+func (_m *MockSubscriptionObserver) OnPayment(ctx context.Context, userID string, outcome string, subscription interface{}, payment interface{}) error {
+	ret := _m.Called(ctx, userID, outcome, subscription, payment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OnPayment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, interface{}, interface{}) error); ok {
+		r0 = rf(ctx, userID, outcome, subscription, payment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSubscriptionObserver_OnPayment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OnPayment'
+type MockSubscriptionObserver_OnPayment_Call struct {
+	*mock.Call
+}
+
+// OnPayment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - outcome string
+//   - subscription interface{}
+//   - payment interface{}
+func (_e *MockSubscriptionObserver_Expecter) OnPayment(ctx interface{}, userID interface{}, outcome interface{}, subscription interface{}, payment interface{}) *MockSubscriptionObserver_OnPayment_Call {
+	return &MockSubscriptionObserver_OnPayment_Call{Call: _e.mock.On("OnPayment", ctx, userID, outcome, subscription, payment)}
+}
+
+func (_c *MockSubscriptionObserver_OnPayment_Call) Run(run func(ctx context.Context, userID string, outcome string, subscription interface{}, payment interface{})) *MockSubscriptionObserver_OnPayment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(interface{}), args[4].(interface{}))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionObserver_OnPayment_Call) Return(_a0 error) *MockSubscriptionObserver_OnPayment_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSubscriptionObserver_OnPayment_Call) RunAndReturn(run func(context.Context, string, string, interface{}, interface{}) error) *MockSubscriptionObserver_OnPayment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// OnSubscriptionChange provides a mock function with given fields: ctx, userID, productID, active, cause, subscription
+func (_m *MockSubscriptionObserver) OnSubscriptionChange(ctx context.Context, userID string, productID int, active bool, cause string, subscription interface{}) error {
+	ret := _m.Called(ctx, userID, productID, active, cause, subscription)
 
 	if len(ret) == 0 {
 		panic("no return value specified for OnSubscriptionChange")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, int, bool, interface{}) error); ok {
-		r0 = rf(ctx, userID, productID, active, subscription)
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, bool, string, interface{}) error); ok {
+		r0 = rf(ctx, userID, productID, active, cause, subscription)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -49,14 +99,15 @@ type MockSubscriptionObserver_OnSubscriptionChange_Call struct {
 //   - userID string
 //   - productID int
 //   - active bool
+//   - cause string
 //   - subscription interface{}
-func (_e *MockSubscriptionObserver_Expecter) OnSubscriptionChange(ctx interface{}, userID interface{}, productID interface{}, active interface{}, subscription interface{}) *MockSubscriptionObserver_OnSubscriptionChange_Call {
-	return &MockSubscriptionObserver_OnSubscriptionChange_Call{Call: _e.mock.On("OnSubscriptionChange", ctx, userID, productID, active, subscription)}
+func (_e *MockSubscriptionObserver_Expecter) OnSubscriptionChange(ctx interface{}, userID interface{}, productID interface{}, active interface{}, cause interface{}, subscription interface{}) *MockSubscriptionObserver_OnSubscriptionChange_Call {
+	return &MockSubscriptionObserver_OnSubscriptionChange_Call{Call: _e.mock.On("OnSubscriptionChange", ctx, userID, productID, active, cause, subscription)}
 }
 
-func (_c *MockSubscriptionObserver_OnSubscriptionChange_Call) Run(run func(ctx context.Context, userID string, productID int, active bool, subscription interface{})) *MockSubscriptionObserver_OnSubscriptionChange_Call {
+func (_c *MockSubscriptionObserver_OnSubscriptionChange_Call) Run(run func(ctx context.Context, userID string, productID int, active bool, cause string, subscription interface{})) *MockSubscriptionObserver_OnSubscriptionChange_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(int), args[3].(bool), args[4].(interface{}))
+		run(args[0].(context.Context), args[1].(string), args[2].(int), args[3].(bool), args[4].(string), args[5].(interface{}))
 	})
 	return _c
 }
@@ -66,7 +117,7 @@ func (_c *MockSubscriptionObserver_OnSubscriptionChange_Call) Return(_a0 error)
 	return _c
 }
 
-func (_c *MockSubscriptionObserver_OnSubscriptionChange_Call) RunAndReturn(run func(context.Context, string, int, bool, interface{}) error) *MockSubscriptionObserver_OnSubscriptionChange_Call {
+func (_c *MockSubscriptionObserver_OnSubscriptionChange_Call) RunAndReturn(run func(context.Context, string, int, bool, string, interface{}) error) *MockSubscriptionObserver_OnSubscriptionChange_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -22,6 +22,53 @@ func (_m *MockSubscriptionWriter) EXPECT() *MockSubscriptionWriter_Expecter {
 	return &MockSubscriptionWriter_Expecter{mock: &_m.Mock}
 }
 
+// InsertPayment provides a mock function with given fields: ctx, p
+func (_m *MockSubscriptionWriter) InsertPayment(ctx context.Context, p *subscriptions.Payment) error {
+	ret := _m.Called(ctx, p)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertPayment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *subscriptions.Payment) error); ok {
+		r0 = rf(ctx, p)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSubscriptionWriter_InsertPayment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertPayment'
+type MockSubscriptionWriter_InsertPayment_Call struct {
+	*mock.Call
+}
+
+// InsertPayment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - p *subscriptions.Payment
+func (_e *MockSubscriptionWriter_Expecter) InsertPayment(ctx interface{}, p interface{}) *MockSubscriptionWriter_InsertPayment_Call {
+	return &MockSubscriptionWriter_InsertPayment_Call{Call: _e.mock.On("InsertPayment", ctx, p)}
+}
+
+func (_c *MockSubscriptionWriter_InsertPayment_Call) Run(run func(ctx context.Context, p *subscriptions.Payment)) *MockSubscriptionWriter_InsertPayment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*subscriptions.Payment))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionWriter_InsertPayment_Call) Return(_a0 error) *MockSubscriptionWriter_InsertPayment_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSubscriptionWriter_InsertPayment_Call) RunAndReturn(run func(context.Context, *subscriptions.Payment) error) *MockSubscriptionWriter_InsertPayment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpsertSubscription provides a mock function with given fields: ctx, sub
 func (_m *MockSubscriptionWriter) UpsertSubscription(ctx context.Context, sub *subscriptions.Subscription) error {
 	ret := _m.Called(ctx, sub)
@@ -0,0 +1,141 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockWebhookDeduplicator is an autogenerated mock type for the WebhookDeduplicator type
+type MockWebhookDeduplicator struct {
+	mock.Mock
+}
+
+type MockWebhookDeduplicator_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockWebhookDeduplicator) EXPECT() *MockWebhookDeduplicator_Expecter {
+	return &MockWebhookDeduplicator_Expecter{mock: &_m.Mock}
+}
+
+// IsWebhookProcessed provides a mock function with given fields: ctx, hash
+func (_m *MockWebhookDeduplicator) IsWebhookProcessed(ctx context.Context, hash string) (bool, error) {
+	ret := _m.Called(ctx, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsWebhookProcessed")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, hash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookDeduplicator_IsWebhookProcessed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsWebhookProcessed'
+type MockWebhookDeduplicator_IsWebhookProcessed_Call struct {
+	*mock.Call
+}
+
+// IsWebhookProcessed is a helper method to define mock.On call
+//   - ctx context.Context
+//   - hash string
+func (_e *MockWebhookDeduplicator_Expecter) IsWebhookProcessed(ctx interface{}, hash interface{}) *MockWebhookDeduplicator_IsWebhookProcessed_Call {
+	return &MockWebhookDeduplicator_IsWebhookProcessed_Call{Call: _e.mock.On("IsWebhookProcessed", ctx, hash)}
+}
+
+func (_c *MockWebhookDeduplicator_IsWebhookProcessed_Call) Run(run func(ctx context.Context, hash string)) *MockWebhookDeduplicator_IsWebhookProcessed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockWebhookDeduplicator_IsWebhookProcessed_Call) Return(_a0 bool, _a1 error) *MockWebhookDeduplicator_IsWebhookProcessed_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookDeduplicator_IsWebhookProcessed_Call) RunAndReturn(run func(context.Context, string) (bool, error)) *MockWebhookDeduplicator_IsWebhookProcessed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkWebhookProcessed provides a mock function with given fields: ctx, hash, eventName
+func (_m *MockWebhookDeduplicator) MarkWebhookProcessed(ctx context.Context, hash string, eventName string) error {
+	ret := _m.Called(ctx, hash, eventName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkWebhookProcessed")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, hash, eventName)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWebhookDeduplicator_MarkWebhookProcessed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkWebhookProcessed'
+type MockWebhookDeduplicator_MarkWebhookProcessed_Call struct {
+	*mock.Call
+}
+
+// MarkWebhookProcessed is a helper method to define mock.On call
+//   - ctx context.Context
+//   - hash string
+//   - eventName string
+func (_e *MockWebhookDeduplicator_Expecter) MarkWebhookProcessed(ctx interface{}, hash interface{}, eventName interface{}) *MockWebhookDeduplicator_MarkWebhookProcessed_Call {
+	return &MockWebhookDeduplicator_MarkWebhookProcessed_Call{Call: _e.mock.On("MarkWebhookProcessed", ctx, hash, eventName)}
+}
+
+func (_c *MockWebhookDeduplicator_MarkWebhookProcessed_Call) Run(run func(ctx context.Context, hash string, eventName string)) *MockWebhookDeduplicator_MarkWebhookProcessed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockWebhookDeduplicator_MarkWebhookProcessed_Call) Return(_a0 error) *MockWebhookDeduplicator_MarkWebhookProcessed_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWebhookDeduplicator_MarkWebhookProcessed_Call) RunAndReturn(run func(context.Context, string, string) error) *MockWebhookDeduplicator_MarkWebhookProcessed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockWebhookDeduplicator creates a new instance of MockWebhookDeduplicator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockWebhookDeduplicator(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockWebhookDeduplicator {
+	mock := &MockWebhookDeduplicator{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,83 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockWebhookEnqueuer is an autogenerated mock type for the WebhookEnqueuer type
+type MockWebhookEnqueuer struct {
+	mock.Mock
+}
+
+type MockWebhookEnqueuer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockWebhookEnqueuer) EXPECT() *MockWebhookEnqueuer_Expecter {
+	return &MockWebhookEnqueuer_Expecter{mock: &_m.Mock}
+}
+
+// EnqueueWebhookEvent provides a mock function with given fields: ctx, eventID
+func (_m *MockWebhookEnqueuer) EnqueueWebhookEvent(ctx context.Context, eventID string) error {
+	ret := _m.Called(ctx, eventID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnqueueWebhookEvent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, eventID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWebhookEnqueuer_EnqueueWebhookEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnqueueWebhookEvent'
+type MockWebhookEnqueuer_EnqueueWebhookEvent_Call struct {
+	*mock.Call
+}
+
+// EnqueueWebhookEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - eventID string
+func (_e *MockWebhookEnqueuer_Expecter) EnqueueWebhookEvent(ctx interface{}, eventID interface{}) *MockWebhookEnqueuer_EnqueueWebhookEvent_Call {
+	return &MockWebhookEnqueuer_EnqueueWebhookEvent_Call{Call: _e.mock.On("EnqueueWebhookEvent", ctx, eventID)}
+}
+
+func (_c *MockWebhookEnqueuer_EnqueueWebhookEvent_Call) Run(run func(ctx context.Context, eventID string)) *MockWebhookEnqueuer_EnqueueWebhookEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockWebhookEnqueuer_EnqueueWebhookEvent_Call) Return(_a0 error) *MockWebhookEnqueuer_EnqueueWebhookEvent_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWebhookEnqueuer_EnqueueWebhookEvent_Call) RunAndReturn(run func(context.Context, string) error) *MockWebhookEnqueuer_EnqueueWebhookEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockWebhookEnqueuer creates a new instance of MockWebhookEnqueuer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockWebhookEnqueuer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockWebhookEnqueuer {
+	mock := &MockWebhookEnqueuer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,155 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	subscriptions "github.com/grantsy/grantsy/internal/subscriptions"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockWebhookEventReader is an autogenerated mock type for the WebhookEventReader type
+type MockWebhookEventReader struct {
+	mock.Mock
+}
+
+type MockWebhookEventReader_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockWebhookEventReader) EXPECT() *MockWebhookEventReader_Expecter {
+	return &MockWebhookEventReader_Expecter{mock: &_m.Mock}
+}
+
+// GetWebhookEvent provides a mock function with given fields: ctx, id
+func (_m *MockWebhookEventReader) GetWebhookEvent(ctx context.Context, id string) (*subscriptions.WebhookEvent, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWebhookEvent")
+	}
+
+	var r0 *subscriptions.WebhookEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*subscriptions.WebhookEvent, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *subscriptions.WebhookEvent); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*subscriptions.WebhookEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookEventReader_GetWebhookEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWebhookEvent'
+type MockWebhookEventReader_GetWebhookEvent_Call struct {
+	*mock.Call
+}
+
+// GetWebhookEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockWebhookEventReader_Expecter) GetWebhookEvent(ctx interface{}, id interface{}) *MockWebhookEventReader_GetWebhookEvent_Call {
+	return &MockWebhookEventReader_GetWebhookEvent_Call{Call: _e.mock.On("GetWebhookEvent", ctx, id)}
+}
+
+func (_c *MockWebhookEventReader_GetWebhookEvent_Call) Run(run func(ctx context.Context, id string)) *MockWebhookEventReader_GetWebhookEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockWebhookEventReader_GetWebhookEvent_Call) Return(_a0 *subscriptions.WebhookEvent, _a1 error) *MockWebhookEventReader_GetWebhookEvent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookEventReader_GetWebhookEvent_Call) RunAndReturn(run func(context.Context, string) (*subscriptions.WebhookEvent, error)) *MockWebhookEventReader_GetWebhookEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListWebhookEvents provides a mock function with given fields: ctx, filter
+func (_m *MockWebhookEventReader) ListWebhookEvents(ctx context.Context, filter subscriptions.WebhookEventFilter) ([]subscriptions.WebhookEvent, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWebhookEvents")
+	}
+
+	var r0 []subscriptions.WebhookEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, subscriptions.WebhookEventFilter) ([]subscriptions.WebhookEvent, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, subscriptions.WebhookEventFilter) []subscriptions.WebhookEvent); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]subscriptions.WebhookEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, subscriptions.WebhookEventFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookEventReader_ListWebhookEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListWebhookEvents'
+type MockWebhookEventReader_ListWebhookEvents_Call struct {
+	*mock.Call
+}
+
+// ListWebhookEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter subscriptions.WebhookEventFilter
+func (_e *MockWebhookEventReader_Expecter) ListWebhookEvents(ctx interface{}, filter interface{}) *MockWebhookEventReader_ListWebhookEvents_Call {
+	return &MockWebhookEventReader_ListWebhookEvents_Call{Call: _e.mock.On("ListWebhookEvents", ctx, filter)}
+}
+
+func (_c *MockWebhookEventReader_ListWebhookEvents_Call) Run(run func(ctx context.Context, filter subscriptions.WebhookEventFilter)) *MockWebhookEventReader_ListWebhookEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(subscriptions.WebhookEventFilter))
+	})
+	return _c
+}
+
+func (_c *MockWebhookEventReader_ListWebhookEvents_Call) Return(_a0 []subscriptions.WebhookEvent, _a1 error) *MockWebhookEventReader_ListWebhookEvents_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookEventReader_ListWebhookEvents_Call) RunAndReturn(run func(context.Context, subscriptions.WebhookEventFilter) ([]subscriptions.WebhookEvent, error)) *MockWebhookEventReader_ListWebhookEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockWebhookEventReader creates a new instance of MockWebhookEventReader. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockWebhookEventReader(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockWebhookEventReader {
+	mock := &MockWebhookEventReader{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
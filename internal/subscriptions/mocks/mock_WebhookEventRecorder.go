@@ -0,0 +1,190 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	subscriptions "github.com/grantsy/grantsy/internal/subscriptions"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockWebhookEventRecorder is an autogenerated mock type for the WebhookEventRecorder type
+type MockWebhookEventRecorder struct {
+	mock.Mock
+}
+
+type MockWebhookEventRecorder_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockWebhookEventRecorder) EXPECT() *MockWebhookEventRecorder_Expecter {
+	return &MockWebhookEventRecorder_Expecter{mock: &_m.Mock}
+}
+
+// GetWebhookEvent provides a mock function with given fields: ctx, id
+func (_m *MockWebhookEventRecorder) GetWebhookEvent(ctx context.Context, id string) (*subscriptions.WebhookEvent, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWebhookEvent")
+	}
+
+	var r0 *subscriptions.WebhookEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*subscriptions.WebhookEvent, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *subscriptions.WebhookEvent); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*subscriptions.WebhookEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookEventRecorder_GetWebhookEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWebhookEvent'
+type MockWebhookEventRecorder_GetWebhookEvent_Call struct {
+	*mock.Call
+}
+
+// GetWebhookEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockWebhookEventRecorder_Expecter) GetWebhookEvent(ctx interface{}, id interface{}) *MockWebhookEventRecorder_GetWebhookEvent_Call {
+	return &MockWebhookEventRecorder_GetWebhookEvent_Call{Call: _e.mock.On("GetWebhookEvent", ctx, id)}
+}
+
+func (_c *MockWebhookEventRecorder_GetWebhookEvent_Call) Run(run func(ctx context.Context, id string)) *MockWebhookEventRecorder_GetWebhookEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockWebhookEventRecorder_GetWebhookEvent_Call) Return(_a0 *subscriptions.WebhookEvent, _a1 error) *MockWebhookEventRecorder_GetWebhookEvent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookEventRecorder_GetWebhookEvent_Call) RunAndReturn(run func(context.Context, string) (*subscriptions.WebhookEvent, error)) *MockWebhookEventRecorder_GetWebhookEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertWebhookEvent provides a mock function with given fields: ctx, e
+func (_m *MockWebhookEventRecorder) InsertWebhookEvent(ctx context.Context, e *subscriptions.WebhookEvent) error {
+	ret := _m.Called(ctx, e)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertWebhookEvent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *subscriptions.WebhookEvent) error); ok {
+		r0 = rf(ctx, e)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWebhookEventRecorder_InsertWebhookEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertWebhookEvent'
+type MockWebhookEventRecorder_InsertWebhookEvent_Call struct {
+	*mock.Call
+}
+
+// InsertWebhookEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - e *subscriptions.WebhookEvent
+func (_e *MockWebhookEventRecorder_Expecter) InsertWebhookEvent(ctx interface{}, e interface{}) *MockWebhookEventRecorder_InsertWebhookEvent_Call {
+	return &MockWebhookEventRecorder_InsertWebhookEvent_Call{Call: _e.mock.On("InsertWebhookEvent", ctx, e)}
+}
+
+func (_c *MockWebhookEventRecorder_InsertWebhookEvent_Call) Run(run func(ctx context.Context, e *subscriptions.WebhookEvent)) *MockWebhookEventRecorder_InsertWebhookEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*subscriptions.WebhookEvent))
+	})
+	return _c
+}
+
+func (_c *MockWebhookEventRecorder_InsertWebhookEvent_Call) Return(_a0 error) *MockWebhookEventRecorder_InsertWebhookEvent_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWebhookEventRecorder_InsertWebhookEvent_Call) RunAndReturn(run func(context.Context, *subscriptions.WebhookEvent) error) *MockWebhookEventRecorder_InsertWebhookEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateWebhookEventResult provides a mock function with given fields: ctx, e
+func (_m *MockWebhookEventRecorder) UpdateWebhookEventResult(ctx context.Context, e *subscriptions.WebhookEvent) error {
+	ret := _m.Called(ctx, e)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateWebhookEventResult")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *subscriptions.WebhookEvent) error); ok {
+		r0 = rf(ctx, e)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWebhookEventRecorder_UpdateWebhookEventResult_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateWebhookEventResult'
+type MockWebhookEventRecorder_UpdateWebhookEventResult_Call struct {
+	*mock.Call
+}
+
+// UpdateWebhookEventResult is a helper method to define mock.On call
+//   - ctx context.Context
+//   - e *subscriptions.WebhookEvent
+func (_e *MockWebhookEventRecorder_Expecter) UpdateWebhookEventResult(ctx interface{}, e interface{}) *MockWebhookEventRecorder_UpdateWebhookEventResult_Call {
+	return &MockWebhookEventRecorder_UpdateWebhookEventResult_Call{Call: _e.mock.On("UpdateWebhookEventResult", ctx, e)}
+}
+
+func (_c *MockWebhookEventRecorder_UpdateWebhookEventResult_Call) Run(run func(ctx context.Context, e *subscriptions.WebhookEvent)) *MockWebhookEventRecorder_UpdateWebhookEventResult_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*subscriptions.WebhookEvent))
+	})
+	return _c
+}
+
+func (_c *MockWebhookEventRecorder_UpdateWebhookEventResult_Call) Return(_a0 error) *MockWebhookEventRecorder_UpdateWebhookEventResult_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWebhookEventRecorder_UpdateWebhookEventResult_Call) RunAndReturn(run func(context.Context, *subscriptions.WebhookEvent) error) *MockWebhookEventRecorder_UpdateWebhookEventResult_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockWebhookEventRecorder creates a new instance of MockWebhookEventRecorder. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockWebhookEventRecorder(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockWebhookEventRecorder {
+	mock := &MockWebhookEventRecorder{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,71 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	subscriptions "github.com/grantsy/grantsy/internal/subscriptions"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockWebhookReplayer is an autogenerated mock type for the WebhookReplayer type
+type MockWebhookReplayer struct {
+	mock.Mock
+}
+
+type MockWebhookReplayer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockWebhookReplayer) EXPECT() *MockWebhookReplayer_Expecter {
+	return &MockWebhookReplayer_Expecter{mock: &_m.Mock}
+}
+
+// Replay provides a mock function with given fields: ctx, event
+func (_m *MockWebhookReplayer) Replay(ctx context.Context, event *subscriptions.WebhookEvent) {
+	_m.Called(ctx, event)
+}
+
+// MockWebhookReplayer_Replay_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Replay'
+type MockWebhookReplayer_Replay_Call struct {
+	*mock.Call
+}
+
+// Replay is a helper method to define mock.On call
+//   - ctx context.Context
+//   - event *subscriptions.WebhookEvent
+func (_e *MockWebhookReplayer_Expecter) Replay(ctx interface{}, event interface{}) *MockWebhookReplayer_Replay_Call {
+	return &MockWebhookReplayer_Replay_Call{Call: _e.mock.On("Replay", ctx, event)}
+}
+
+func (_c *MockWebhookReplayer_Replay_Call) Run(run func(ctx context.Context, event *subscriptions.WebhookEvent)) *MockWebhookReplayer_Replay_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*subscriptions.WebhookEvent))
+	})
+	return _c
+}
+
+func (_c *MockWebhookReplayer_Replay_Call) Return() *MockWebhookReplayer_Replay_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockWebhookReplayer_Replay_Call) RunAndReturn(run func(context.Context, *subscriptions.WebhookEvent)) *MockWebhookReplayer_Replay_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewMockWebhookReplayer creates a new instance of MockWebhookReplayer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockWebhookReplayer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockWebhookReplayer {
+	mock := &MockWebhookReplayer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
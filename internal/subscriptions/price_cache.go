@@ -0,0 +1,58 @@
+package subscriptions
+
+import (
+	"context"
+	"time"
+
+	"github.com/grantsy/grantsy/internal/infra/logger"
+)
+
+// priceCacheTTL is how long a cached price is used before it is fetched again.
+// Older entries are still used when the provider API is unavailable.
+const priceCacheTTL = 24 * time.Hour
+
+// CachedPriceFetcher serves prices from the price store and refreshes them
+// from the provider, falling back to stale entries when the provider fails.
+type CachedPriceFetcher struct {
+	fetcher PriceFetcher
+	store   PriceStore
+}
+
+func NewCachedPriceFetcher(fetcher PriceFetcher, store PriceStore) *CachedPriceFetcher {
+	return &CachedPriceFetcher{fetcher: fetcher, store: store}
+}
+
+// GetPrice returns price data for the given price ID.
+func (c *CachedPriceFetcher) GetPrice(ctx context.Context, priceID int) (*PriceInfo, error) {
+	log := logger.FromContext(ctx).With("price_id", priceID)
+
+	cached, err := c.store.GetCachedPrice(ctx, priceID)
+	if err != nil {
+		// A broken cache must not block enrichment, go to the provider.
+		log.Error("failed to read cached price", "error", err)
+		cached = nil
+	}
+	if cached != nil && time.Since(time.Unix(cached.UpdatedAt, 0)) < priceCacheTTL {
+		return &cached.PriceInfo, nil
+	}
+
+	price, err := c.fetcher.GetPrice(ctx, priceID)
+	if err != nil {
+		if cached != nil {
+			log.Warn("using cached price, provider unavailable", "error", err)
+			return &cached.PriceInfo, nil
+		}
+		return nil, err
+	}
+
+	err = c.store.UpsertPrice(ctx, &Price{
+		PriceID:   priceID,
+		PriceInfo: *price,
+		UpdatedAt: time.Now().Unix(),
+	})
+	if err != nil {
+		log.Error("failed to cache price", "error", err)
+	}
+
+	return price, nil
+}
@@ -0,0 +1,81 @@
+package subscriptions_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grantsy/grantsy/internal/subscriptions"
+	"github.com/grantsy/grantsy/internal/subscriptions/mocks"
+)
+
+func cachedPrice(unitPrice int, age time.Duration) *subscriptions.Price {
+	return &subscriptions.Price{
+		PriceID:   555,
+		PriceInfo: subscriptions.PriceInfo{VariantID: 7, UnitPrice: unitPrice},
+		UpdatedAt: time.Now().Add(-age).Unix(),
+	}
+}
+
+func TestCachedPriceFetcher_FreshCache(t *testing.T) {
+	store := mocks.NewMockPriceStore(t)
+	store.EXPECT().GetCachedPrice(mock.Anything, 555).Return(cachedPrice(999, time.Hour), nil)
+	fetcher := mocks.NewMockPriceFetcher(t)
+
+	price, err := subscriptions.NewCachedPriceFetcher(fetcher, store).GetPrice(context.Background(), 555)
+	require.NoError(t, err)
+	assert.Equal(t, 999, price.UnitPrice)
+}
+
+func TestCachedPriceFetcher_MissFetchesAndStores(t *testing.T) {
+	store := mocks.NewMockPriceStore(t)
+	store.EXPECT().GetCachedPrice(mock.Anything, 555).Return(nil, nil)
+	store.EXPECT().
+		UpsertPrice(mock.Anything, mock.MatchedBy(func(p *subscriptions.Price) bool {
+			return p.PriceID == 555 && p.UnitPrice == 1999 && p.UpdatedAt > 0
+		})).
+		Return(nil)
+	fetcher := mocks.NewMockPriceFetcher(t)
+	fetcher.EXPECT().GetPrice(mock.Anything, 555).Return(&subscriptions.PriceInfo{UnitPrice: 1999}, nil)
+
+	price, err := subscriptions.NewCachedPriceFetcher(fetcher, store).GetPrice(context.Background(), 555)
+	require.NoError(t, err)
+	assert.Equal(t, 1999, price.UnitPrice)
+}
+
+func TestCachedPriceFetcher_StaleRefreshed(t *testing.T) {
+	store := mocks.NewMockPriceStore(t)
+	store.EXPECT().GetCachedPrice(mock.Anything, 555).Return(cachedPrice(999, 48*time.Hour), nil)
+	store.EXPECT().UpsertPrice(mock.Anything, mock.Anything).Return(nil)
+	fetcher := mocks.NewMockPriceFetcher(t)
+	fetcher.EXPECT().GetPrice(mock.Anything, 555).Return(&subscriptions.PriceInfo{UnitPrice: 1999}, nil)
+
+	price, err := subscriptions.NewCachedPriceFetcher(fetcher, store).GetPrice(context.Background(), 555)
+	require.NoError(t, err)
+	assert.Equal(t, 1999, price.UnitPrice)
+}
+
+func TestCachedPriceFetcher_ProviderDownUsesStale(t *testing.T) {
+	store := mocks.NewMockPriceStore(t)
+	store.EXPECT().GetCachedPrice(mock.Anything, 555).Return(cachedPrice(999, 48*time.Hour), nil)
+	fetcher := mocks.NewMockPriceFetcher(t)
+	fetcher.EXPECT().GetPrice(mock.Anything, 555).Return(nil, assert.AnError)
+
+	price, err := subscriptions.NewCachedPriceFetcher(fetcher, store).GetPrice(context.Background(), 555)
+	require.NoError(t, err)
+	assert.Equal(t, 999, price.UnitPrice)
+}
+
+func TestCachedPriceFetcher_ProviderDownNoCache(t *testing.T) {
+	store := mocks.NewMockPriceStore(t)
+	store.EXPECT().GetCachedPrice(mock.Anything, 555).Return(nil, assert.AnError)
+	fetcher := mocks.NewMockPriceFetcher(t)
+	fetcher.EXPECT().GetPrice(mock.Anything, 555).Return(nil, assert.AnError)
+
+	_, err := subscriptions.NewCachedPriceFetcher(fetcher, store).GetPrice(context.Background(), 555)
+	assert.ErrorIs(t, err, assert.AnError)
+}
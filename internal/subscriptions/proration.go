@@ -0,0 +1,77 @@
+package subscriptions
+
+import (
+	"time"
+
+	"github.com/grantsy/grantsy/internal/entitlements"
+)
+
+// ProrationQuote estimates what a variant change costs, in cents. It is
+// calculated locally from cached prices, not by the provider, so the
+// provider's invoice may differ, e.g. by taxes, discounts or rounding.
+type ProrationQuote struct {
+	VariantID    int    `json:"variant_id"     description:"Variant the quote was calculated for"                                                                     required:"true"`
+	Credit       int    `json:"credit"         description:"Estimated credit for unused time on the current price, in cents"                                          required:"true"`
+	Charge       int    `json:"charge"         description:"Estimated charge for the new price until the next renewal, in cents"                                      required:"true"`
+	AmountDue    int    `json:"amount_due"     description:"Estimated charge minus credit, in cents; negative values are a credit. The provider's invoice may differ" required:"true"`
+	Interval     string `json:"interval"       description:"Billing interval of the new variant"                                                                      required:"true"`
+	NextRenewsAt int64  `json:"next_renews_at" description:"Unix timestamp of the next renewal after the change"                                                      required:"true"`
+}
+
+// QuoteProration estimates the proration LemonSqueezy applies when sub moves
+// to the given variant at now; the provider calculates the amount it charges
+// itself. Unused time on the current price is credited.
+// Within the same billing interval the rest of the period is charged at the new
+// price; a different interval starts a new period charged in full. Trials are
+// not charged.
+func QuoteProration(sub *Subscription, to entitlements.Variant, now time.Time) *ProrationQuote {
+	quote := &ProrationQuote{
+		VariantID:    to.ID,
+		Interval:     to.Interval,
+		NextRenewsAt: sub.RenewsAt,
+	}
+	if sub.Status == "on_trial" {
+		return quote
+	}
+
+	renewsAt := time.Unix(sub.RenewsAt, 0)
+	periodStart := addInterval(renewsAt, sub.RenewalIntervalUnit, -sub.RenewalIntervalQuantity)
+	period := renewsAt.Sub(periodStart)
+	remaining := min(max(renewsAt.Sub(now), 0), period)
+
+	if period > 0 {
+		quote.Credit = prorate(sub.UnitPrice, remaining, period)
+	}
+
+	if to.Interval == sub.RenewalIntervalUnit && to.IntervalCount == sub.RenewalIntervalQuantity {
+		if period > 0 {
+			quote.Charge = prorate(to.Price, remaining, period)
+		}
+	} else {
+		quote.Charge = to.Price
+		quote.NextRenewsAt = addInterval(now, to.Interval, to.IntervalCount).Unix()
+	}
+
+	quote.AmountDue = quote.Charge - quote.Credit
+	return quote
+}
+
+func prorate(price int, remaining, period time.Duration) int {
+	return int(int64(price) * int64(remaining) / int64(period))
+}
+
+// addInterval moves t by count billing intervals of the given unit.
+func addInterval(t time.Time, unit string, count int) time.Time {
+	switch unit {
+	case "day":
+		return t.AddDate(0, 0, count)
+	case "week":
+		return t.AddDate(0, 0, 7*count)
+	case "month":
+		return t.AddDate(0, count, 0)
+	case "year":
+		return t.AddDate(count, 0, 0)
+	default:
+		return t
+	}
+}
@@ -0,0 +1,68 @@
+package subscriptions_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/grantsy/grantsy/internal/entitlements"
+	"github.com/grantsy/grantsy/internal/subscriptions"
+)
+
+func monthlySub(renewsAt time.Time, price int) *subscriptions.Subscription {
+	return &subscriptions.Subscription{
+		Status:                  "active",
+		RenewsAt:                renewsAt.Unix(),
+		UnitPrice:               price,
+		RenewalIntervalUnit:     "month",
+		RenewalIntervalQuantity: 1,
+	}
+}
+
+func TestQuoteProration_UpgradeMidPeriod(t *testing.T) {
+	renewsAt := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2026, 4, 16, 0, 0, 0, 0, time.UTC) // 15 of 30 days left
+
+	quote := subscriptions.QuoteProration(monthlySub(renewsAt, 1000),
+		entitlements.Variant{ID: 2, Price: 3000, Interval: "month", IntervalCount: 1}, now)
+
+	assert.Equal(t, 2, quote.VariantID)
+	assert.Equal(t, 500, quote.Credit)
+	assert.Equal(t, 1500, quote.Charge)
+	assert.Equal(t, 1000, quote.AmountDue)
+	assert.Equal(t, renewsAt.Unix(), quote.NextRenewsAt)
+}
+
+func TestQuoteProration_DowngradeIsCredit(t *testing.T) {
+	renewsAt := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2026, 4, 16, 0, 0, 0, 0, time.UTC)
+
+	quote := subscriptions.QuoteProration(monthlySub(renewsAt, 3000),
+		entitlements.Variant{ID: 2, Price: 1000, Interval: "month", IntervalCount: 1}, now)
+
+	assert.Equal(t, -1000, quote.AmountDue)
+}
+
+func TestQuoteProration_IntervalChangeStartsNewPeriod(t *testing.T) {
+	renewsAt := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2026, 4, 16, 0, 0, 0, 0, time.UTC)
+
+	quote := subscriptions.QuoteProration(monthlySub(renewsAt, 1000),
+		entitlements.Variant{ID: 3, Price: 10000, Interval: "year", IntervalCount: 1}, now)
+
+	assert.Equal(t, 500, quote.Credit)
+	assert.Equal(t, 10000, quote.Charge)
+	assert.Equal(t, 9500, quote.AmountDue)
+	assert.Equal(t, now.AddDate(1, 0, 0).Unix(), quote.NextRenewsAt)
+}
+
+func TestQuoteProration_Trial(t *testing.T) {
+	sub := monthlySub(time.Now().Add(24*time.Hour), 1000)
+	sub.Status = "on_trial"
+
+	quote := subscriptions.QuoteProration(sub,
+		entitlements.Variant{ID: 2, Price: 3000, Interval: "month", IntervalCount: 1}, time.Now())
+
+	assert.Zero(t, quote.AmountDue)
+}
@@ -1,9 +1,15 @@
 package subscriptions
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
+	"maps"
+	"net/http"
 	"sort"
 	"strconv"
 	"sync"
@@ -15,33 +21,79 @@ import (
 	"github.com/grantsy/grantsy/internal/infra/config"
 )
 
+// PriceStore persists price data for enrichment when the provider API is unavailable.
+type PriceStore interface {
+	GetCachedPrice(ctx context.Context, priceID int) (*Price, error)
+	UpsertPrice(ctx context.Context, p *Price) error
+}
+
 // LemonSqueezyProvider consolidates all LemonSqueezy SDK usage.
 // It fetches and caches variant/pricing data and verifies webhooks.
 type LemonSqueezyProvider struct {
 	client        *lemonsqueezy.Client
+	apiKey        string
+	baseURL       string
+	httpClient    *http.Client
+	storeID       int
+	taxInclusive  bool
 	productToPlan map[int]string
+	pricePoints   map[int][]config.PricePoint
+	prices        PriceStore
 	mu            sync.RWMutex
 	cache         map[string][]entitlements.Variant
+	currencies    map[int]string
+}
+
+const lemonSqueezyAPIURL = "https://api.lemonsqueezy.com"
+
+// LemonSqueezyOption configures a LemonSqueezyProvider.
+type LemonSqueezyOption func(*LemonSqueezyProvider)
+
+// WithLemonSqueezyAPI sends API requests to baseURL through client instead
+// of the public LemonSqueezy API.
+func WithLemonSqueezyAPI(baseURL string, client *http.Client) LemonSqueezyOption {
+	return func(p *LemonSqueezyProvider) {
+		p.baseURL = baseURL
+		p.httpClient = client
+	}
 }
 
 func NewLemonSqueezyProvider(
-	apiKey string,
-	signingSecret string,
-	products []config.ProductMapping,
+	cfg config.LemonSqueezyConfig,
+	prices PriceStore,
+	opts ...LemonSqueezyOption,
 ) *LemonSqueezyProvider {
-	productToPlan := make(map[int]string, len(products))
-	for _, p := range products {
+	productToPlan := make(map[int]string, len(cfg.Products))
+	for _, p := range cfg.Products {
 		productToPlan[p.ProductID] = p.PlanID
 	}
+	pricePoints := make(map[int][]config.PricePoint, len(cfg.PricePoints))
+	for _, pp := range cfg.PricePoints {
+		pricePoints[pp.VariantID] = append(pricePoints[pp.VariantID], pp)
+	}
 
-	return &LemonSqueezyProvider{
-		client: lemonsqueezy.New(
-			lemonsqueezy.WithAPIKey(apiKey),
-			lemonsqueezy.WithSigningSecret(signingSecret),
-		),
+	p := &LemonSqueezyProvider{
+		apiKey:        cfg.APIKey,
+		baseURL:       lemonSqueezyAPIURL,
+		httpClient:    http.DefaultClient,
+		storeID:       cfg.StoreID,
+		taxInclusive:  cfg.TaxInclusive,
 		productToPlan: productToPlan,
+		pricePoints:   pricePoints,
+		prices:        prices,
 		cache:         make(map[string][]entitlements.Variant),
+		currencies:    make(map[int]string),
+	}
+	for _, opt := range opts {
+		opt(p)
 	}
+	p.client = lemonsqueezy.New(
+		lemonsqueezy.WithAPIKey(cfg.APIKey),
+		lemonsqueezy.WithSigningSecret(cfg.Webhook.Secret),
+		lemonsqueezy.WithBaseURL(p.baseURL),
+		lemonsqueezy.WithHTTPClient(p.httpClient),
+	)
+	return p
 }
 
 // Start loads pricing data and optionally refreshes it periodically.
@@ -80,7 +132,15 @@ func (p *LemonSqueezyProvider) load(ctx context.Context) {
 		return
 	}
 
+	productStores := make(map[int]int, len(resp.Data))
+	for _, product := range resp.Data {
+		id, _ := strconv.Atoi(product.ID)
+		productStores[id] = product.Attributes.StoreID
+	}
+	currencies := p.loadCurrencies(ctx, productStores)
+
 	cache := make(map[string][]entitlements.Variant)
+	variantIDs := make(map[int]bool)
 
 	for _, variant := range resp.Included {
 		if variant.Attributes.Status != "published" {
@@ -93,6 +153,7 @@ func (p *LemonSqueezyProvider) load(ctx context.Context) {
 		}
 
 		id, _ := strconv.Atoi(variant.ID)
+		variantIDs[id] = true
 
 		var interval string
 		if variant.Attributes.Interval != nil {
@@ -103,10 +164,16 @@ func (p *LemonSqueezyProvider) load(ctx context.Context) {
 			intervalCount = *variant.Attributes.IntervalCount
 		}
 
+		price := int(variant.Attributes.Price.(float64))
+		currency := currencies[productStores[variant.Attributes.ProductID]]
+
 		cache[planID] = append(cache[planID], entitlements.Variant{
 			ID:                 id,
 			Name:               variant.Attributes.Name,
-			Price:              int(variant.Attributes.Price.(float64)),
+			Price:              price,
+			Currency:           currency,
+			TaxInclusive:       p.taxInclusive,
+			Prices:             p.variantPrices(id, currency, price),
 			Interval:           interval,
 			IntervalCount:      intervalCount,
 			HasFreeTrial:       variant.Attributes.HasFreeTrial,
@@ -127,6 +194,203 @@ func (p *LemonSqueezyProvider) load(ctx context.Context) {
 	p.mu.Unlock()
 
 	slog.Info("loaded pricing variants from LemonSqueezy", "plans", len(cache))
+
+	p.loadPrices(ctx, variantIDs)
+}
+
+// defaultCurrency is used when a store's currency can't be fetched.
+const defaultCurrency = "USD"
+
+// loadCurrencies returns the currency of each store the products belong to.
+// Store currencies are fetched once; stores that fail to load are priced in
+// defaultCurrency and fetched again on the next load.
+func (p *LemonSqueezyProvider) loadCurrencies(ctx context.Context, productStores map[int]int) map[int]string {
+	p.mu.RLock()
+	known := maps.Clone(p.currencies)
+	p.mu.RUnlock()
+
+	currencies := make(map[int]string)
+	for _, storeID := range productStores {
+		if currency, ok := known[storeID]; ok {
+			currencies[storeID] = currency
+			continue
+		}
+		resp, _, err := p.client.Stores.Get(ctx, storeID)
+		if err != nil {
+			slog.Error("failed to fetch store from LemonSqueezy", "error", err, "store_id", storeID)
+			currencies[storeID] = defaultCurrency
+			continue
+		}
+		known[storeID] = resp.Data.Attributes.Currency
+		currencies[storeID] = resp.Data.Attributes.Currency
+	}
+
+	p.mu.Lock()
+	p.currencies = known
+	p.mu.Unlock()
+
+	return currencies
+}
+
+// variantPrices returns the store price followed by the configured price points.
+func (p *LemonSqueezyProvider) variantPrices(
+	variantID int,
+	currency string,
+	price int,
+) []entitlements.VariantPrice {
+	points := p.pricePoints[variantID]
+	if len(points) == 0 {
+		return nil
+	}
+	prices := make([]entitlements.VariantPrice, 0, len(points)+1)
+	prices = append(prices, entitlements.VariantPrice{Currency: currency, Price: price})
+	for _, pp := range points {
+		if pp.Currency == currency {
+			continue
+		}
+		prices = append(prices, entitlements.VariantPrice{Currency: pp.Currency, Price: pp.Price})
+	}
+	return prices
+}
+
+// loadPrices stores prices of the given variants in the price cache.
+func (p *LemonSqueezyProvider) loadPrices(ctx context.Context, variantIDs map[int]bool) {
+	now := time.Now().Unix()
+	stored := 0
+	for variantID := range variantIDs {
+		prices, err := p.listVariantPrices(ctx, variantID)
+		if err != nil {
+			slog.Error("failed to fetch prices from LemonSqueezy", "error", err, "variant_id", variantID)
+			continue
+		}
+		for _, price := range prices {
+			id, _ := strconv.Atoi(price.ID)
+			err := p.prices.UpsertPrice(ctx, &Price{
+				PriceID:   id,
+				PriceInfo: mapLemonsqueezyPrice(price.Attributes),
+				UpdatedAt: now,
+			})
+			if err != nil {
+				slog.Error("failed to cache price", "error", err, "price_id", id)
+				continue
+			}
+			stored++
+		}
+	}
+
+	slog.Info("cached prices from LemonSqueezy", "prices", stored)
+}
+
+// pricesPageSize is the largest page size the LemonSqueezy API allows.
+const pricesPageSize = 100
+
+// listVariantPrices returns every price of the variant, following pagination.
+// The SDK's Prices.List only returns the first page of all prices.
+func (p *LemonSqueezyProvider) listVariantPrices(
+	ctx context.Context,
+	variantID int,
+) ([]lemonsqueezy.ApiResponseData[lemonsqueezy.PriceAttributes, lemonsqueezy.APIResponseRelationshipsPrice], error) {
+	var prices []lemonsqueezy.ApiResponseData[lemonsqueezy.PriceAttributes, lemonsqueezy.APIResponseRelationshipsPrice]
+	for page := 1; ; page++ {
+		var resp lemonsqueezy.PricesAPIResponse
+		path := fmt.Sprintf(
+			"/v1/prices?filter[variant_id]=%d&page[number]=%d&page[size]=%d",
+			variantID, page, pricesPageSize,
+		)
+		if err := p.request(ctx, http.MethodGet, path, nil, &resp); err != nil {
+			return nil, err
+		}
+		prices = append(prices, resp.Data...)
+		if page >= resp.Meta.Page.LastPage {
+			return prices, nil
+		}
+	}
+}
+
+// request sends a request the SDK can't express to the LemonSqueezy API and
+// decodes the response into out.
+func (p *LemonSqueezyProvider) request(ctx context.Context, method, path string, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("lemonsqueezy: failed to marshal request: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, p.baseURL+path, reader)
+	if err != nil {
+		return fmt.Errorf("lemonsqueezy: failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	req.Header.Set("Content-Type", "application/vnd.api+json")
+	req.Header.Set("Accept", "application/vnd.api+json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("lemonsqueezy: %s %s: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("lemonsqueezy: failed to read response: %w", err)
+	}
+	if resp.StatusCode >= http.StatusBadRequest && resp.StatusCode < http.StatusInternalServerError {
+		return newProviderError(resp.StatusCode, data)
+	}
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("lemonsqueezy: %s %s: %d %s", method, path, resp.StatusCode, data)
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("lemonsqueezy: failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// ProviderError is a request the provider rejected with a 4xx status, such as
+// an action the subscription's current state doesn't allow.
+type ProviderError struct {
+	StatusCode int
+	Message    string // The provider's explanation of the rejection
+}
+
+func (e *ProviderError) Error() string {
+	return fmt.Sprintf("lemonsqueezy: request rejected: %d %s", e.StatusCode, e.Message)
+}
+
+// newProviderError reads the first JSON:API error in body, falling back to
+// the status text.
+func newProviderError(status int, body []byte) *ProviderError {
+	var resp struct {
+		Errors []struct {
+			Title  string `json:"title"`
+			Detail string `json:"detail"`
+		} `json:"errors"`
+	}
+	msg := http.StatusText(status)
+	if json.Unmarshal(body, &resp) == nil && len(resp.Errors) > 0 {
+		if resp.Errors[0].Detail != "" {
+			msg = resp.Errors[0].Detail
+		} else if resp.Errors[0].Title != "" {
+			msg = resp.Errors[0].Title
+		}
+	}
+	return &ProviderError{StatusCode: status, Message: msg}
+}
+
+// sdkError returns a *ProviderError for a 4xx response to an SDK call, or err
+// as is.
+func sdkError(resp *lemonsqueezy.Response, err error) error {
+	if resp == nil || resp.HTTPResponse == nil || resp.Body == nil {
+		return err
+	}
+	status := resp.HTTPResponse.StatusCode
+	if status >= http.StatusBadRequest && status < http.StatusInternalServerError {
+		return newProviderError(status, *resp.Body)
+	}
+	return err
 }
 
 // GetPlanVariants returns cached variant data for the given plan.
@@ -140,6 +404,7 @@ func (p *LemonSqueezyProvider) GetPlanVariants(
 
 // PriceInfo holds price data fetched from LemonSqueezy.
 type PriceInfo struct {
+	VariantID               int
 	UnitPrice               int
 	RenewalIntervalUnit     string
 	RenewalIntervalQuantity int
@@ -159,10 +424,199 @@ func (p *LemonSqueezyProvider) GetPrice(
 		)
 	}
 
-	return &PriceInfo{
-		UnitPrice:               resp.Data.Attributes.UnitPrice,
-		RenewalIntervalUnit:     resp.Data.Attributes.RenewalIntervalUnit,
-		RenewalIntervalQuantity: resp.Data.Attributes.RenewalIntervalQuantity,
+	price := mapLemonsqueezyPrice(resp.Data.Attributes)
+	return &price, nil
+}
+
+func mapLemonsqueezyPrice(attrs lemonsqueezy.PriceAttributes) PriceInfo {
+	return PriceInfo{
+		VariantID:               attrs.VariantID,
+		UnitPrice:               attrs.UnitPrice,
+		RenewalIntervalUnit:     attrs.RenewalIntervalUnit,
+		RenewalIntervalQuantity: attrs.RenewalIntervalQuantity,
+	}
+}
+
+// GetSubscription fetches the current subscription state from LemonSqueezy.
+// The API does not return custom data, so UserID is left empty.
+func (p *LemonSqueezyProvider) GetSubscription(
+	ctx context.Context,
+	subscriptionID int,
+) (*Subscription, error) {
+	resp, _, err := p.client.Subscriptions.Get(ctx, strconv.Itoa(subscriptionID))
+	if err != nil {
+		return nil, fmt.Errorf(
+			"lemonsqueezy: failed to get subscription %d: %w",
+			subscriptionID,
+			err,
+		)
+	}
+	return mapLemonsqueezySubscriptionResponse(resp), nil
+}
+
+// GetCustomerPortalURL returns a signed customer portal URL for the subscription.
+// The URL is valid for 24 hours.
+func (p *LemonSqueezyProvider) GetCustomerPortalURL(
+	ctx context.Context,
+	subscriptionID int,
+) (string, error) {
+	resp, _, err := p.client.Subscriptions.Get(ctx, strconv.Itoa(subscriptionID))
+	if err != nil {
+		return "", fmt.Errorf(
+			"lemonsqueezy: failed to get subscription %d: %w",
+			subscriptionID,
+			err,
+		)
+	}
+	return resp.Data.Attributes.Urls.CustomerPortal, nil
+}
+
+// CancelSubscription cancels the subscription at the end of the billing period.
+func (p *LemonSqueezyProvider) CancelSubscription(
+	ctx context.Context,
+	subscriptionID int,
+) (*Subscription, error) {
+	resp, httpResp, err := p.client.Subscriptions.Cancel(ctx, strconv.Itoa(subscriptionID))
+	if err != nil {
+		return nil, fmt.Errorf(
+			"lemonsqueezy: failed to cancel subscription %d: %w",
+			subscriptionID,
+			sdkError(httpResp, err),
+		)
+	}
+	return mapLemonsqueezySubscriptionResponse(resp), nil
+}
+
+// ResumeSubscription reverts a pending cancellation and lifts a pause.
+func (p *LemonSqueezyProvider) ResumeSubscription(
+	ctx context.Context,
+	subscriptionID int,
+) (*Subscription, error) {
+	return p.updateSubscription(ctx, subscriptionID, "resume",
+		map[string]any{"cancelled": false, "pause": nil},
+	)
+}
+
+// PauseSubscription pauses payment collection. Mode is "void" or "free";
+// resumesAt is an optional unix timestamp to resume automatically.
+func (p *LemonSqueezyProvider) PauseSubscription(
+	ctx context.Context,
+	subscriptionID int,
+	mode string,
+	resumesAt *int64,
+) (*Subscription, error) {
+	pause := map[string]any{"mode": mode}
+	if resumesAt != nil {
+		pause["resumes_at"] = time.Unix(*resumesAt, 0).UTC()
+	}
+	return p.updateSubscription(ctx, subscriptionID, "pause",
+		map[string]any{"pause": pause},
+	)
+}
+
+// ChangeSubscriptionVariant moves the subscription to another variant.
+func (p *LemonSqueezyProvider) ChangeSubscriptionVariant(
+	ctx context.Context,
+	subscriptionID int,
+	variantID int,
+	invoiceImmediately bool,
+) (*Subscription, error) {
+	return p.updateSubscription(ctx, subscriptionID, "change variant of",
+		map[string]any{"variant_id": variantID, "invoice_immediately": invoiceImmediately},
+	)
+}
+
+// updateSubscription sends only the given attributes. The SDK's update params
+// always send cancelled, pause and trial_ends_at, which would un-cancel or
+// unpause the subscription as a side effect.
+func (p *LemonSqueezyProvider) updateSubscription(
+	ctx context.Context,
+	subscriptionID int,
+	action string,
+	attrs map[string]any,
+) (*Subscription, error) {
+	id := strconv.Itoa(subscriptionID)
+	body := map[string]any{
+		"data": map[string]any{
+			"id":         id,
+			"type":       "subscriptions",
+			"attributes": attrs,
+		},
+	}
+	var resp lemonsqueezy.SubscriptionApiResponse
+	if err := p.request(ctx, http.MethodPatch, "/v1/subscriptions/"+id, body, &resp); err != nil {
+		return nil, fmt.Errorf(
+			"lemonsqueezy: failed to %s subscription %d: %w",
+			action,
+			subscriptionID,
+			err,
+		)
+	}
+	return mapLemonsqueezySubscriptionResponse(&resp), nil
+}
+
+func mapLemonsqueezySubscriptionResponse(resp *lemonsqueezy.SubscriptionApiResponse) *Subscription {
+	return MapLemonsqueezyToSubscription(lemonsqueezy.WebhookRequestSubscription{
+		Data: lemonsqueezy.WebhookRequestData[lemonsqueezy.Subscription, lemonsqueezy.ApiResponseRelationshipsSubscription]{
+			ID:         resp.Data.ID,
+			Attributes: resp.Data.Attributes,
+		},
+	})
+}
+
+// ErrCheckoutNotConfigured is returned when no store is configured for checkouts.
+var ErrCheckoutNotConfigured = errors.New("lemonsqueezy: store_id is not configured")
+
+// CheckoutParams describes a checkout to create for a user.
+type CheckoutParams struct {
+	UserID       string
+	VariantID    int
+	Email        string
+	Name         string
+	DiscountCode string
+	RedirectURL  string
+}
+
+// Checkout is a created hosted checkout.
+type Checkout struct {
+	URL       string
+	ExpiresAt *int64
+}
+
+// CreateCheckout creates a hosted checkout for the variant with the user ID
+// in custom data, so resulting subscription webhooks map back to the user.
+func (p *LemonSqueezyProvider) CreateCheckout(
+	ctx context.Context,
+	params CheckoutParams,
+) (*Checkout, error) {
+	if p.storeID == 0 {
+		return nil, ErrCheckoutNotConfigured
+	}
+
+	resp, _, err := p.client.Checkouts.Create(ctx, p.storeID, params.VariantID,
+		&lemonsqueezy.CheckoutCreateAttributes{
+			ProductOptions: lemonsqueezy.CheckoutCreateProductOptions{
+				RedirectURL: params.RedirectURL,
+			},
+			CheckoutData: lemonsqueezy.CheckoutCreateData{
+				Email:        params.Email,
+				Name:         params.Name,
+				DiscountCode: params.DiscountCode,
+				Custom:       map[string]any{"user_id": params.UserID},
+			},
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"lemonsqueezy: failed to create checkout for variant %d: %w",
+			params.VariantID,
+			err,
+		)
+	}
+
+	return &Checkout{
+		URL:       resp.Data.Attributes.URL,
+		ExpiresAt: TimePtrToUnix(resp.Data.Attributes.ExpiresAt),
 	}, nil
 }
 
@@ -0,0 +1,131 @@
+package subscriptions_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grantsy/grantsy/internal/infra/config"
+	"github.com/grantsy/grantsy/internal/subscriptions"
+	"github.com/grantsy/grantsy/internal/subscriptions/mocks"
+)
+
+// newLemonSqueezyAPI serves handlers as the LemonSqueezy API and returns a
+// provider pointed at it.
+func newLemonSqueezyAPI(t *testing.T, prices subscriptions.PriceStore, handlers map[string]http.HandlerFunc) *subscriptions.LemonSqueezyProvider {
+	t.Helper()
+	mux := http.NewServeMux()
+	for pattern, h := range handlers {
+		mux.HandleFunc(pattern, h)
+	}
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	cfg := config.LemonSqueezyConfig{
+		APIKey:   "test",
+		Products: []config.ProductMapping{{ProductID: 10, PlanID: "pro"}},
+	}
+	return subscriptions.NewLemonSqueezyProvider(cfg, prices, subscriptions.WithLemonSqueezyAPI(srv.URL, srv.Client()))
+}
+
+func TestLemonSqueezyProvider_LoadsAllPricePages(t *testing.T) {
+	prices := mocks.NewMockPriceStore(t)
+	for _, id := range []int{1, 2} {
+		prices.EXPECT().
+			UpsertPrice(mock.Anything, mock.MatchedBy(func(p *subscriptions.Price) bool { return p.PriceID == id })).
+			Return(nil).Once()
+	}
+
+	var pages []string
+	provider := newLemonSqueezyAPI(t, prices, map[string]http.HandlerFunc{
+		"GET /v1/products": func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"data":[{"id":"10","attributes":{"store_id":1}}],
+				"included":[{"id":"11","type":"variants","attributes":{"product_id":10,"status":"published","price":900}}]}`)
+		},
+		"GET /v1/stores/1": func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"data":{"id":"1","attributes":{"currency":"USD"}}}`)
+		},
+		"GET /v1/prices": func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "11", r.URL.Query().Get("filter[variant_id]"))
+			page := r.URL.Query().Get("page[number]")
+			pages = append(pages, page)
+			fmt.Fprintf(w, `{"meta":{"page":{"currentPage":%s,"lastPage":2}},
+				"data":[{"id":"%s","attributes":{"variant_id":11,"unit_price":900}}]}`, page, page)
+		},
+	})
+
+	provider.Start(context.Background(), 0)
+
+	assert.Equal(t, []string{"1", "2"}, pages)
+	assert.Len(t, provider.GetPlanVariants("pro"), 1)
+}
+
+func TestLemonSqueezyProvider_UpdateSendsOnlyChangedAttributes(t *testing.T) {
+	resumesAt := int64(1767225600)
+	tests := []struct {
+		name   string
+		update func(p *subscriptions.LemonSqueezyProvider) (*subscriptions.Subscription, error)
+		want   string
+	}{
+		{
+			name: "resume",
+			update: func(p *subscriptions.LemonSqueezyProvider) (*subscriptions.Subscription, error) {
+				return p.ResumeSubscription(context.Background(), 42)
+			},
+			want: `{"cancelled":false,"pause":null}`,
+		},
+		{
+			name: "pause",
+			update: func(p *subscriptions.LemonSqueezyProvider) (*subscriptions.Subscription, error) {
+				return p.PauseSubscription(context.Background(), 42, "free", &resumesAt)
+			},
+			want: `{"pause":{"mode":"free","resumes_at":"2026-01-01T00:00:00Z"}}`,
+		},
+		{
+			name: "change_variant",
+			update: func(p *subscriptions.LemonSqueezyProvider) (*subscriptions.Subscription, error) {
+				return p.ChangeSubscriptionVariant(context.Background(), 42, 7, true)
+			},
+			want: `{"invoice_immediately":true,"variant_id":7}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body []byte
+			provider := newLemonSqueezyAPI(t, nil, map[string]http.HandlerFunc{
+				"PATCH /v1/subscriptions/42": func(w http.ResponseWriter, r *http.Request) {
+					body, _ = io.ReadAll(r.Body)
+					fmt.Fprint(w, `{"data":{"id":"42","attributes":{"status":"active"}}}`)
+				},
+			})
+
+			sub, err := tt.update(provider)
+			require.NoError(t, err)
+			assert.Equal(t, 42, sub.ID)
+			assert.JSONEq(t, `{"data":{"id":"42","type":"subscriptions","attributes":`+tt.want+`}}`, string(body))
+		})
+	}
+}
+
+func TestLemonSqueezyProvider_UpdateRejected(t *testing.T) {
+	provider := newLemonSqueezyAPI(t, nil, map[string]http.HandlerFunc{
+		"PATCH /v1/subscriptions/42": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			fmt.Fprint(w, `{"errors":[{"status":"422","title":"Unprocessable Entity","detail":"The subscription is expired."}]}`)
+		},
+	})
+
+	_, err := provider.ResumeSubscription(context.Background(), 42)
+
+	var rejected *subscriptions.ProviderError
+	require.ErrorAs(t, err, &rejected)
+	assert.Equal(t, http.StatusUnprocessableEntity, rejected.StatusCode)
+	assert.Equal(t, "The subscription is expired.", rejected.Message)
+}
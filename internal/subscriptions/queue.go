@@ -0,0 +1,45 @@
+package subscriptions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/iamolegga/goqite"
+	"github.com/iamolegga/goqite/jobs"
+
+	"github.com/grantsy/grantsy/internal/infra/tracing"
+)
+
+// IncomingWebhookJob is the job name for processing stored incoming webhooks.
+const IncomingWebhookJob = "incoming_webhooks"
+
+type incomingWebhookJob struct {
+	EventID   string `json:"event_id"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// WebhookQueue schedules incoming webhook events on the job queue.
+type WebhookQueue struct {
+	queue *goqite.Queue
+}
+
+// NewWebhookQueue creates a new incoming webhook queue.
+func NewWebhookQueue(queue *goqite.Queue) *WebhookQueue {
+	return &WebhookQueue{queue: queue}
+}
+
+// EnqueueWebhookEvent queues the stored event for processing by RouteWebhook.HandleJob.
+func (q *WebhookQueue) EnqueueWebhookEvent(ctx context.Context, eventID string) error {
+	body, err := json.Marshal(incomingWebhookJob{
+		EventID:   eventID,
+		RequestID: tracing.GetRequestID(ctx),
+	})
+	if err != nil {
+		return fmt.Errorf("subscriptions: failed to marshal webhook job: %w", err)
+	}
+	if _, err := jobs.Create(ctx, q.queue, IncomingWebhookJob, goqite.Message{Body: body}); err != nil {
+		return fmt.Errorf("subscriptions: failed to enqueue webhook event: %w", err)
+	}
+	return nil
+}
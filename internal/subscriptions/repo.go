@@ -5,10 +5,15 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/grantsy/grantsy/internal/infra/db"
 )
 
+// ErrStaleSubscription is returned by UpsertSubscription when the stored row
+// was updated more recently than the incoming subscription.
+var ErrStaleSubscription = errors.New("subscriptions: stale subscription update")
+
 type Subscription struct {
 	ID                      int
 	UserID                  string
@@ -46,6 +51,12 @@ func (s *Subscription) IsActive() bool {
 	}
 }
 
+// ChangedAt returns when the provider last changed the subscription, as a
+// Unix timestamp.
+func (s *Subscription) ChangedAt() int64 {
+	return s.UpdatedAt
+}
+
 type Repo struct {
 	db *db.DB
 }
@@ -54,6 +65,8 @@ func NewRepo(database *db.DB) *Repo {
 	return &Repo{db: database}
 }
 
+// UpsertSubscription inserts or updates a subscription. Updates older than the
+// stored row are refused with ErrStaleSubscription.
 func (r *Repo) UpsertSubscription(
 	ctx context.Context,
 	sub *Subscription,
@@ -91,9 +104,10 @@ func (r *Repo) UpsertSubscription(
 			unit_price = excluded.unit_price,
 			renewal_interval_unit = excluded.renewal_interval_unit,
 			renewal_interval_quantity = excluded.renewal_interval_quantity
-	`, table))
+		WHERE excluded.updated_at >= %s.updated_at
+	`, table, table))
 
-	_, err := r.db.ExecContext(
+	res, err := r.db.ExecContext(
 		ctx,
 		query,
 		sub.ID,
@@ -125,6 +139,14 @@ func (r *Repo) UpsertSubscription(
 		return fmt.Errorf("billing: failed to upsert subscription: %w", err)
 	}
 
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("billing: failed to upsert subscription: %w", err)
+	}
+	if affected == 0 {
+		return ErrStaleSubscription
+	}
+
 	return nil
 }
 
@@ -134,22 +156,114 @@ func (r *Repo) GetSubscriptionByUserID(
 ) (*Subscription, error) {
 	table := r.db.TableName("subscriptions_lemonsqueezy")
 	query := r.db.Rebind(fmt.Sprintf(`
-		SELECT id, user_id, customer_id, order_id, product_id, product_name,
+		SELECT %s
+		FROM %s
+		WHERE user_id = $1
+		ORDER BY %s
+		LIMIT 1
+	`, subscriptionColumns, table, subscriptionPreference))
+
+	sub, err := scanSubscription(r.db.QueryRowContext(ctx, query, userID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return sub, nil
+}
+
+// UserFilter selects the users returned by ListUserSubscriptions.
+type UserFilter struct {
+	Status  string   // Status of the user's subscription, any if empty
+	Cursor  string   // Return users with an ID after this one
+	UserIDs []string // Return only these users, any if nil
+	Limit   int
+}
+
+// ListUserSubscriptions returns the subscription GetSubscriptionByUserID
+// would return for each user, ordered by user ID.
+func (r *Repo) ListUserSubscriptions(ctx context.Context, filter UserFilter) ([]Subscription, error) {
+	table := r.db.TableName("subscriptions_lemonsqueezy")
+
+	// The cursor and users apply before ranking, the status to the ranked
+	// subscription.
+	var args []any
+	var userConds []string
+	if filter.Cursor != "" {
+		args = append(args, filter.Cursor)
+		userConds = append(userConds, fmt.Sprintf("user_id > $%d", len(args)))
+	}
+	if filter.UserIDs != nil {
+		placeholders := make([]string, len(filter.UserIDs))
+		for i, id := range filter.UserIDs {
+			args = append(args, id)
+			placeholders[i] = fmt.Sprintf("$%d", len(args))
+		}
+		if len(placeholders) == 0 {
+			placeholders = []string{"NULL"}
+		}
+		userConds = append(userConds, fmt.Sprintf("user_id IN (%s)", strings.Join(placeholders, ", ")))
+	}
+	where := ""
+	if len(userConds) > 0 {
+		where = "WHERE " + strings.Join(userConds, " AND ")
+	}
+	conds := []string{"rn = 1"}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conds = append(conds, fmt.Sprintf("status = $%d", len(args)))
+	}
+	args = append(args, filter.Limit)
+
+	query := r.db.Rebind(fmt.Sprintf(`
+		SELECT %s FROM (
+			SELECT *, ROW_NUMBER() OVER (PARTITION BY user_id ORDER BY %s) AS rn
+			FROM %s
+			%s
+		) ranked
+		WHERE %s
+		ORDER BY user_id
+		LIMIT $%d
+	`, subscriptionColumns, subscriptionPreference, table, where, strings.Join(conds, " AND "), len(args)))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("subscriptions: failed to query user subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	var result []Subscription
+	for rows.Next() {
+		sub, err := scanSubscription(rows)
+		if err != nil {
+			return nil, fmt.Errorf("subscriptions: failed to scan row: %w", err)
+		}
+		result = append(result, *sub)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("subscriptions: rows error: %w", err)
+	}
+
+	return result, nil
+}
+
+const subscriptionColumns = `id, user_id, customer_id, order_id, product_id, product_name,
 			variant_id, variant_name, status, status_formatted,
 			card_brand, card_last_four, cancelled, trial_ends_at,
 			billing_anchor, subscription_item_id, renews_at, ends_at,
 			created_at, updated_at,
-			price_id, unit_price, renewal_interval_unit, renewal_interval_quantity
-		FROM %s
-		WHERE user_id = $1
-		ORDER BY
-			CASE WHEN status IN ('on_trial', 'active', 'past_due', 'cancelled') THEN 0 ELSE 1 END,
-			updated_at DESC
-		LIMIT 1
-	`, table))
+			price_id, unit_price, renewal_interval_unit, renewal_interval_quantity`
+
+// subscriptionPreference orders a user's subscriptions so the one that
+// decides their plan comes first: active ones, then the latest updated.
+const subscriptionPreference = `CASE WHEN status IN ('on_trial', 'active', 'past_due', 'cancelled') THEN 0 ELSE 1 END,
+			updated_at DESC`
 
+func scanSubscription(row interface{ Scan(dest ...any) error }) (*Subscription, error) {
 	var sub Subscription
-	err := r.db.QueryRowContext(ctx, query, userID).Scan(
+	err := row.Scan(
 		&sub.ID, &sub.UserID, &sub.CustomerID, &sub.OrderID, &sub.ProductID, &sub.ProductName,
 		&sub.VariantID, &sub.VariantName, &sub.Status, &sub.StatusFormatted,
 		&sub.CardBrand, &sub.CardLastFour, &sub.Cancelled, &sub.TrialEndsAt,
@@ -158,9 +272,6 @@ func (r *Repo) GetSubscriptionByUserID(
 		&sub.PriceID, &sub.UnitPrice, &sub.RenewalIntervalUnit, &sub.RenewalIntervalQuantity,
 	)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, nil
-		}
 		return nil, err
 	}
 	return &sub, nil
@@ -0,0 +1,110 @@
+package subscriptions
+
+import (
+	"context"
+	"fmt"
+)
+
+// Payment is a single subscription payment event (success, failure or recovery).
+type Payment struct {
+	InvoiceID      int
+	EventName      string
+	SubscriptionID int
+	UserID         string
+	BillingReason  string
+	Status         string
+	Currency       string
+	Subtotal       int
+	DiscountTotal  int
+	Tax            int
+	Total          int
+	TotalUSD       int
+	Refunded       bool
+	InvoiceURL     string
+	CreatedAt      int64
+	UpdatedAt      int64
+}
+
+// InsertPayment appends a payment event to the history.
+// Redelivered events for the same invoice are ignored.
+func (r *Repo) InsertPayment(ctx context.Context, p *Payment) error {
+	table := r.db.TableName("subscription_payments_lemonsqueezy")
+	query := r.db.Rebind(fmt.Sprintf(`
+		INSERT INTO %s (
+			invoice_id, event_name, subscription_id, user_id,
+			billing_reason, status, currency,
+			subtotal, discount_total, tax, total, total_usd,
+			refunded, invoice_url, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+		ON CONFLICT(invoice_id, event_name) DO NOTHING
+	`, table))
+
+	_, err := r.db.ExecContext(
+		ctx,
+		query,
+		p.InvoiceID,
+		p.EventName,
+		p.SubscriptionID,
+		p.UserID,
+		p.BillingReason,
+		p.Status,
+		p.Currency,
+		p.Subtotal,
+		p.DiscountTotal,
+		p.Tax,
+		p.Total,
+		p.TotalUSD,
+		p.Refunded,
+		p.InvoiceURL,
+		p.CreatedAt,
+		p.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("subscriptions: failed to insert payment: %w", err)
+	}
+
+	return nil
+}
+
+// ListPayments returns the payment history of a subscription, oldest first.
+func (r *Repo) ListPayments(
+	ctx context.Context,
+	subscriptionID int,
+) ([]Payment, error) {
+	table := r.db.TableName("subscription_payments_lemonsqueezy")
+	query := r.db.Rebind(fmt.Sprintf(`
+		SELECT invoice_id, event_name, subscription_id, user_id,
+			billing_reason, status, currency,
+			subtotal, discount_total, tax, total, total_usd,
+			refunded, invoice_url, created_at, updated_at
+		FROM %s
+		WHERE subscription_id = $1
+		ORDER BY updated_at, invoice_id
+	`, table))
+
+	rows, err := r.db.QueryContext(ctx, query, subscriptionID)
+	if err != nil {
+		return nil, fmt.Errorf("subscriptions: failed to query payments: %w", err)
+	}
+	defer rows.Close()
+
+	var result []Payment
+	for rows.Next() {
+		var p Payment
+		if err := rows.Scan(
+			&p.InvoiceID, &p.EventName, &p.SubscriptionID, &p.UserID,
+			&p.BillingReason, &p.Status, &p.Currency,
+			&p.Subtotal, &p.DiscountTotal, &p.Tax, &p.Total, &p.TotalUSD,
+			&p.Refunded, &p.InvoiceURL, &p.CreatedAt, &p.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("subscriptions: failed to scan row: %w", err)
+		}
+		result = append(result, p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("subscriptions: rows error: %w", err)
+	}
+
+	return result, nil
+}
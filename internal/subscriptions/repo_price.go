@@ -0,0 +1,69 @@
+package subscriptions
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// Price is a cached LemonSqueezy price.
+type Price struct {
+	PriceID int
+	PriceInfo
+	UpdatedAt int64
+}
+
+// UpsertPrice inserts or refreshes a cached price.
+func (r *Repo) UpsertPrice(ctx context.Context, p *Price) error {
+	table := r.db.TableName("prices_lemonsqueezy")
+	query := r.db.Rebind(fmt.Sprintf(`
+		INSERT INTO %s (price_id, variant_id, unit_price, renewal_interval_unit,
+			renewal_interval_quantity, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT(price_id) DO UPDATE SET
+			variant_id = excluded.variant_id,
+			unit_price = excluded.unit_price,
+			renewal_interval_unit = excluded.renewal_interval_unit,
+			renewal_interval_quantity = excluded.renewal_interval_quantity,
+			updated_at = excluded.updated_at
+	`, table))
+
+	_, err := r.db.ExecContext(
+		ctx,
+		query,
+		p.PriceID,
+		p.VariantID,
+		p.UnitPrice,
+		p.RenewalIntervalUnit,
+		p.RenewalIntervalQuantity,
+		p.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("subscriptions: failed to upsert price: %w", err)
+	}
+	return nil
+}
+
+// GetCachedPrice returns the cached price with the given ID, or nil if not cached.
+func (r *Repo) GetCachedPrice(ctx context.Context, priceID int) (*Price, error) {
+	table := r.db.TableName("prices_lemonsqueezy")
+	query := r.db.Rebind(fmt.Sprintf(`
+		SELECT price_id, variant_id, unit_price, renewal_interval_unit,
+			renewal_interval_quantity, updated_at
+		FROM %s WHERE price_id = $1
+	`, table))
+
+	var p Price
+	err := r.db.QueryRowContext(ctx, query, priceID).Scan(
+		&p.PriceID, &p.VariantID, &p.UnitPrice, &p.RenewalIntervalUnit,
+		&p.RenewalIntervalQuantity, &p.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("subscriptions: failed to get cached price: %w", err)
+	}
+	return &p, nil
+}
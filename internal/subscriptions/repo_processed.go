@@ -0,0 +1,43 @@
+package subscriptions
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// IsWebhookProcessed reports whether a webhook with the given payload hash
+// has already been processed successfully.
+func (r *Repo) IsWebhookProcessed(ctx context.Context, hash string) (bool, error) {
+	table := r.db.TableName("processed_webhooks")
+	query := r.db.Rebind(fmt.Sprintf(`
+		SELECT 1 FROM %s WHERE payload_hash = $1
+	`, table))
+
+	var exists int
+	err := r.db.QueryRowContext(ctx, query, hash).Scan(&exists)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("subscriptions: failed to query processed webhook: %w", err)
+	}
+	return true, nil
+}
+
+// MarkWebhookProcessed records a successfully processed webhook payload hash.
+func (r *Repo) MarkWebhookProcessed(ctx context.Context, hash, eventName string) error {
+	table := r.db.TableName("processed_webhooks")
+	query := r.db.Rebind(fmt.Sprintf(`
+		INSERT INTO %s (payload_hash, event_name, processed_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT(payload_hash) DO NOTHING
+	`, table))
+
+	if _, err := r.db.ExecContext(ctx, query, hash, eventName, time.Now().Unix()); err != nil {
+		return fmt.Errorf("subscriptions: failed to mark webhook processed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+package subscriptions
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Webhook event processing outcomes.
+const (
+	WebhookResultPending    = "pending"
+	WebhookResultProcessed  = "processed"
+	WebhookResultDuplicate  = "duplicate"
+	WebhookResultOutOfOrder = "out_of_order"
+	WebhookResultRejected   = "rejected"
+	WebhookResultFailed     = "failed"
+)
+
+// WebhookEvent is a verified incoming provider webhook and its processing outcome.
+type WebhookEvent struct {
+	ID          string
+	Provider    string
+	EventName   string
+	PayloadHash string
+	Headers     http.Header
+	Body        []byte
+	Result      string
+	StatusCode  int
+	Error       string
+	Attempts    int
+	ReceivedAt  int64
+	ProcessedAt *int64
+}
+
+// WebhookEventFilter narrows ListWebhookEvents results.
+// Events are returned newest first; Cursor is the ID of the last event of the previous page.
+type WebhookEventFilter struct {
+	EventName string
+	Result    string
+	Cursor    string
+	Limit     int
+}
+
+const webhookEventColumns = `id, provider, event_name, payload_hash, headers, body,
+			result, status_code, error, attempts, received_at, processed_at`
+
+// InsertWebhookEvent stores a newly received webhook.
+func (r *Repo) InsertWebhookEvent(ctx context.Context, e *WebhookEvent) error {
+	headers, err := json.Marshal(e.Headers)
+	if err != nil {
+		return fmt.Errorf("subscriptions: failed to marshal webhook headers: %w", err)
+	}
+
+	table := r.db.TableName("incoming_webhook_events")
+	query := r.db.Rebind(fmt.Sprintf(`
+		INSERT INTO %s (%s)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`, table, webhookEventColumns))
+
+	_, err = r.db.ExecContext(
+		ctx,
+		query,
+		e.ID,
+		e.Provider,
+		e.EventName,
+		e.PayloadHash,
+		string(headers),
+		string(e.Body),
+		e.Result,
+		e.StatusCode,
+		e.Error,
+		e.Attempts,
+		e.ReceivedAt,
+		e.ProcessedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("subscriptions: failed to insert webhook event: %w", err)
+	}
+	return nil
+}
+
+// UpdateWebhookEventResult stores the processing outcome of an event.
+func (r *Repo) UpdateWebhookEventResult(ctx context.Context, e *WebhookEvent) error {
+	table := r.db.TableName("incoming_webhook_events")
+	query := r.db.Rebind(fmt.Sprintf(`
+		UPDATE %s
+		SET result = $1, status_code = $2, error = $3, attempts = $4, processed_at = $5
+		WHERE id = $6
+	`, table))
+
+	_, err := r.db.ExecContext(
+		ctx,
+		query,
+		e.Result,
+		e.StatusCode,
+		e.Error,
+		e.Attempts,
+		e.ProcessedAt,
+		e.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("subscriptions: failed to update webhook event: %w", err)
+	}
+	return nil
+}
+
+// GetWebhookEvent returns the event with the given ID, or nil if not found.
+func (r *Repo) GetWebhookEvent(ctx context.Context, id string) (*WebhookEvent, error) {
+	table := r.db.TableName("incoming_webhook_events")
+	query := r.db.Rebind(fmt.Sprintf(`
+		SELECT %s FROM %s WHERE id = $1
+	`, webhookEventColumns, table))
+
+	e, err := scanWebhookEvent(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("subscriptions: failed to get webhook event: %w", err)
+	}
+	return e, nil
+}
+
+// ListWebhookEvents returns events matching the filter, newest first.
+func (r *Repo) ListWebhookEvents(
+	ctx context.Context,
+	filter WebhookEventFilter,
+) ([]WebhookEvent, error) {
+	var conds []string
+	var args []any
+	if filter.EventName != "" {
+		args = append(args, filter.EventName)
+		conds = append(conds, fmt.Sprintf("event_name = $%d", len(args)))
+	}
+	if filter.Result != "" {
+		args = append(args, filter.Result)
+		conds = append(conds, fmt.Sprintf("result = $%d", len(args)))
+	}
+	if filter.Cursor != "" {
+		args = append(args, filter.Cursor)
+		conds = append(conds, fmt.Sprintf("id < $%d", len(args)))
+	}
+	where := ""
+	if len(conds) > 0 {
+		where = "WHERE " + strings.Join(conds, " AND ")
+	}
+	args = append(args, filter.Limit)
+
+	table := r.db.TableName("incoming_webhook_events")
+	query := r.db.Rebind(fmt.Sprintf(`
+		SELECT %s FROM %s
+		%s
+		ORDER BY id DESC
+		LIMIT $%d
+	`, webhookEventColumns, table, where, len(args)))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("subscriptions: failed to query webhook events: %w", err)
+	}
+	defer rows.Close()
+
+	var result []WebhookEvent
+	for rows.Next() {
+		e, err := scanWebhookEvent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("subscriptions: failed to scan row: %w", err)
+		}
+		result = append(result, *e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("subscriptions: rows error: %w", err)
+	}
+
+	return result, nil
+}
+
+func scanWebhookEvent(row interface{ Scan(dest ...any) error }) (*WebhookEvent, error) {
+	var e WebhookEvent
+	var headers, body string
+	if err := row.Scan(
+		&e.ID, &e.Provider, &e.EventName, &e.PayloadHash, &headers, &body,
+		&e.Result, &e.StatusCode, &e.Error, &e.Attempts, &e.ReceivedAt, &e.ProcessedAt,
+	); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(headers), &e.Headers); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal headers: %w", err)
+	}
+	e.Body = []byte(body)
+	return &e, nil
+}
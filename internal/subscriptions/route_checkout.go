@@ -0,0 +1,143 @@
+package subscriptions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/iamolegga/valmid"
+	"github.com/swaggest/openapi-go"
+	"github.com/swaggest/openapi-go/openapi31"
+
+	"github.com/grantsy/grantsy/internal/auth"
+	"github.com/grantsy/grantsy/internal/entitlements"
+	"github.com/grantsy/grantsy/internal/httptools"
+	"github.com/grantsy/grantsy/internal/infra/logger"
+	oa "github.com/grantsy/grantsy/internal/openapi"
+)
+
+// CheckoutCreator creates hosted checkouts with the billing provider.
+type CheckoutCreator interface {
+	CreateCheckout(ctx context.Context, params CheckoutParams) (*Checkout, error)
+}
+
+// PlanVariantProvider lists the purchasable variants of a plan.
+type PlanVariantProvider interface {
+	GetPlanVariants(planID string) []entitlements.Variant
+}
+
+type CheckoutBody struct {
+	UserID       string `json:"user_id"                 validate:"required"           description:"User ID the subscription will belong to"                   required:"true"`
+	PlanID       string `json:"plan_id"                 validate:"required"           description:"Plan to subscribe to"                                      required:"true"`
+	VariantID    int    `json:"variant_id,omitempty"    validate:"omitempty,min=1"    description:"Plan variant to check out; defaults to the plan's first variant"`
+	Email        string `json:"email,omitempty"         validate:"omitempty,email"    description:"Prefilled customer email"`
+	Name         string `json:"name,omitempty"          description:"Prefilled customer name"`
+	DiscountCode string `json:"discount_code,omitempty" description:"Discount code to apply"`
+	RedirectURL  string `json:"redirect_url,omitempty"  validate:"omitempty,url"      description:"URL to redirect to after a successful purchase"`
+}
+
+type CheckoutRequest struct {
+	Body *CheckoutBody `in:"body=json" validate:"required"`
+}
+
+type CheckoutResponse struct {
+	URL       string `json:"url"        description:"Hosted checkout URL to send the user to"  required:"true"`
+	VariantID int    `json:"variant_id" description:"Variant the checkout was created for"     required:"true"`
+	ExpiresAt *int64 `json:"expires_at" description:"Unix timestamp when the checkout expires, null if it doesn't"`
+}
+
+type RouteCheckout struct {
+	checkouts CheckoutCreator
+	variants  PlanVariantProvider
+}
+
+func NewRouteCheckout(checkouts CheckoutCreator, variants PlanVariantProvider) *RouteCheckout {
+	return &RouteCheckout{checkouts: checkouts, variants: variants}
+}
+
+func (route *RouteCheckout) Register(mux *http.ServeMux, r *openapi31.Reflector) {
+	mux.Handle("POST /v1/checkout", httptools.Wrap(
+		route.Handler(),
+		auth.RequireScope(auth.ScopeAdminWrite),
+		valmid.Middleware[CheckoutRequest](),
+	))
+	RegisterCheckoutSchema(r)
+}
+
+func RegisterCheckoutSchema(r *openapi31.Reflector) {
+	op, _ := r.NewOperationContext(http.MethodPost, "/v1/checkout")
+	op.AddReqStructure(new(CheckoutBody))
+	op.AddRespStructure(struct {
+		Data CheckoutResponse `json:"data"`
+		Meta httptools.Meta   `json:"meta"`
+		_    struct{}         `title:"CheckoutResponse"`
+	}{}, func(cu *openapi.ContentUnit) {
+		cu.HTTPStatus = http.StatusCreated
+		cu.Description = "Checkout created"
+	})
+	oa.AddErrorResponses(op)
+	op.SetSummary("Create checkout")
+	op.SetDescription(
+		"Create a LemonSqueezy checkout for a plan with the user ID attached, so the resulting subscription is assigned to that user",
+	)
+	op.SetTags("Checkout")
+	op.AddSecurity("ApiKeyAuth", auth.ScopeAdminWrite)
+	op.AddSecurity("BearerAuth", auth.ScopeAdminWrite)
+	r.AddOperation(op)
+}
+
+func (route *RouteCheckout) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		input := valmid.Get[CheckoutRequest](r).Body
+		log := logger.FromContext(r.Context())
+
+		variants := route.variants.GetPlanVariants(input.PlanID)
+		if len(variants) == 0 {
+			httptools.BadRequest(w, r, fmt.Sprintf("Plan '%s' has no purchasable variants", input.PlanID))
+			return
+		}
+
+		variantID := variants[0].ID
+		if input.VariantID != 0 {
+			if !slices.ContainsFunc(variants, func(v entitlements.Variant) bool {
+				return v.ID == input.VariantID
+			}) {
+				httptools.BadRequest(w, r, fmt.Sprintf(
+					"Variant %d does not belong to plan '%s'", input.VariantID, input.PlanID,
+				))
+				return
+			}
+			variantID = input.VariantID
+		}
+
+		checkout, err := route.checkouts.CreateCheckout(r.Context(), CheckoutParams{
+			UserID:       input.UserID,
+			VariantID:    variantID,
+			Email:        input.Email,
+			Name:         input.Name,
+			DiscountCode: input.DiscountCode,
+			RedirectURL:  input.RedirectURL,
+		})
+		if err != nil {
+			log.Error("failed to create checkout", "error", err, "variant_id", variantID)
+			if errors.Is(err, ErrCheckoutNotConfigured) {
+				httptools.Error(w, r, http.StatusNotImplemented,
+					httptools.ErrTypeInternalError,
+					"Not Implemented",
+					"Checkout is not configured",
+				)
+				return
+			}
+			httptools.InternalError(w, r)
+			return
+		}
+
+		httptools.JSON(w, r, http.StatusCreated, CheckoutResponse{
+			URL:       checkout.URL,
+			VariantID: variantID,
+			ExpiresAt: checkout.ExpiresAt,
+		})
+	})
+}
@@ -0,0 +1,129 @@
+package subscriptions_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/openapi-go/openapi31"
+
+	"github.com/grantsy/grantsy/internal/auth/authtest"
+	"github.com/grantsy/grantsy/internal/entitlements"
+	"github.com/grantsy/grantsy/internal/httptools"
+	"github.com/grantsy/grantsy/internal/subscriptions"
+	"github.com/grantsy/grantsy/internal/subscriptions/mocks"
+
+	_ "github.com/grantsy/grantsy/internal/infra/validation"
+)
+
+func newCheckoutMux(
+	t *testing.T,
+	checkouts subscriptions.CheckoutCreator,
+) http.Handler {
+	t.Helper()
+	variants := mocks.NewMockPlanVariantProvider(t)
+	variants.EXPECT().GetPlanVariants("pro").Return([]entitlements.Variant{
+		{ID: 11, Name: "Monthly", Sort: 1},
+		{ID: 12, Name: "Yearly", Sort: 2},
+	}).Maybe()
+	variants.EXPECT().GetPlanVariants(mock.Anything).Return(nil).Maybe()
+
+	mux := http.NewServeMux()
+	subscriptions.NewRouteCheckout(checkouts, variants).Register(mux, openapi31.NewReflector())
+	return authtest.Handler(mux)
+}
+
+func postCheckout(t *testing.T, mux http.Handler, body string) (int, map[string]any) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/v1/checkout", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	var resp httptools.Response
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	data, _ := resp.Data.(map[string]any)
+	return w.Code, data
+}
+
+func TestRouteCheckout_DefaultVariant(t *testing.T) {
+	expiresAt := int64(1700000000)
+	checkouts := mocks.NewMockCheckoutCreator(t)
+	checkouts.EXPECT().
+		CreateCheckout(mock.Anything, subscriptions.CheckoutParams{
+			UserID:    "user-1",
+			VariantID: 11,
+			Email:     "user@example.com",
+		}).
+		Return(&subscriptions.Checkout{URL: "https://pay.example.com/c/1", ExpiresAt: &expiresAt}, nil)
+
+	code, data := postCheckout(t, newCheckoutMux(t, checkouts),
+		`{"user_id":"user-1","plan_id":"pro","email":"user@example.com"}`)
+
+	assert.Equal(t, http.StatusCreated, code)
+	assert.Equal(t, "https://pay.example.com/c/1", data["url"])
+	assert.InDelta(t, 11, data["variant_id"], 0)
+	assert.InDelta(t, expiresAt, data["expires_at"], 0)
+}
+
+func TestRouteCheckout_ExplicitVariant(t *testing.T) {
+	checkouts := mocks.NewMockCheckoutCreator(t)
+	checkouts.EXPECT().
+		CreateCheckout(mock.Anything, mock.MatchedBy(func(p subscriptions.CheckoutParams) bool {
+			return p.VariantID == 12 && p.UserID == "user-1"
+		})).
+		Return(&subscriptions.Checkout{URL: "https://pay.example.com/c/2"}, nil)
+
+	code, data := postCheckout(t, newCheckoutMux(t, checkouts),
+		`{"user_id":"user-1","plan_id":"pro","variant_id":12}`)
+
+	assert.Equal(t, http.StatusCreated, code)
+	assert.InDelta(t, 12, data["variant_id"], 0)
+	assert.Nil(t, data["expires_at"])
+}
+
+func TestRouteCheckout_VariantOfOtherPlan(t *testing.T) {
+	code, _ := postCheckout(t, newCheckoutMux(t, mocks.NewMockCheckoutCreator(t)),
+		`{"user_id":"user-1","plan_id":"pro","variant_id":99}`)
+
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestRouteCheckout_PlanWithoutVariants(t *testing.T) {
+	code, _ := postCheckout(t, newCheckoutMux(t, mocks.NewMockCheckoutCreator(t)),
+		`{"user_id":"user-1","plan_id":"free"}`)
+
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestRouteCheckout_MissingUserID(t *testing.T) {
+	code, _ := postCheckout(t, newCheckoutMux(t, mocks.NewMockCheckoutCreator(t)),
+		`{"plan_id":"pro"}`)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, code)
+}
+
+func TestRouteCheckout_NotConfigured(t *testing.T) {
+	checkouts := mocks.NewMockCheckoutCreator(t)
+	checkouts.EXPECT().
+		CreateCheckout(mock.Anything, mock.Anything).
+		Return(nil, subscriptions.ErrCheckoutNotConfigured)
+
+	code, _ := postCheckout(t, newCheckoutMux(t, checkouts), `{"user_id":"user-1","plan_id":"pro"}`)
+
+	assert.Equal(t, http.StatusNotImplemented, code)
+}
+
+func TestRouteCheckout_ProviderError(t *testing.T) {
+	checkouts := mocks.NewMockCheckoutCreator(t)
+	checkouts.EXPECT().CreateCheckout(mock.Anything, mock.Anything).Return(nil, assert.AnError)
+
+	code, _ := postCheckout(t, newCheckoutMux(t, checkouts), `{"user_id":"user-1","plan_id":"pro"}`)
+
+	assert.Equal(t, http.StatusInternalServerError, code)
+}
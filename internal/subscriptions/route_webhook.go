@@ -3,36 +3,51 @@ package subscriptions
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"slices"
 	"strconv"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/iamolegga/lemonsqueezy-go"
 	"github.com/swaggest/openapi-go/openapi31"
 
+	"github.com/grantsy/grantsy/internal/audit"
 	"github.com/grantsy/grantsy/internal/httptools"
 	"github.com/grantsy/grantsy/internal/infra/logger"
+	"github.com/grantsy/grantsy/internal/infra/metrics"
+	"github.com/grantsy/grantsy/internal/infra/tracing"
 )
 
 // SubscriptionObserver is notified when subscriptions change state.
-// Uses primitives + any to avoid import cycles.
+// Uses primitives + any to avoid import cycles. The cause is the name of the
+// provider event that changed the subscription.
 type SubscriptionObserver interface {
 	OnSubscriptionChange(
 		ctx context.Context,
 		userID string,
 		productID int,
 		active bool,
+		cause string,
 		subscription any,
 	) error
+	OnPayment(
+		ctx context.Context,
+		userID, outcome string,
+		subscription, payment any,
+	) error
 }
 
 // SubscriptionWriter writes subscription data.
 type SubscriptionWriter interface {
 	UpsertSubscription(ctx context.Context, sub *Subscription) error
+	InsertPayment(ctx context.Context, p *Payment) error
 }
 
 // WebhookVerifier verifies incoming webhook signatures.
@@ -45,17 +60,56 @@ type PriceFetcher interface {
 	GetPrice(ctx context.Context, priceID int) (*PriceInfo, error)
 }
 
+// WebhookDeduplicator tracks processed webhook payloads to skip redeliveries.
+type WebhookDeduplicator interface {
+	IsWebhookProcessed(ctx context.Context, hash string) (bool, error)
+	MarkWebhookProcessed(ctx context.Context, hash, eventName string) error
+}
+
+// WebhookEventRecorder persists raw incoming webhooks and their processing outcome.
+type WebhookEventRecorder interface {
+	InsertWebhookEvent(ctx context.Context, e *WebhookEvent) error
+	UpdateWebhookEventResult(ctx context.Context, e *WebhookEvent) error
+	GetWebhookEvent(ctx context.Context, id string) (*WebhookEvent, error)
+}
+
+// WebhookEnqueuer schedules stored webhook events for background processing.
+type WebhookEnqueuer interface {
+	EnqueueWebhookEvent(ctx context.Context, eventID string) error
+}
+
+// Transactor runs a function in a database transaction.
+type Transactor interface {
+	InTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// SubscriptionFetcher fetches the current subscription state from the billing provider.
+type SubscriptionFetcher interface {
+	GetSubscription(ctx context.Context, subscriptionID int) (*Subscription, error)
+}
+
 type RouteWebhook struct {
 	repo     SubscriptionWriter
 	observer SubscriptionObserver
 	provider WebhookVerifier
 	pricing  PriceFetcher
+	fetcher  SubscriptionFetcher
+	dedup    WebhookDeduplicator
+	events   WebhookEventRecorder
+	queue    WebhookEnqueuer
+	tx       Transactor
+	users    userLocks
 }
 
 func NewRouteWebhook(
 	provider WebhookVerifier,
 	pricing PriceFetcher,
+	fetcher SubscriptionFetcher,
 	repo SubscriptionWriter,
+	dedup WebhookDeduplicator,
+	events WebhookEventRecorder,
+	queue WebhookEnqueuer,
+	tx Transactor,
 	observer SubscriptionObserver,
 ) *RouteWebhook {
 	return &RouteWebhook{
@@ -63,9 +117,47 @@ func NewRouteWebhook(
 		observer: observer,
 		provider: provider,
 		pricing:  pricing,
+		fetcher:  fetcher,
+		dedup:    dedup,
+		events:   events,
+		queue:    queue,
+		tx:       tx,
 	}
 }
 
+// subscriptionEvents carry a subscription object as payload.
+var subscriptionEvents = []string{
+	lemonsqueezy.WebhookEventSubscriptionCreated,
+	lemonsqueezy.WebhookEventSubscriptionUpdated,
+	lemonsqueezy.WebhookEventSubscriptionCancelled,
+	lemonsqueezy.WebhookEventSubscriptionResumed,
+	lemonsqueezy.WebhookEventSubscriptionExpired,
+	lemonsqueezy.WebhookEventSubscriptionPaused,
+	lemonsqueezy.WebhookEventSubscriptionUnpaused,
+}
+
+// paymentEvents carry a subscription invoice object as payload.
+var paymentEvents = []string{
+	lemonsqueezy.WebhookEventSubscriptionPaymentSuccess,
+	lemonsqueezy.WebhookEventSubscriptionPaymentFailed,
+	lemonsqueezy.WebhookEventSubscriptionPaymentRecovered,
+}
+
+// Payment outcomes reported to SubscriptionObserver.OnPayment.
+const (
+	PaymentSucceeded = "succeeded"
+	PaymentFailed    = "failed"
+	PaymentRecovered = "recovered"
+)
+
+var paymentOutcomes = map[string]string{
+	lemonsqueezy.WebhookEventSubscriptionPaymentSuccess:   PaymentSucceeded,
+	lemonsqueezy.WebhookEventSubscriptionPaymentFailed:    PaymentFailed,
+	lemonsqueezy.WebhookEventSubscriptionPaymentRecovered: PaymentRecovered,
+}
+
+var errDuplicateWebhook = errors.New("duplicate webhook")
+
 func (route *RouteWebhook) Register(mux *http.ServeMux, _ *openapi31.Reflector) {
 	mux.Handle("POST /v1/webhook/lemonsqueezy", route.Handler())
 	// Webhook intentionally excluded from OpenAPI documentation
@@ -89,61 +181,288 @@ func (route *RouteWebhook) Handler() http.Handler {
 			return
 		}
 
-		switch eventName {
-		case lemonsqueezy.WebhookEventSubscriptionCreated,
-			lemonsqueezy.WebhookEventSubscriptionUpdated:
-			var request lemonsqueezy.WebhookRequestSubscription
-			if err := json.Unmarshal(payload, &request); err != nil {
-				log.Info("failed to unmarshal webhook payload", "error", err)
-				httptools.WriteStatus(w, http.StatusBadRequest)
-				return
-			}
-			log.Debug(eventName, "request", request)
-			sub := MapLemonsqueezyToSubscription(request)
-			if sub.PriceID == 0 {
-				log.Error("missing price_id in webhook payload", "subscription_id", sub.ID)
-				httptools.WriteStatus(w, http.StatusBadRequest)
-				return
-			}
-			price, err := route.pricing.GetPrice(r.Context(), sub.PriceID)
-			if err != nil {
-				log.Error("failed to fetch price", "error", err, "price_id", sub.PriceID)
-				httptools.WriteStatus(w, http.StatusInternalServerError)
-				return
-			}
-			sub.UnitPrice = price.UnitPrice
-			sub.RenewalIntervalUnit = price.RenewalIntervalUnit
-			sub.RenewalIntervalQuantity = price.RenewalIntervalQuantity
-			if err := route.repo.UpsertSubscription(r.Context(), sub); err != nil {
-				log.Info("failed to upsert subscription", "error", err)
-				httptools.WriteStatus(w, http.StatusInternalServerError)
-				return
-			}
-			if err := route.notifyObserver(r.Context(), sub); err != nil {
-				log.Info("failed to update entitlements", "error", err)
-				httptools.WriteStatus(w, http.StatusInternalServerError)
-				return
-			}
-			httptools.WriteStatus(w, http.StatusOK)
+		event := &WebhookEvent{
+			ID:          uuid.Must(uuid.NewV7()).String(),
+			Provider:    "lemonsqueezy",
+			EventName:   eventName,
+			PayloadHash: payloadHash(payload),
+			Headers:     r.Header.Clone(),
+			Body:        payload,
+			Result:      WebhookResultPending,
+			ReceivedAt:  time.Now().Unix(),
+		}
+		if err := route.events.InsertWebhookEvent(r.Context(), event); err != nil {
+			log.Error("failed to record webhook event", "error", err)
+			httptools.WriteStatus(w, http.StatusInternalServerError)
 			return
+		}
 
-		default:
-			log.Info("invalid event", "event", eventName, "payload", string(payload))
-			httptools.WriteStatus(w, http.StatusBadRequest)
+		if !isSupportedEvent(eventName) {
+			// Nothing to do in the background, reject right away.
+			route.process(r.Context(), event, false)
+			httptools.WriteStatus(w, event.StatusCode)
 			return
 		}
+
+		// The provider is acknowledged once the event is durably queued,
+		// so slow provider API calls during processing can't time it out.
+		if err := route.queue.EnqueueWebhookEvent(r.Context(), event.ID); err != nil {
+			log.Error("failed to enqueue webhook event", "error", err, "webhook_event_id", event.ID)
+			httptools.WriteStatus(w, http.StatusInternalServerError)
+			return
+		}
+
+		httptools.WriteStatus(w, http.StatusOK)
+	})
+}
+
+// HandleJob processes a queued incoming webhook event.
+// A failed attempt returns an error so the queue redelivers the job.
+func (route *RouteWebhook) HandleJob(ctx context.Context, body []byte) error {
+	var job incomingWebhookJob
+	if err := json.Unmarshal(body, &job); err != nil {
+		return fmt.Errorf("failed to unmarshal webhook job: %w", err)
+	}
+
+	event, err := route.events.GetWebhookEvent(ctx, job.EventID)
+	if err != nil {
+		return fmt.Errorf("failed to load webhook event %s: %w", job.EventID, err)
+	}
+	if event == nil {
+		logger.FromContext(ctx).Warn("queued webhook event not found, skipping", "webhook_event_id", job.EventID)
+		return nil
+	}
+	if event.Result != WebhookResultPending && event.Result != WebhookResultFailed {
+		// Already settled, e.g. by a replay.
+		return nil
+	}
+
+	// Plan changes are audited as made by the provider, under the ID of the
+	// request that delivered the webhook.
+	ctx = audit.WithProvider(ctx, event.Provider)
+	if job.RequestID != "" {
+		ctx = tracing.WithRequestID(ctx, job.RequestID)
+	}
+	route.process(ctx, event, false)
+	if event.Result == WebhookResultFailed {
+		return fmt.Errorf("webhook event %s failed: %s", event.ID, event.Error)
+	}
+	return nil
+}
+
+// Replay processes a stored event again and records the new outcome on it.
+// Duplicate detection is skipped, out-of-order protection still applies.
+func (route *RouteWebhook) Replay(ctx context.Context, event *WebhookEvent) {
+	route.process(ctx, event, true)
+}
+
+// process applies the event, then stores its outcome.
+func (route *RouteWebhook) process(ctx context.Context, event *WebhookEvent, replay bool) {
+	log := logger.FromContext(ctx).With("webhook_event_id", event.ID, "event", event.EventName)
+
+	status, err := route.apply(ctx, event, replay)
+
+	now := time.Now().Unix()
+	event.Attempts++
+	event.StatusCode = status
+	event.ProcessedAt = &now
+	event.Error = ""
+	if err != nil {
+		event.Error = err.Error()
+	}
+
+	switch {
+	case errors.Is(err, errDuplicateWebhook):
+		log.Info("duplicate webhook, skipping", "hash", event.PayloadHash)
+		metrics.RecordIncomingWebhookDropped("duplicate")
+		event.Result = WebhookResultDuplicate
+	case errors.Is(err, ErrStaleSubscription):
+		// Acknowledged so the provider stops retrying; a newer state is already stored.
+		log.Info("dropping out-of-order subscription update")
+		metrics.RecordIncomingWebhookDropped("out_of_order")
+		event.Result = WebhookResultOutOfOrder
+	case err != nil && status >= http.StatusInternalServerError:
+		log.Error("failed to process webhook", "error", err)
+		event.Result = WebhookResultFailed
+	case err != nil:
+		// The body holds customer data, so only its stored event is referenced.
+		log.Info("rejected webhook", "error", err)
+		event.Result = WebhookResultRejected
+	default:
+		event.Result = WebhookResultProcessed
+	}
+
+	if err := route.events.UpdateWebhookEventResult(ctx, event); err != nil {
+		log.Error("failed to record webhook result", "error", err)
+	}
+}
+
+func isSupportedEvent(eventName string) bool {
+	return slices.Contains(subscriptionEvents, eventName) ||
+		slices.Contains(paymentEvents, eventName)
+}
+
+// apply handles a verified webhook payload.
+// Returns the HTTP status to acknowledge the webhook with.
+func (route *RouteWebhook) apply(
+	ctx context.Context,
+	event *WebhookEvent,
+	replay bool,
+) (int, error) {
+	if !isSupportedEvent(event.EventName) {
+		return http.StatusBadRequest, fmt.Errorf("unsupported event %q", event.EventName)
+	}
+
+	if !replay {
+		processed, err := route.dedup.IsWebhookProcessed(ctx, event.PayloadHash)
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("failed to check processed webhook: %w", err)
+		}
+		if processed {
+			return http.StatusOK, errDuplicateWebhook
+		}
+	}
+
+	if slices.Contains(paymentEvents, event.EventName) {
+		return route.applyPayment(ctx, event)
+	}
+	return route.applySubscription(ctx, event)
+}
+
+func (route *RouteWebhook) applySubscription(ctx context.Context, event *WebhookEvent) (int, error) {
+	var request lemonsqueezy.WebhookRequestSubscription
+	if err := json.Unmarshal(event.Body, &request); err != nil {
+		return http.StatusBadRequest, fmt.Errorf("failed to unmarshal webhook payload: %w", err)
+	}
+
+	sub := MapLemonsqueezyToSubscription(request)
+	logger.FromContext(ctx).Debug(event.EventName, "subscription_id", sub.ID, "status", sub.Status)
+	if status, err := route.enrichSubscription(ctx, sub); err != nil {
+		return status, err
+	}
+	return route.commit(ctx, event, sub.UserID, func(ctx context.Context) (int, error) {
+		return route.storeSubscription(ctx, sub, event.EventName)
+	})
+}
+
+func (route *RouteWebhook) applyPayment(ctx context.Context, event *WebhookEvent) (int, error) {
+	var request lemonsqueezy.WebhookRequestSubscriptionInvoice
+	if err := json.Unmarshal(event.Body, &request); err != nil {
+		return http.StatusBadRequest, fmt.Errorf("failed to unmarshal webhook payload: %w", err)
+	}
+
+	payment := MapLemonsqueezyToPayment(event.EventName, request)
+	logger.FromContext(ctx).Debug(event.EventName, "invoice_id", payment.InvoiceID, "subscription_id", payment.SubscriptionID)
+	if payment.SubscriptionID == 0 {
+		return http.StatusBadRequest, fmt.Errorf(
+			"missing subscription_id in webhook payload for invoice %d",
+			payment.InvoiceID,
+		)
+	}
+	// Invoice payloads carry no subscription state, so fetch it to
+	// apply status changes (e.g. past_due) without waiting for an update event.
+	sub, err := route.fetcher.GetSubscription(ctx, payment.SubscriptionID)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	sub.UserID = payment.UserID
+	if status, err := route.enrichSubscription(ctx, sub); err != nil {
+		return status, err
+	}
+
+	return route.commit(ctx, event, sub.UserID, func(ctx context.Context) (int, error) {
+		if err := route.repo.InsertPayment(ctx, payment); err != nil {
+			return http.StatusInternalServerError, err
+		}
+		status, err := route.storeSubscription(ctx, sub, event.EventName)
+		if status != http.StatusOK {
+			return status, err
+		}
+		if notifyErr := route.observer.OnPayment(
+			ctx, payment.UserID, paymentOutcomes[event.EventName], sub, payment,
+		); notifyErr != nil {
+			return http.StatusInternalServerError, fmt.Errorf("failed to notify payment: %w", notifyErr)
+		}
+		return status, err
+	})
+}
+
+// commit runs fn and marks the event processed in one transaction, so stored
+// subscription state, the outgoing webhooks queued for it and the dedup mark
+// are written together or not at all. An out-of-order update is still
+// committed, to record the mark. Commits for the same user are serialized, so
+// each plan change starts from the plan the previous one left.
+func (route *RouteWebhook) commit(
+	ctx context.Context,
+	event *WebhookEvent,
+	userID string,
+	fn func(ctx context.Context) (int, error),
+) (int, error) {
+	unlock := route.users.lock(userID)
+	defer unlock()
+
+	var status int
+	var applyErr error
+	err := route.tx.InTx(ctx, func(ctx context.Context) error {
+		status, applyErr = fn(ctx)
+		if applyErr != nil && !errors.Is(applyErr, ErrStaleSubscription) {
+			return applyErr
+		}
+		if err := route.dedup.MarkWebhookProcessed(ctx, event.PayloadHash, event.EventName); err != nil {
+			return fmt.Errorf("failed to mark webhook processed: %w", err)
+		}
+		return nil
 	})
+	// A failure other than fn's own, such as marking the event or committing.
+	if err != nil && !errors.Is(err, applyErr) {
+		return http.StatusInternalServerError, err
+	}
+	return status, applyErr
+}
+
+// enrichSubscription sets the subscription's price data from the billing provider.
+func (route *RouteWebhook) enrichSubscription(ctx context.Context, sub *Subscription) (int, error) {
+	if sub.PriceID == 0 {
+		return http.StatusBadRequest, fmt.Errorf(
+			"missing price_id in webhook payload for subscription %d",
+			sub.ID,
+		)
+	}
+	price, err := route.pricing.GetPrice(ctx, sub.PriceID)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	sub.UnitPrice = price.UnitPrice
+	sub.RenewalIntervalUnit = price.RenewalIntervalUnit
+	sub.RenewalIntervalQuantity = price.RenewalIntervalQuantity
+	return http.StatusOK, nil
+}
+
+// storeSubscription persists the subscription and updates entitlements.
+func (route *RouteWebhook) storeSubscription(ctx context.Context, sub *Subscription, cause string) (int, error) {
+	if err := route.repo.UpsertSubscription(ctx, sub); err != nil {
+		if errors.Is(err, ErrStaleSubscription) {
+			return http.StatusOK, err
+		}
+		return http.StatusInternalServerError, err
+	}
+	if err := route.notifyObserver(ctx, sub, cause); err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("failed to update entitlements: %w", err)
+	}
+	return http.StatusOK, nil
 }
 
 func (route *RouteWebhook) notifyObserver(
 	ctx context.Context,
 	sub *Subscription,
+	cause string,
 ) error {
 	return route.observer.OnSubscriptionChange(
 		ctx,
 		sub.UserID,
 		sub.ProductID,
 		sub.IsActive(),
+		cause,
 		sub,
 	)
 }
@@ -168,6 +487,13 @@ func (route *RouteWebhook) validateWebhook(r *http.Request) error {
 	return nil
 }
 
+// payloadHash identifies a webhook delivery by the SHA-256 of its body.
+// LemonSqueezy does not send an event ID, and retries resend the same body.
+func payloadHash(payload []byte) string {
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:])
+}
+
 func MapLemonsqueezyToSubscription(
 	s lemonsqueezy.WebhookRequestSubscription,
 ) *Subscription {
@@ -205,6 +531,34 @@ func MapLemonsqueezyToSubscription(
 	}
 }
 
+// MapLemonsqueezyToPayment converts a subscription invoice webhook into a Payment.
+func MapLemonsqueezyToPayment(
+	eventName string,
+	s lemonsqueezy.WebhookRequestSubscriptionInvoice,
+) *Payment {
+	invoiceID, _ := strconv.Atoi(s.Data.ID)
+	userID, _ := s.Meta.CustomData["user_id"].(string)
+
+	return &Payment{
+		InvoiceID:      invoiceID,
+		EventName:      eventName,
+		SubscriptionID: s.Data.Attributes.SubscriptionID,
+		UserID:         userID,
+		BillingReason:  s.Data.Attributes.BillingReason,
+		Status:         s.Data.Attributes.Status,
+		Currency:       s.Data.Attributes.Currency,
+		Subtotal:       s.Data.Attributes.Subtotal,
+		DiscountTotal:  s.Data.Attributes.DiscountTotal,
+		Tax:            s.Data.Attributes.Tax,
+		Total:          s.Data.Attributes.Total,
+		TotalUSD:       s.Data.Attributes.TotalUsd,
+		Refunded:       s.Data.Attributes.Refunded,
+		InvoiceURL:     s.Data.Attributes.Urls.InvoiceURL,
+		CreatedAt:      s.Data.Attributes.CreatedAt.Unix(),
+		UpdatedAt:      s.Data.Attributes.UpdatedAt.Unix(),
+	}
+}
+
 func TimePtrToUnix(t *time.Time) *int64 {
 	if t == nil || t.IsZero() {
 		return nil
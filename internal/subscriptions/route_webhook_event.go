@@ -0,0 +1,102 @@
+package subscriptions
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/iamolegga/valmid"
+	"github.com/swaggest/openapi-go"
+	"github.com/swaggest/openapi-go/openapi31"
+
+	"github.com/grantsy/grantsy/internal/auth"
+	"github.com/grantsy/grantsy/internal/httptools"
+	"github.com/grantsy/grantsy/internal/infra/logger"
+	oa "github.com/grantsy/grantsy/internal/openapi"
+)
+
+type WebhookEventRequest struct {
+	EventID string `in:"path=event_id" path:"event_id" validate:"required" description:"Webhook event ID"`
+}
+
+type WebhookEventResponse struct {
+	Event WebhookEventDetail `json:"event" description:"Webhook event details" required:"true"`
+}
+
+// WebhookEventDetail is a WebhookEventSummary with the raw request.
+type WebhookEventDetail struct {
+	WebhookEventSummary
+	Headers map[string][]string `json:"headers" description:"Request headers as received" required:"true"`
+	Body    string              `json:"body"    description:"Raw request body"            required:"true"`
+}
+
+type RouteWebhookEvent struct {
+	reader WebhookEventReader
+}
+
+func NewRouteWebhookEvent(reader WebhookEventReader) *RouteWebhookEvent {
+	return &RouteWebhookEvent{reader: reader}
+}
+
+func (route *RouteWebhookEvent) Register(mux *http.ServeMux, r *openapi31.Reflector) {
+	mux.Handle("GET /v1/webhook-events/{event_id}", httptools.Wrap(
+		route.Handler(),
+		auth.RequireScope(auth.ScopeAdminWrite),
+		valmid.Middleware[WebhookEventRequest](),
+	))
+	RegisterWebhookEventSchema(r)
+}
+
+func RegisterWebhookEventSchema(r *openapi31.Reflector) {
+	op, _ := r.NewOperationContext(http.MethodGet, "/v1/webhook-events/{event_id}")
+	op.AddReqStructure(new(WebhookEventRequest))
+	op.AddRespStructure(struct {
+		Data WebhookEventResponse `json:"data"`
+		Meta httptools.Meta       `json:"meta"`
+		_    struct{}             `title:"WebhookEventResponse"`
+	}{}, func(cu *openapi.ContentUnit) {
+		cu.HTTPStatus = http.StatusOK
+		cu.Description = "Webhook event details"
+	})
+	oa.AddErrorResponses(op)
+	op.SetSummary("Get incoming webhook event")
+	op.SetDescription("Get a received provider webhook including its raw headers and body")
+	op.SetTags("Webhook Events")
+	op.AddSecurity("ApiKeyAuth", auth.ScopeAdminWrite)
+	op.AddSecurity("BearerAuth", auth.ScopeAdminWrite)
+	r.AddOperation(op)
+}
+
+func (route *RouteWebhookEvent) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		input := valmid.Get[WebhookEventRequest](r)
+
+		e, err := route.reader.GetWebhookEvent(r.Context(), input.EventID)
+		if err != nil {
+			logger.FromContext(r.Context()).
+				Error("failed to get webhook event", "error", err, "event_id", input.EventID)
+			httptools.InternalError(w, r)
+			return
+		}
+		if e == nil {
+			httptools.NotFound(w, r, fmt.Sprintf("Webhook event '%s' not found", input.EventID))
+			return
+		}
+
+		httptools.JSON(w, r, http.StatusOK, WebhookEventResponse{
+			Event: ToWebhookEventDetail(e),
+		})
+	})
+}
+
+// ToWebhookEventDetail converts a WebhookEvent to its detail display type.
+func ToWebhookEventDetail(e *WebhookEvent) WebhookEventDetail {
+	headers := e.Headers
+	if headers == nil {
+		headers = http.Header{}
+	}
+	return WebhookEventDetail{
+		WebhookEventSummary: ToWebhookEventSummary(e),
+		Headers:             headers,
+		Body:                string(e.Body),
+	}
+}
@@ -0,0 +1,88 @@
+package subscriptions
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/iamolegga/valmid"
+	"github.com/swaggest/openapi-go"
+	"github.com/swaggest/openapi-go/openapi31"
+
+	"github.com/grantsy/grantsy/internal/auth"
+	"github.com/grantsy/grantsy/internal/httptools"
+	"github.com/grantsy/grantsy/internal/infra/logger"
+	oa "github.com/grantsy/grantsy/internal/openapi"
+)
+
+// WebhookReplayer processes a stored webhook event again.
+type WebhookReplayer interface {
+	Replay(ctx context.Context, event *WebhookEvent)
+}
+
+type RouteWebhookEventReplay struct {
+	reader   WebhookEventReader
+	replayer WebhookReplayer
+}
+
+func NewRouteWebhookEventReplay(
+	reader WebhookEventReader,
+	replayer WebhookReplayer,
+) *RouteWebhookEventReplay {
+	return &RouteWebhookEventReplay{reader: reader, replayer: replayer}
+}
+
+func (route *RouteWebhookEventReplay) Register(mux *http.ServeMux, r *openapi31.Reflector) {
+	mux.Handle("POST /v1/webhook-events/{event_id}/replay", httptools.Wrap(
+		route.Handler(),
+		auth.RequireScope(auth.ScopeAdminWrite),
+		valmid.Middleware[WebhookEventRequest](),
+	))
+	RegisterWebhookEventReplaySchema(r)
+}
+
+func RegisterWebhookEventReplaySchema(r *openapi31.Reflector) {
+	op, _ := r.NewOperationContext(http.MethodPost, "/v1/webhook-events/{event_id}/replay")
+	op.AddReqStructure(new(WebhookEventRequest))
+	op.AddRespStructure(struct {
+		Data WebhookEventResponse `json:"data"`
+		Meta httptools.Meta       `json:"meta"`
+		_    struct{}             `title:"WebhookEventReplayResponse"`
+	}{}, func(cu *openapi.ContentUnit) {
+		cu.HTTPStatus = http.StatusOK
+		cu.Description = "Webhook event with the replay result"
+	})
+	oa.AddErrorResponses(op)
+	op.SetSummary("Replay incoming webhook event")
+	op.SetDescription(
+		"Process a stored provider webhook again through the regular webhook handler. Duplicate detection is bypassed; updates older than the stored subscription are still dropped.",
+	)
+	op.SetTags("Webhook Events")
+	op.AddSecurity("ApiKeyAuth", auth.ScopeAdminWrite)
+	op.AddSecurity("BearerAuth", auth.ScopeAdminWrite)
+	r.AddOperation(op)
+}
+
+func (route *RouteWebhookEventReplay) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		input := valmid.Get[WebhookEventRequest](r)
+
+		e, err := route.reader.GetWebhookEvent(r.Context(), input.EventID)
+		if err != nil {
+			logger.FromContext(r.Context()).
+				Error("failed to get webhook event", "error", err, "event_id", input.EventID)
+			httptools.InternalError(w, r)
+			return
+		}
+		if e == nil {
+			httptools.NotFound(w, r, fmt.Sprintf("Webhook event '%s' not found", input.EventID))
+			return
+		}
+
+		route.replayer.Replay(r.Context(), e)
+
+		httptools.JSON(w, r, http.StatusOK, WebhookEventResponse{
+			Event: ToWebhookEventDetail(e),
+		})
+	})
+}
@@ -0,0 +1,131 @@
+package subscriptions
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/iamolegga/valmid"
+	"github.com/swaggest/openapi-go"
+	"github.com/swaggest/openapi-go/openapi31"
+
+	"github.com/grantsy/grantsy/internal/auth"
+	"github.com/grantsy/grantsy/internal/httptools"
+	"github.com/grantsy/grantsy/internal/infra/logger"
+	oa "github.com/grantsy/grantsy/internal/openapi"
+)
+
+// WebhookEventReader reads stored incoming webhook events.
+type WebhookEventReader interface {
+	ListWebhookEvents(ctx context.Context, filter WebhookEventFilter) ([]WebhookEvent, error)
+	GetWebhookEvent(ctx context.Context, id string) (*WebhookEvent, error)
+}
+
+type WebhookEventsRequest struct {
+	EventName string `in:"query=event_name"       query:"event_name" description:"Filter by provider event name (e.g. subscription_updated)"`
+	Result    string `in:"query=result"           query:"result"     description:"Filter by processing result"                                  validate:"omitempty,oneof=pending processed duplicate out_of_order rejected failed"`
+	Cursor    string `in:"query=cursor"           query:"cursor"     description:"Pagination cursor (next_cursor from the previous page)"`
+	Limit     int    `in:"query=limit;default=50" query:"limit"      description:"Maximum number of events to return"                          validate:"min=1,max=200"                                                            default:"50"`
+}
+
+type WebhookEventsResponse struct {
+	Events     []WebhookEventSummary `json:"events"                description:"Incoming webhook events, newest first" nullable:"false" required:"true"`
+	NextCursor string                `json:"next_cursor,omitempty" description:"Cursor for the next page, omitted on the last page"`
+}
+
+type WebhookEventSummary struct {
+	ID          string `json:"id"              description:"Event identifier"                                   required:"true"`
+	Provider    string `json:"provider"        description:"Provider that sent the webhook"                     required:"true" enum:"lemonsqueezy"`
+	EventName   string `json:"event_name"      description:"Provider event name"                                required:"true"`
+	Result      string `json:"result"          description:"Processing result"                                  required:"true" enum:"pending,processed,duplicate,out_of_order,rejected,failed"`
+	StatusCode  int    `json:"status_code"     description:"HTTP status returned to the provider"               required:"true"`
+	Error       string `json:"error,omitempty" description:"Processing error, if any"`
+	Attempts    int    `json:"attempts"        description:"Number of times the event was processed"            required:"true"`
+	ReceivedAt  int64  `json:"received_at"     description:"Unix timestamp when the webhook was received"       required:"true"`
+	ProcessedAt *int64 `json:"processed_at"    description:"Unix timestamp of the latest processing attempt"`
+}
+
+type RouteWebhookEvents struct {
+	reader WebhookEventReader
+}
+
+func NewRouteWebhookEvents(reader WebhookEventReader) *RouteWebhookEvents {
+	return &RouteWebhookEvents{reader: reader}
+}
+
+func (route *RouteWebhookEvents) Register(mux *http.ServeMux, r *openapi31.Reflector) {
+	mux.Handle("GET /v1/webhook-events", httptools.Wrap(
+		route.Handler(),
+		auth.RequireScope(auth.ScopeAdminWrite),
+		valmid.Middleware[WebhookEventsRequest](),
+	))
+	RegisterWebhookEventsSchema(r)
+}
+
+func RegisterWebhookEventsSchema(r *openapi31.Reflector) {
+	op, _ := r.NewOperationContext(http.MethodGet, "/v1/webhook-events")
+	op.AddReqStructure(new(WebhookEventsRequest))
+	op.AddRespStructure(struct {
+		Data WebhookEventsResponse `json:"data"`
+		Meta httptools.Meta        `json:"meta"`
+		_    struct{}              `title:"WebhookEventsResponse"`
+	}{}, func(cu *openapi.ContentUnit) {
+		cu.HTTPStatus = http.StatusOK
+		cu.Description = "Incoming webhook events"
+	})
+	oa.AddErrorResponses(op)
+	op.SetSummary("List incoming webhook events")
+	op.SetDescription(
+		"List verified webhooks received from payment providers with their processing result, newest first. Use next_cursor to fetch the next page.",
+	)
+	op.SetTags("Webhook Events")
+	op.AddSecurity("ApiKeyAuth", auth.ScopeAdminWrite)
+	op.AddSecurity("BearerAuth", auth.ScopeAdminWrite)
+	r.AddOperation(op)
+}
+
+func (route *RouteWebhookEvents) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		input := valmid.Get[WebhookEventsRequest](r)
+
+		// Fetch one extra event to know whether another page exists.
+		events, err := route.reader.ListWebhookEvents(r.Context(), WebhookEventFilter{
+			EventName: input.EventName,
+			Result:    input.Result,
+			Cursor:    input.Cursor,
+			Limit:     input.Limit + 1,
+		})
+		if err != nil {
+			logger.FromContext(r.Context()).Error("failed to list webhook events", "error", err)
+			httptools.InternalError(w, r)
+			return
+		}
+
+		resp := WebhookEventsResponse{
+			Events: make([]WebhookEventSummary, 0, min(len(events), input.Limit)),
+		}
+		for i, e := range events {
+			if i == input.Limit {
+				resp.NextCursor = events[i-1].ID
+				break
+			}
+			resp.Events = append(resp.Events, ToWebhookEventSummary(&e))
+		}
+
+		httptools.JSON(w, r, http.StatusOK, resp)
+	})
+}
+
+// ToWebhookEventSummary converts a WebhookEvent to its list display type.
+func ToWebhookEventSummary(e *WebhookEvent) WebhookEventSummary {
+	return WebhookEventSummary{
+		ID:          e.ID,
+		Provider:    e.Provider,
+		EventName:   e.EventName,
+		Result:      e.Result,
+		StatusCode:  e.StatusCode,
+		Error:       e.Error,
+		Attempts:    e.Attempts,
+		ReceivedAt:  e.ReceivedAt,
+		ProcessedAt: e.ProcessedAt,
+	}
+}
@@ -0,0 +1,161 @@
+package subscriptions_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/openapi-go/openapi31"
+
+	"github.com/grantsy/grantsy/internal/auth/authtest"
+	"github.com/grantsy/grantsy/internal/httptools"
+	"github.com/grantsy/grantsy/internal/subscriptions"
+	"github.com/grantsy/grantsy/internal/subscriptions/mocks"
+
+	_ "github.com/grantsy/grantsy/internal/infra/validation"
+)
+
+func newWebhookEventsMux(
+	t *testing.T,
+	reader subscriptions.WebhookEventReader,
+	replayer subscriptions.WebhookReplayer,
+) http.Handler {
+	t.Helper()
+	mux := http.NewServeMux()
+	r := openapi31.NewReflector()
+	subscriptions.NewRouteWebhookEvents(reader).Register(mux, r)
+	subscriptions.NewRouteWebhookEvent(reader).Register(mux, r)
+	subscriptions.NewRouteWebhookEventReplay(reader, replayer).Register(mux, r)
+	return authtest.Handler(mux)
+}
+
+func serveWebhookEvents(t *testing.T, mux http.Handler, method, target string) (int, map[string]any) {
+	t.Helper()
+	req := httptest.NewRequest(method, target, nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	var resp httptools.Response
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	data, _ := resp.Data.(map[string]any)
+	return w.Code, data
+}
+
+func TestRouteWebhookEvents_Pagination(t *testing.T) {
+	reader := mocks.NewMockWebhookEventReader(t)
+	reader.EXPECT().
+		ListWebhookEvents(mock.Anything, subscriptions.WebhookEventFilter{
+			Result: subscriptions.WebhookResultFailed,
+			Cursor: "05",
+			Limit:  3,
+		}).
+		Return([]subscriptions.WebhookEvent{
+			*testWebhookEvent("04", "subscription_updated", subscriptions.WebhookResultFailed),
+			*testWebhookEvent("03", "subscription_updated", subscriptions.WebhookResultFailed),
+			*testWebhookEvent("02", "subscription_updated", subscriptions.WebhookResultFailed),
+		}, nil)
+
+	mux := newWebhookEventsMux(t, reader, mocks.NewMockWebhookReplayer(t))
+	code, data := serveWebhookEvents(
+		t, mux, http.MethodGet, "/v1/webhook-events?result=failed&cursor=05&limit=2",
+	)
+
+	assert.Equal(t, http.StatusOK, code)
+	events := data["events"].([]any)
+	require.Len(t, events, 2)
+	assert.Equal(t, "04", events[0].(map[string]any)["id"])
+	assert.Equal(t, "03", data["next_cursor"])
+	_, hasBody := events[0].(map[string]any)["body"]
+	assert.False(t, hasBody)
+}
+
+func TestRouteWebhookEvents_LastPage(t *testing.T) {
+	reader := mocks.NewMockWebhookEventReader(t)
+	reader.EXPECT().
+		ListWebhookEvents(mock.Anything, subscriptions.WebhookEventFilter{Limit: 51}).
+		Return(nil, nil)
+
+	mux := newWebhookEventsMux(t, reader, mocks.NewMockWebhookReplayer(t))
+	code, data := serveWebhookEvents(t, mux, http.MethodGet, "/v1/webhook-events")
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Empty(t, data["events"])
+	_, hasCursor := data["next_cursor"]
+	assert.False(t, hasCursor)
+}
+
+func TestRouteWebhookEvents_InvalidResult(t *testing.T) {
+	mux := newWebhookEventsMux(
+		t,
+		mocks.NewMockWebhookEventReader(t),
+		mocks.NewMockWebhookReplayer(t),
+	)
+	code, _ := serveWebhookEvents(t, mux, http.MethodGet, "/v1/webhook-events?result=unknown")
+
+	assert.Equal(t, http.StatusUnprocessableEntity, code)
+}
+
+func TestRouteWebhookEvent_Get(t *testing.T) {
+	reader := mocks.NewMockWebhookEventReader(t)
+	reader.EXPECT().
+		GetWebhookEvent(mock.Anything, "01").
+		Return(testWebhookEvent("01", "subscription_created", subscriptions.WebhookResultProcessed), nil)
+
+	mux := newWebhookEventsMux(t, reader, mocks.NewMockWebhookReplayer(t))
+	code, data := serveWebhookEvents(t, mux, http.MethodGet, "/v1/webhook-events/01")
+
+	assert.Equal(t, http.StatusOK, code)
+	event := data["event"].(map[string]any)
+	assert.Equal(t, "subscription_created", event["event_name"])
+	assert.Equal(t, `{"meta":{}}`, event["body"])
+	assert.Equal(t, []any{"subscription_created"}, event["headers"].(map[string]any)["X-Event-Name"])
+}
+
+func TestRouteWebhookEvent_NotFound(t *testing.T) {
+	reader := mocks.NewMockWebhookEventReader(t)
+	reader.EXPECT().GetWebhookEvent(mock.Anything, "missing").Return(nil, nil)
+
+	mux := newWebhookEventsMux(t, reader, mocks.NewMockWebhookReplayer(t))
+	code, _ := serveWebhookEvents(t, mux, http.MethodGet, "/v1/webhook-events/missing")
+
+	assert.Equal(t, http.StatusNotFound, code)
+}
+
+func TestRouteWebhookEventReplay(t *testing.T) {
+	stored := testWebhookEvent("01", "subscription_updated", subscriptions.WebhookResultFailed)
+
+	reader := mocks.NewMockWebhookEventReader(t)
+	reader.EXPECT().GetWebhookEvent(mock.Anything, "01").Return(stored, nil)
+
+	replayer := mocks.NewMockWebhookReplayer(t)
+	replayer.EXPECT().
+		Replay(mock.Anything, stored).
+		Run(func(_ context.Context, e *subscriptions.WebhookEvent) {
+			e.Result = subscriptions.WebhookResultProcessed
+			e.StatusCode = http.StatusOK
+			e.Attempts++
+		})
+
+	mux := newWebhookEventsMux(t, reader, replayer)
+	code, data := serveWebhookEvents(t, mux, http.MethodPost, "/v1/webhook-events/01/replay")
+
+	assert.Equal(t, http.StatusOK, code)
+	event := data["event"].(map[string]any)
+	assert.Equal(t, subscriptions.WebhookResultProcessed, event["result"])
+	assert.InDelta(t, 1, event["attempts"], 0)
+}
+
+func TestRouteWebhookEventReplay_NotFound(t *testing.T) {
+	reader := mocks.NewMockWebhookEventReader(t)
+	reader.EXPECT().GetWebhookEvent(mock.Anything, "missing").Return(nil, nil)
+
+	mux := newWebhookEventsMux(t, reader, mocks.NewMockWebhookReplayer(t))
+	code, _ := serveWebhookEvents(t, mux, http.MethodPost, "/v1/webhook-events/missing/replay")
+
+	assert.Equal(t, http.StatusNotFound, code)
+}
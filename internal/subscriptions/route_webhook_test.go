@@ -1,10 +1,15 @@
 package subscriptions_test
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -13,6 +18,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/grantsy/grantsy/internal/infra/logger"
 	"github.com/grantsy/grantsy/internal/subscriptions"
 	"github.com/grantsy/grantsy/internal/subscriptions/mocks"
 )
@@ -178,13 +184,88 @@ func validWebhookPayload(t *testing.T, eventName string) string {
 	return webhookPayload(t, eventName, testFirstItem)
 }
 
+// newEvents expects the webhook to be recorded and to end with the given result.
+func newEvents(t *testing.T, result string) *mocks.MockWebhookEventRecorder {
+	t.Helper()
+	var stored *subscriptions.WebhookEvent
+	events := mocks.NewMockWebhookEventRecorder(t)
+	events.EXPECT().
+		InsertWebhookEvent(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, e *subscriptions.WebhookEvent) { stored = e }).
+		Return(nil)
+	events.EXPECT().
+		GetWebhookEvent(mock.Anything, mock.Anything).
+		RunAndReturn(func(context.Context, string) (*subscriptions.WebhookEvent, error) {
+			return stored, nil
+		}).
+		Maybe()
+	events.EXPECT().
+		UpdateWebhookEventResult(mock.Anything, mock.MatchedBy(func(e *subscriptions.WebhookEvent) bool {
+			return e.Result == result && e.Attempts == 1
+		})).
+		Return(nil)
+	return events
+}
+
+// serveWebhook sends the webhook and runs the job it queued, like the worker would.
+// Returns the status acknowledged to the provider and the job error.
+func serveWebhook(
+	t *testing.T,
+	route *subscriptions.RouteWebhook,
+	queue *mocks.MockWebhookEnqueuer,
+	req *http.Request,
+) (int, error) {
+	t.Helper()
+	var queued []string
+	queue.EXPECT().
+		EnqueueWebhookEvent(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, id string) { queued = append(queued, id) }).
+		Return(nil).
+		Maybe()
+
+	w := httptest.NewRecorder()
+	route.Handler().ServeHTTP(w, req)
+
+	var jobErr error
+	for _, id := range queued {
+		body, err := json.Marshal(map[string]string{"event_id": id})
+		require.NoError(t, err)
+		jobErr = route.HandleJob(context.Background(), body)
+	}
+	return w.Code, jobErr
+}
+
+func newDedup(t *testing.T, processed bool) *mocks.MockWebhookDeduplicator {
+	t.Helper()
+	dedup := mocks.NewMockWebhookDeduplicator(t)
+	dedup.EXPECT().IsWebhookProcessed(mock.Anything, mock.Anything).Return(processed, nil)
+	return dedup
+}
+
+// newTx returns a Transactor that runs the function it is given.
+func newTx(t *testing.T) *mocks.MockTransactor {
+	t.Helper()
+	tx := mocks.NewMockTransactor(t)
+	tx.EXPECT().
+		InTx(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).
+		Maybe()
+	return tx
+}
+
 func TestRouteWebhook_MissingSignature(t *testing.T) {
 	verifier := mocks.NewMockWebhookVerifier(t)
 	writer := mocks.NewMockSubscriptionWriter(t)
 	observer := mocks.NewMockSubscriptionObserver(t)
 
 	pricing := mocks.NewMockPriceFetcher(t)
-	route := subscriptions.NewRouteWebhook(verifier, pricing, writer, observer)
+	fetcher := mocks.NewMockSubscriptionFetcher(t)
+	dedup := mocks.NewMockWebhookDeduplicator(t)
+	events := mocks.NewMockWebhookEventRecorder(t)
+	queue := mocks.NewMockWebhookEnqueuer(t)
+	route := subscriptions.NewRouteWebhook(verifier, pricing, fetcher, writer, dedup, events, queue, newTx(t), observer)
 	handler := route.Handler()
 
 	req := httptest.NewRequest(http.MethodPost, "/v1/webhook/lemonsqueezy", strings.NewReader("{}"))
@@ -202,7 +283,11 @@ func TestRouteWebhook_InvalidSignature(t *testing.T) {
 	observer := mocks.NewMockSubscriptionObserver(t)
 
 	pricing := mocks.NewMockPriceFetcher(t)
-	route := subscriptions.NewRouteWebhook(verifier, pricing, writer, observer)
+	fetcher := mocks.NewMockSubscriptionFetcher(t)
+	dedup := mocks.NewMockWebhookDeduplicator(t)
+	events := mocks.NewMockWebhookEventRecorder(t)
+	queue := mocks.NewMockWebhookEnqueuer(t)
+	route := subscriptions.NewRouteWebhook(verifier, pricing, fetcher, writer, dedup, events, queue, newTx(t), observer)
 	handler := route.Handler()
 
 	req := httptest.NewRequest(http.MethodPost, "/v1/webhook/lemonsqueezy", strings.NewReader("{}"))
@@ -223,7 +308,11 @@ func TestRouteWebhook_InvalidEventName(t *testing.T) {
 	observer := mocks.NewMockSubscriptionObserver(t)
 
 	pricing := mocks.NewMockPriceFetcher(t)
-	route := subscriptions.NewRouteWebhook(verifier, pricing, writer, observer)
+	fetcher := mocks.NewMockSubscriptionFetcher(t)
+	dedup := mocks.NewMockWebhookDeduplicator(t)
+	events := newEvents(t, subscriptions.WebhookResultRejected)
+	queue := mocks.NewMockWebhookEnqueuer(t)
+	route := subscriptions.NewRouteWebhook(verifier, pricing, fetcher, writer, dedup, events, queue, newTx(t), observer)
 	handler := route.Handler()
 
 	req := httptest.NewRequest(http.MethodPost, "/v1/webhook/lemonsqueezy", strings.NewReader(body))
@@ -245,8 +334,11 @@ func TestRouteWebhook_InvalidPayload(t *testing.T) {
 	observer := mocks.NewMockSubscriptionObserver(t)
 
 	pricing := mocks.NewMockPriceFetcher(t)
-	route := subscriptions.NewRouteWebhook(verifier, pricing, writer, observer)
-	handler := route.Handler()
+	fetcher := mocks.NewMockSubscriptionFetcher(t)
+	dedup := newDedup(t, false)
+	events := newEvents(t, subscriptions.WebhookResultRejected)
+	queue := mocks.NewMockWebhookEnqueuer(t)
+	route := subscriptions.NewRouteWebhook(verifier, pricing, fetcher, writer, dedup, events, queue, newTx(t), observer)
 
 	req := httptest.NewRequest(
 		http.MethodPost,
@@ -255,10 +347,10 @@ func TestRouteWebhook_InvalidPayload(t *testing.T) {
 	)
 	req.Header.Set("X-Signature", "valid-sig")
 	req.Header.Set("X-Event-Name", "subscription_created")
-	w := httptest.NewRecorder()
-	handler.ServeHTTP(w, req)
+	code, err := serveWebhook(t, route, queue, req)
 
-	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, http.StatusOK, code)
+	assert.NoError(t, err)
 }
 
 func TestRouteWebhook_MissingPriceID(t *testing.T) {
@@ -270,16 +362,19 @@ func TestRouteWebhook_MissingPriceID(t *testing.T) {
 	writer := mocks.NewMockSubscriptionWriter(t)
 	observer := mocks.NewMockSubscriptionObserver(t)
 	pricing := mocks.NewMockPriceFetcher(t)
-	route := subscriptions.NewRouteWebhook(verifier, pricing, writer, observer)
-	handler := route.Handler()
+	fetcher := mocks.NewMockSubscriptionFetcher(t)
+	dedup := newDedup(t, false)
+	events := newEvents(t, subscriptions.WebhookResultRejected)
+	queue := mocks.NewMockWebhookEnqueuer(t)
+	route := subscriptions.NewRouteWebhook(verifier, pricing, fetcher, writer, dedup, events, queue, newTx(t), observer)
 
 	req := httptest.NewRequest(http.MethodPost, "/v1/webhook/lemonsqueezy", strings.NewReader(body))
 	req.Header.Set("X-Signature", "valid-sig")
 	req.Header.Set("X-Event-Name", "subscription_created")
-	w := httptest.NewRecorder()
-	handler.ServeHTTP(w, req)
+	code, err := serveWebhook(t, route, queue, req)
 
-	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, http.StatusOK, code)
+	assert.NoError(t, err)
 }
 
 func TestRouteWebhook_PriceFetchError(t *testing.T) {
@@ -292,16 +387,19 @@ func TestRouteWebhook_PriceFetchError(t *testing.T) {
 	observer := mocks.NewMockSubscriptionObserver(t)
 	pricing := mocks.NewMockPriceFetcher(t)
 	pricing.EXPECT().GetPrice(mock.Anything, 555).Return(nil, assert.AnError)
-	route := subscriptions.NewRouteWebhook(verifier, pricing, writer, observer)
-	handler := route.Handler()
+	fetcher := mocks.NewMockSubscriptionFetcher(t)
+	dedup := newDedup(t, false)
+	events := newEvents(t, subscriptions.WebhookResultFailed)
+	queue := mocks.NewMockWebhookEnqueuer(t)
+	route := subscriptions.NewRouteWebhook(verifier, pricing, fetcher, writer, dedup, events, queue, newTx(t), observer)
 
 	req := httptest.NewRequest(http.MethodPost, "/v1/webhook/lemonsqueezy", strings.NewReader(body))
 	req.Header.Set("X-Signature", "valid-sig")
 	req.Header.Set("X-Event-Name", "subscription_created")
-	w := httptest.NewRecorder()
-	handler.ServeHTTP(w, req)
+	code, err := serveWebhook(t, route, queue, req)
 
-	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Error(t, err)
 }
 
 func TestRouteWebhook_UpsertError(t *testing.T) {
@@ -317,16 +415,19 @@ func TestRouteWebhook_UpsertError(t *testing.T) {
 
 	pricing := mocks.NewMockPriceFetcher(t)
 	pricing.EXPECT().GetPrice(mock.Anything, 555).Return(&subscriptions.PriceInfo{UnitPrice: 999}, nil)
-	route := subscriptions.NewRouteWebhook(verifier, pricing, writer, observer)
-	handler := route.Handler()
+	fetcher := mocks.NewMockSubscriptionFetcher(t)
+	dedup := newDedup(t, false)
+	events := newEvents(t, subscriptions.WebhookResultFailed)
+	queue := mocks.NewMockWebhookEnqueuer(t)
+	route := subscriptions.NewRouteWebhook(verifier, pricing, fetcher, writer, dedup, events, queue, newTx(t), observer)
 
 	req := httptest.NewRequest(http.MethodPost, "/v1/webhook/lemonsqueezy", strings.NewReader(body))
 	req.Header.Set("X-Signature", "valid-sig")
 	req.Header.Set("X-Event-Name", "subscription_created")
-	w := httptest.NewRecorder()
-	handler.ServeHTTP(w, req)
+	code, err := serveWebhook(t, route, queue, req)
 
-	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Error(t, err)
 }
 
 func TestRouteWebhook_ObserverError(t *testing.T) {
@@ -340,21 +441,24 @@ func TestRouteWebhook_ObserverError(t *testing.T) {
 
 	observer := mocks.NewMockSubscriptionObserver(t)
 	observer.EXPECT().
-		OnSubscriptionChange(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		OnSubscriptionChange(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 		Return(assert.AnError)
 
 	pricing := mocks.NewMockPriceFetcher(t)
 	pricing.EXPECT().GetPrice(mock.Anything, 555).Return(&subscriptions.PriceInfo{UnitPrice: 999}, nil)
-	route := subscriptions.NewRouteWebhook(verifier, pricing, writer, observer)
-	handler := route.Handler()
+	fetcher := mocks.NewMockSubscriptionFetcher(t)
+	dedup := newDedup(t, false)
+	events := newEvents(t, subscriptions.WebhookResultFailed)
+	queue := mocks.NewMockWebhookEnqueuer(t)
+	route := subscriptions.NewRouteWebhook(verifier, pricing, fetcher, writer, dedup, events, queue, newTx(t), observer)
 
 	req := httptest.NewRequest(http.MethodPost, "/v1/webhook/lemonsqueezy", strings.NewReader(body))
 	req.Header.Set("X-Signature", "valid-sig")
 	req.Header.Set("X-Event-Name", "subscription_created")
-	w := httptest.NewRecorder()
-	handler.ServeHTTP(w, req)
+	code, err := serveWebhook(t, route, queue, req)
 
-	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Error(t, err)
 }
 
 func TestRouteWebhook_Success_Created(t *testing.T) {
@@ -368,21 +472,25 @@ func TestRouteWebhook_Success_Created(t *testing.T) {
 
 	observer := mocks.NewMockSubscriptionObserver(t)
 	observer.EXPECT().
-		OnSubscriptionChange(mock.Anything, "user-123", 300, true, mock.Anything).
+		OnSubscriptionChange(mock.Anything, "user-123", 300, true, mock.Anything, mock.Anything).
 		Return(nil)
 
 	pricing := mocks.NewMockPriceFetcher(t)
 	pricing.EXPECT().GetPrice(mock.Anything, 555).Return(&subscriptions.PriceInfo{UnitPrice: 999}, nil)
-	route := subscriptions.NewRouteWebhook(verifier, pricing, writer, observer)
-	handler := route.Handler()
+	fetcher := mocks.NewMockSubscriptionFetcher(t)
+	dedup := newDedup(t, false)
+	dedup.EXPECT().MarkWebhookProcessed(mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	events := newEvents(t, subscriptions.WebhookResultProcessed)
+	queue := mocks.NewMockWebhookEnqueuer(t)
+	route := subscriptions.NewRouteWebhook(verifier, pricing, fetcher, writer, dedup, events, queue, newTx(t), observer)
 
 	req := httptest.NewRequest(http.MethodPost, "/v1/webhook/lemonsqueezy", strings.NewReader(body))
 	req.Header.Set("X-Signature", "valid-sig")
 	req.Header.Set("X-Event-Name", "subscription_created")
-	w := httptest.NewRecorder()
-	handler.ServeHTTP(w, req)
+	code, err := serveWebhook(t, route, queue, req)
 
-	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, http.StatusOK, code)
+	assert.NoError(t, err)
 }
 
 func TestRouteWebhook_Success_Updated(t *testing.T) {
@@ -396,19 +504,506 @@ func TestRouteWebhook_Success_Updated(t *testing.T) {
 
 	observer := mocks.NewMockSubscriptionObserver(t)
 	observer.EXPECT().
-		OnSubscriptionChange(mock.Anything, "user-123", 300, true, mock.Anything).
+		OnSubscriptionChange(mock.Anything, "user-123", 300, true, mock.Anything, mock.Anything).
 		Return(nil)
 
 	pricing := mocks.NewMockPriceFetcher(t)
 	pricing.EXPECT().GetPrice(mock.Anything, 555).Return(&subscriptions.PriceInfo{UnitPrice: 999}, nil)
-	route := subscriptions.NewRouteWebhook(verifier, pricing, writer, observer)
-	handler := route.Handler()
+	fetcher := mocks.NewMockSubscriptionFetcher(t)
+	dedup := newDedup(t, false)
+	dedup.EXPECT().MarkWebhookProcessed(mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	events := newEvents(t, subscriptions.WebhookResultProcessed)
+	queue := mocks.NewMockWebhookEnqueuer(t)
+	route := subscriptions.NewRouteWebhook(verifier, pricing, fetcher, writer, dedup, events, queue, newTx(t), observer)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/webhook/lemonsqueezy", strings.NewReader(body))
+	req.Header.Set("X-Signature", "valid-sig")
+	req.Header.Set("X-Event-Name", "subscription_updated")
+	code, err := serveWebhook(t, route, queue, req)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.NoError(t, err)
+}
+
+func TestRouteWebhook_Success_Expired(t *testing.T) {
+	req := lemonsqueezy.WebhookRequestSubscription{
+		Meta: lemonsqueezy.WebhookRequestMeta{
+			EventName:  "subscription_expired",
+			CustomData: map[string]any{"user_id": "user-123"},
+		},
+		Data: lemonsqueezy.WebhookRequestData[lemonsqueezy.Subscription, lemonsqueezy.ApiResponseRelationshipsSubscription]{
+			ID: "42",
+			Attributes: lemonsqueezy.Subscription{
+				ProductID:             300,
+				Status:                "expired",
+				FirstSubscriptionItem: testFirstItem,
+				RenewsAt:              time.Date(2024, 7, 15, 0, 0, 0, 0, time.UTC),
+				CreatedAt:             time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:             time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+	b, err := json.Marshal(req)
+	require.NoError(t, err)
+	body := string(b)
+
+	verifier := mocks.NewMockWebhookVerifier(t)
+	verifier.EXPECT().VerifyWebhook(mock.Anything, "valid-sig", []byte(body)).Return(true)
+
+	writer := mocks.NewMockSubscriptionWriter(t)
+	writer.EXPECT().UpsertSubscription(mock.Anything, mock.Anything).Return(nil)
+
+	observer := mocks.NewMockSubscriptionObserver(t)
+	observer.EXPECT().
+		OnSubscriptionChange(mock.Anything, "user-123", 300, false, "subscription_expired", mock.Anything).
+		Return(nil)
+
+	pricing := mocks.NewMockPriceFetcher(t)
+	pricing.EXPECT().GetPrice(mock.Anything, 555).Return(&subscriptions.PriceInfo{UnitPrice: 999}, nil)
+	fetcher := mocks.NewMockSubscriptionFetcher(t)
+	dedup := newDedup(t, false)
+	dedup.EXPECT().MarkWebhookProcessed(mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	events := newEvents(t, subscriptions.WebhookResultProcessed)
+	queue := mocks.NewMockWebhookEnqueuer(t)
+	route := subscriptions.NewRouteWebhook(verifier, pricing, fetcher, writer, dedup, events, queue, newTx(t), observer)
+
+	r := httptest.NewRequest(http.MethodPost, "/v1/webhook/lemonsqueezy", strings.NewReader(body))
+	r.Header.Set("X-Signature", "valid-sig")
+	r.Header.Set("X-Event-Name", "subscription_expired")
+	code, err := serveWebhook(t, route, queue, r)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.NoError(t, err)
+}
+
+// --- Payment events ---
+
+func invoicePayload(t *testing.T, eventName string, subscriptionID int) string {
+	t.Helper()
+	req := lemonsqueezy.WebhookRequestSubscriptionInvoice{
+		Meta: lemonsqueezy.WebhookRequestMeta{
+			EventName:  eventName,
+			CustomData: map[string]any{"user_id": "user-123"},
+		},
+		Data: lemonsqueezy.WebhookRequestData[lemonsqueezy.SubscriptionInvoiceAttributes, lemonsqueezy.APIResponseRelationshipsSubscriptionInvoice]{
+			ID: "77",
+			Attributes: lemonsqueezy.SubscriptionInvoiceAttributes{
+				SubscriptionID: subscriptionID,
+				BillingReason:  "renewal",
+				Currency:       "USD",
+				Subtotal:       999,
+				Total:          999,
+				TotalUsd:       999,
+				Status:         "pending",
+				CreatedAt:      time.Date(2024, 7, 15, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:      time.Date(2024, 7, 15, 0, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+	b, err := json.Marshal(req)
+	require.NoError(t, err)
+	return string(b)
+}
+
+func TestMapLemonsqueezyToPayment(t *testing.T) {
+	var req lemonsqueezy.WebhookRequestSubscriptionInvoice
+	require.NoError(t, json.Unmarshal([]byte(invoicePayload(t, "subscription_payment_failed", 42)), &req))
+
+	p := subscriptions.MapLemonsqueezyToPayment("subscription_payment_failed", req)
+
+	assert.Equal(t, 77, p.InvoiceID)
+	assert.Equal(t, "subscription_payment_failed", p.EventName)
+	assert.Equal(t, 42, p.SubscriptionID)
+	assert.Equal(t, "user-123", p.UserID)
+	assert.Equal(t, "renewal", p.BillingReason)
+	assert.Equal(t, "pending", p.Status)
+	assert.Equal(t, "USD", p.Currency)
+	assert.Equal(t, 999, p.Total)
+	assert.Equal(t, 999, p.TotalUSD)
+	assert.Equal(t, time.Date(2024, 7, 15, 0, 0, 0, 0, time.UTC).Unix(), p.CreatedAt)
+}
+
+func TestRouteWebhook_PaymentFailed(t *testing.T) {
+	body := invoicePayload(t, "subscription_payment_failed", 42)
+
+	verifier := mocks.NewMockWebhookVerifier(t)
+	verifier.EXPECT().VerifyWebhook(mock.Anything, "valid-sig", []byte(body)).Return(true)
+
+	writer := mocks.NewMockSubscriptionWriter(t)
+	writer.EXPECT().
+		InsertPayment(mock.Anything, mock.MatchedBy(func(p *subscriptions.Payment) bool {
+			return p.InvoiceID == 77 && p.EventName == "subscription_payment_failed"
+		})).
+		Return(nil)
+	writer.EXPECT().
+		UpsertSubscription(mock.Anything, mock.MatchedBy(func(s *subscriptions.Subscription) bool {
+			return s.ID == 42 && s.UserID == "user-123" && s.Status == "past_due"
+		})).
+		Return(nil)
+
+	observer := mocks.NewMockSubscriptionObserver(t)
+	observer.EXPECT().
+		OnSubscriptionChange(mock.Anything, "user-123", 300, true, mock.Anything, mock.Anything).
+		Return(nil)
+	observer.EXPECT().
+		OnPayment(mock.Anything, "user-123", subscriptions.PaymentFailed, mock.Anything,
+			mock.MatchedBy(func(p *subscriptions.Payment) bool { return p.InvoiceID == 77 })).
+		Return(nil)
+
+	fetcher := mocks.NewMockSubscriptionFetcher(t)
+	fetcher.EXPECT().GetSubscription(mock.Anything, 42).Return(&subscriptions.Subscription{
+		ID:        42,
+		ProductID: 300,
+		Status:    "past_due",
+		PriceID:   555,
+	}, nil)
+
+	pricing := mocks.NewMockPriceFetcher(t)
+	pricing.EXPECT().GetPrice(mock.Anything, 555).Return(&subscriptions.PriceInfo{UnitPrice: 999}, nil)
+	dedup := newDedup(t, false)
+	dedup.EXPECT().MarkWebhookProcessed(mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	events := newEvents(t, subscriptions.WebhookResultProcessed)
+	queue := mocks.NewMockWebhookEnqueuer(t)
+	route := subscriptions.NewRouteWebhook(verifier, pricing, fetcher, writer, dedup, events, queue, newTx(t), observer)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/webhook/lemonsqueezy", strings.NewReader(body))
+	req.Header.Set("X-Signature", "valid-sig")
+	req.Header.Set("X-Event-Name", "subscription_payment_failed")
+	code, err := serveWebhook(t, route, queue, req)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.NoError(t, err)
+}
+
+func TestRouteWebhook_PaymentMissingSubscriptionID(t *testing.T) {
+	body := invoicePayload(t, "subscription_payment_success", 0)
+
+	verifier := mocks.NewMockWebhookVerifier(t)
+	verifier.EXPECT().VerifyWebhook(mock.Anything, "valid-sig", []byte(body)).Return(true)
+
+	writer := mocks.NewMockSubscriptionWriter(t)
+	observer := mocks.NewMockSubscriptionObserver(t)
+	pricing := mocks.NewMockPriceFetcher(t)
+	fetcher := mocks.NewMockSubscriptionFetcher(t)
+	dedup := newDedup(t, false)
+	events := newEvents(t, subscriptions.WebhookResultRejected)
+	queue := mocks.NewMockWebhookEnqueuer(t)
+	route := subscriptions.NewRouteWebhook(verifier, pricing, fetcher, writer, dedup, events, queue, newTx(t), observer)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/webhook/lemonsqueezy", strings.NewReader(body))
+	req.Header.Set("X-Signature", "valid-sig")
+	req.Header.Set("X-Event-Name", "subscription_payment_success")
+	code, err := serveWebhook(t, route, queue, req)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.NoError(t, err)
+}
+
+func TestRouteWebhook_PaymentFetchError(t *testing.T) {
+	body := invoicePayload(t, "subscription_payment_recovered", 42)
+
+	verifier := mocks.NewMockWebhookVerifier(t)
+	verifier.EXPECT().VerifyWebhook(mock.Anything, "valid-sig", []byte(body)).Return(true)
+
+	// Nothing is stored until the subscription state is fetched.
+	writer := mocks.NewMockSubscriptionWriter(t)
+
+	observer := mocks.NewMockSubscriptionObserver(t)
+	pricing := mocks.NewMockPriceFetcher(t)
+	fetcher := mocks.NewMockSubscriptionFetcher(t)
+	fetcher.EXPECT().GetSubscription(mock.Anything, 42).Return(nil, assert.AnError)
+	dedup := newDedup(t, false)
+	events := newEvents(t, subscriptions.WebhookResultFailed)
+	queue := mocks.NewMockWebhookEnqueuer(t)
+	route := subscriptions.NewRouteWebhook(verifier, pricing, fetcher, writer, dedup, events, queue, newTx(t), observer)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/webhook/lemonsqueezy", strings.NewReader(body))
+	req.Header.Set("X-Signature", "valid-sig")
+	req.Header.Set("X-Event-Name", "subscription_payment_recovered")
+	code, err := serveWebhook(t, route, queue, req)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Error(t, err)
+}
+
+// --- Idempotency and ordering ---
+
+func TestRouteWebhook_Duplicate(t *testing.T) {
+	body := validWebhookPayload(t, "subscription_updated")
+
+	verifier := mocks.NewMockWebhookVerifier(t)
+	verifier.EXPECT().VerifyWebhook(mock.Anything, "valid-sig", []byte(body)).Return(true)
+
+	writer := mocks.NewMockSubscriptionWriter(t)
+	observer := mocks.NewMockSubscriptionObserver(t)
+	pricing := mocks.NewMockPriceFetcher(t)
+	fetcher := mocks.NewMockSubscriptionFetcher(t)
+	dedup := newDedup(t, true)
+	events := newEvents(t, subscriptions.WebhookResultDuplicate)
+	queue := mocks.NewMockWebhookEnqueuer(t)
+	route := subscriptions.NewRouteWebhook(verifier, pricing, fetcher, writer, dedup, events, queue, newTx(t), observer)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/webhook/lemonsqueezy", strings.NewReader(body))
+	req.Header.Set("X-Signature", "valid-sig")
+	req.Header.Set("X-Event-Name", "subscription_updated")
+	code, err := serveWebhook(t, route, queue, req)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.NoError(t, err)
+}
+
+func TestRouteWebhook_DedupError(t *testing.T) {
+	body := validWebhookPayload(t, "subscription_updated")
+
+	verifier := mocks.NewMockWebhookVerifier(t)
+	verifier.EXPECT().VerifyWebhook(mock.Anything, "valid-sig", []byte(body)).Return(true)
+
+	writer := mocks.NewMockSubscriptionWriter(t)
+	observer := mocks.NewMockSubscriptionObserver(t)
+	pricing := mocks.NewMockPriceFetcher(t)
+	fetcher := mocks.NewMockSubscriptionFetcher(t)
+	dedup := mocks.NewMockWebhookDeduplicator(t)
+	dedup.EXPECT().IsWebhookProcessed(mock.Anything, mock.Anything).Return(false, assert.AnError)
+	events := newEvents(t, subscriptions.WebhookResultFailed)
+	queue := mocks.NewMockWebhookEnqueuer(t)
+	route := subscriptions.NewRouteWebhook(verifier, pricing, fetcher, writer, dedup, events, queue, newTx(t), observer)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/webhook/lemonsqueezy", strings.NewReader(body))
+	req.Header.Set("X-Signature", "valid-sig")
+	req.Header.Set("X-Event-Name", "subscription_updated")
+	code, err := serveWebhook(t, route, queue, req)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Error(t, err)
+}
+
+func TestRouteWebhook_MarkProcessedError(t *testing.T) {
+	body := validWebhookPayload(t, "subscription_updated")
+
+	verifier := mocks.NewMockWebhookVerifier(t)
+	verifier.EXPECT().VerifyWebhook(mock.Anything, "valid-sig", []byte(body)).Return(true)
+
+	writer := mocks.NewMockSubscriptionWriter(t)
+	writer.EXPECT().UpsertSubscription(mock.Anything, mock.Anything).Return(nil)
+
+	observer := mocks.NewMockSubscriptionObserver(t)
+	observer.EXPECT().
+		OnSubscriptionChange(mock.Anything, "user-123", 300, true, mock.Anything, mock.Anything).
+		Return(nil)
+
+	pricing := mocks.NewMockPriceFetcher(t)
+	pricing.EXPECT().GetPrice(mock.Anything, 555).Return(&subscriptions.PriceInfo{UnitPrice: 999}, nil)
+	fetcher := mocks.NewMockSubscriptionFetcher(t)
+	dedup := newDedup(t, false)
+	dedup.EXPECT().MarkWebhookProcessed(mock.Anything, mock.Anything, "subscription_updated").Return(assert.AnError)
+	// The transaction rolls back and the queued job is retried.
+	events := newEvents(t, subscriptions.WebhookResultFailed)
+	queue := mocks.NewMockWebhookEnqueuer(t)
+	route := subscriptions.NewRouteWebhook(verifier, pricing, fetcher, writer, dedup, events, queue, newTx(t), observer)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/webhook/lemonsqueezy", strings.NewReader(body))
+	req.Header.Set("X-Signature", "valid-sig")
+	req.Header.Set("X-Event-Name", "subscription_updated")
+	code, err := serveWebhook(t, route, queue, req)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Error(t, err)
+}
+
+func TestRouteWebhook_OutOfOrder(t *testing.T) {
+	body := validWebhookPayload(t, "subscription_updated")
+
+	verifier := mocks.NewMockWebhookVerifier(t)
+	verifier.EXPECT().VerifyWebhook(mock.Anything, "valid-sig", []byte(body)).Return(true)
+
+	writer := mocks.NewMockSubscriptionWriter(t)
+	writer.EXPECT().UpsertSubscription(mock.Anything, mock.Anything).Return(subscriptions.ErrStaleSubscription)
+
+	// Observer must not be notified about a stale state.
+	observer := mocks.NewMockSubscriptionObserver(t)
+
+	pricing := mocks.NewMockPriceFetcher(t)
+	pricing.EXPECT().GetPrice(mock.Anything, 555).Return(&subscriptions.PriceInfo{UnitPrice: 999}, nil)
+	fetcher := mocks.NewMockSubscriptionFetcher(t)
+	dedup := newDedup(t, false)
+	dedup.EXPECT().MarkWebhookProcessed(mock.Anything, mock.Anything, "subscription_updated").Return(nil)
+	events := newEvents(t, subscriptions.WebhookResultOutOfOrder)
+	queue := mocks.NewMockWebhookEnqueuer(t)
+	route := subscriptions.NewRouteWebhook(verifier, pricing, fetcher, writer, dedup, events, queue, newTx(t), observer)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/webhook/lemonsqueezy", strings.NewReader(body))
+	req.Header.Set("X-Signature", "valid-sig")
+	req.Header.Set("X-Event-Name", "subscription_updated")
+	code, err := serveWebhook(t, route, queue, req)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.NoError(t, err)
+}
+
+// --- Asynchronous processing ---
+
+func TestRouteWebhook_EnqueueError(t *testing.T) {
+	body := validWebhookPayload(t, "subscription_updated")
+
+	verifier := mocks.NewMockWebhookVerifier(t)
+	verifier.EXPECT().VerifyWebhook(mock.Anything, "valid-sig", []byte(body)).Return(true)
+
+	events := mocks.NewMockWebhookEventRecorder(t)
+	events.EXPECT().InsertWebhookEvent(mock.Anything, mock.Anything).Return(nil)
+	queue := mocks.NewMockWebhookEnqueuer(t)
+	queue.EXPECT().EnqueueWebhookEvent(mock.Anything, mock.Anything).Return(assert.AnError)
+	route := subscriptions.NewRouteWebhook(
+		verifier,
+		mocks.NewMockPriceFetcher(t),
+		mocks.NewMockSubscriptionFetcher(t),
+		mocks.NewMockSubscriptionWriter(t),
+		mocks.NewMockWebhookDeduplicator(t),
+		events,
+		queue,
+		mocks.NewMockTransactor(t),
+		mocks.NewMockSubscriptionObserver(t),
+	)
 
 	req := httptest.NewRequest(http.MethodPost, "/v1/webhook/lemonsqueezy", strings.NewReader(body))
 	req.Header.Set("X-Signature", "valid-sig")
 	req.Header.Set("X-Event-Name", "subscription_updated")
 	w := httptest.NewRecorder()
-	handler.ServeHTTP(w, req)
+	route.Handler().ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestRouteWebhook_HandleJob_AlreadySettled(t *testing.T) {
+	events := mocks.NewMockWebhookEventRecorder(t)
+	events.EXPECT().
+		GetWebhookEvent(mock.Anything, "01").
+		Return(&subscriptions.WebhookEvent{ID: "01", Result: subscriptions.WebhookResultProcessed}, nil)
+	route := subscriptions.NewRouteWebhook(
+		mocks.NewMockWebhookVerifier(t),
+		mocks.NewMockPriceFetcher(t),
+		mocks.NewMockSubscriptionFetcher(t),
+		mocks.NewMockSubscriptionWriter(t),
+		mocks.NewMockWebhookDeduplicator(t),
+		events,
+		mocks.NewMockWebhookEnqueuer(t),
+		mocks.NewMockTransactor(t),
+		mocks.NewMockSubscriptionObserver(t),
+	)
+
+	err := route.HandleJob(context.Background(), []byte(`{"event_id":"01"}`))
+	assert.NoError(t, err)
+}
+
+func TestRouteWebhook_HandleJob_SerializesPerUser(t *testing.T) {
+	body := validWebhookPayload(t, "subscription_updated")
+
+	events := mocks.NewMockWebhookEventRecorder(t)
+	events.EXPECT().
+		GetWebhookEvent(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, id string) (*subscriptions.WebhookEvent, error) {
+			return &subscriptions.WebhookEvent{
+				ID:        id,
+				Provider:  "lemonsqueezy",
+				EventName: "subscription_updated",
+				Body:      []byte(body),
+				Result:    subscriptions.WebhookResultPending,
+			}, nil
+		})
+	events.EXPECT().
+		UpdateWebhookEventResult(mock.Anything, mock.MatchedBy(func(e *subscriptions.WebhookEvent) bool {
+			return e.Result == subscriptions.WebhookResultProcessed
+		})).
+		Return(nil)
+
+	writer := mocks.NewMockSubscriptionWriter(t)
+	writer.EXPECT().UpsertSubscription(mock.Anything, mock.Anything).Return(nil)
+
+	var mu sync.Mutex
+	running, overlapped := 0, false
+	observer := mocks.NewMockSubscriptionObserver(t)
+	observer.EXPECT().
+		OnSubscriptionChange(mock.Anything, "user-123", 300, true, mock.Anything, mock.Anything).
+		RunAndReturn(func(context.Context, string, int, bool, string, any) error {
+			mu.Lock()
+			running++
+			overlapped = overlapped || running > 1
+			mu.Unlock()
+			time.Sleep(10 * time.Millisecond)
+			mu.Lock()
+			running--
+			mu.Unlock()
+			return nil
+		})
+
+	pricing := mocks.NewMockPriceFetcher(t)
+	pricing.EXPECT().GetPrice(mock.Anything, 555).Return(&subscriptions.PriceInfo{UnitPrice: 999}, nil)
+	dedup := newDedup(t, false)
+	dedup.EXPECT().MarkWebhookProcessed(mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	route := subscriptions.NewRouteWebhook(
+		mocks.NewMockWebhookVerifier(t),
+		pricing,
+		mocks.NewMockSubscriptionFetcher(t),
+		writer,
+		dedup,
+		events,
+		mocks.NewMockWebhookEnqueuer(t),
+		newTx(t),
+		observer,
+	)
+
+	var wg sync.WaitGroup
+	for i := range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			body, _ := json.Marshal(map[string]string{"event_id": strconv.Itoa(i)})
+			assert.NoError(t, route.HandleJob(context.Background(), body))
+		}()
+	}
+	wg.Wait()
+
+	assert.False(t, overlapped, "plan changes for the same user overlapped")
+}
+
+func TestRouteWebhook_HandleJob_DoesNotLogPayload(t *testing.T) {
+	body := strings.Replace(
+		webhookPayload(t, "subscription_created", nil),
+		`"user_email":""`, `"user_email":"jane@example.com"`, 1,
+	)
+	require.Contains(t, body, "jane@example.com")
+
+	events := mocks.NewMockWebhookEventRecorder(t)
+	events.EXPECT().
+		GetWebhookEvent(mock.Anything, "01").
+		Return(&subscriptions.WebhookEvent{
+			ID:        "01",
+			Provider:  "lemonsqueezy",
+			EventName: "subscription_created",
+			Body:      []byte(body),
+			Result:    subscriptions.WebhookResultPending,
+		}, nil)
+	events.EXPECT().
+		UpdateWebhookEventResult(mock.Anything, mock.MatchedBy(func(e *subscriptions.WebhookEvent) bool {
+			return e.Result == subscriptions.WebhookResultRejected
+		})).
+		Return(nil)
+	route := subscriptions.NewRouteWebhook(
+		mocks.NewMockWebhookVerifier(t),
+		mocks.NewMockPriceFetcher(t),
+		mocks.NewMockSubscriptionFetcher(t),
+		mocks.NewMockSubscriptionWriter(t),
+		newDedup(t, false),
+		events,
+		mocks.NewMockWebhookEnqueuer(t),
+		newTx(t),
+		mocks.NewMockSubscriptionObserver(t),
+	)
+
+	var logs bytes.Buffer
+	log := slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	ctx := logger.WithLogger(context.Background(), log)
+	require.NoError(t, route.HandleJob(ctx, []byte(`{"event_id":"01"}`)))
 
-	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, logs.String(), "rejected webhook")
+	assert.NotContains(t, logs.String(), "jane@example.com")
 }
@@ -0,0 +1,43 @@
+package subscriptions
+
+import "sync"
+
+// userLocks serializes work per user, so concurrent webhooks for the same
+// user apply their plan changes one after the other. The zero value is ready
+// to use.
+type userLocks struct {
+	mu    sync.Mutex
+	locks map[string]*userLock
+}
+
+type userLock struct {
+	mu   sync.Mutex
+	refs int // Goroutines holding or waiting for mu
+}
+
+// lock blocks until no other goroutine holds the lock of userID, and returns
+// the function releasing it.
+func (l *userLocks) lock(userID string) (unlock func()) {
+	l.mu.Lock()
+	if l.locks == nil {
+		l.locks = map[string]*userLock{}
+	}
+	ul := l.locks[userID]
+	if ul == nil {
+		ul = &userLock{}
+		l.locks[userID] = ul
+	}
+	ul.refs++
+	l.mu.Unlock()
+
+	ul.mu.Lock()
+	return func() {
+		ul.mu.Unlock()
+		l.mu.Lock()
+		ul.refs--
+		if ul.refs == 0 {
+			delete(l.locks, userID)
+		}
+		l.mu.Unlock()
+	}
+}
@@ -0,0 +1,390 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	config "github.com/grantsy/grantsy/internal/infra/config"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockEntitlementService is an autogenerated mock type for the EntitlementService type
+type MockEntitlementService struct {
+	mock.Mock
+}
+
+type MockEntitlementService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockEntitlementService) EXPECT() *MockEntitlementService_Expecter {
+	return &MockEntitlementService_Expecter{mock: &_m.Mock}
+}
+
+// DiffPlanFeatures provides a mock function with given fields: fromPlanID, toPlanID
+func (_m *MockEntitlementService) DiffPlanFeatures(fromPlanID string, toPlanID string) ([]string, []string) {
+	ret := _m.Called(fromPlanID, toPlanID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DiffPlanFeatures")
+	}
+
+	var r0 []string
+	var r1 []string
+	if rf, ok := ret.Get(0).(func(string, string) ([]string, []string)); ok {
+		return rf(fromPlanID, toPlanID)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) []string); ok {
+		r0 = rf(fromPlanID, toPlanID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) []string); ok {
+		r1 = rf(fromPlanID, toPlanID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]string)
+		}
+	}
+
+	return r0, r1
+}
+
+// MockEntitlementService_DiffPlanFeatures_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DiffPlanFeatures'
+type MockEntitlementService_DiffPlanFeatures_Call struct {
+	*mock.Call
+}
+
+// DiffPlanFeatures is a helper method to define mock.On call
+//   - fromPlanID string
+//   - toPlanID string
+func (_e *MockEntitlementService_Expecter) DiffPlanFeatures(fromPlanID interface{}, toPlanID interface{}) *MockEntitlementService_DiffPlanFeatures_Call {
+	return &MockEntitlementService_DiffPlanFeatures_Call{Call: _e.mock.On("DiffPlanFeatures", fromPlanID, toPlanID)}
+}
+
+func (_c *MockEntitlementService_DiffPlanFeatures_Call) Run(run func(fromPlanID string, toPlanID string)) *MockEntitlementService_DiffPlanFeatures_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockEntitlementService_DiffPlanFeatures_Call) Return(gained []string, lost []string) *MockEntitlementService_DiffPlanFeatures_Call {
+	_c.Call.Return(gained, lost)
+	return _c
+}
+
+func (_c *MockEntitlementService_DiffPlanFeatures_Call) RunAndReturn(run func(string, string) ([]string, []string)) *MockEntitlementService_DiffPlanFeatures_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFeature provides a mock function with given fields: featureID
+func (_m *MockEntitlementService) GetFeature(featureID string) *config.FeatureConfig {
+	ret := _m.Called(featureID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFeature")
+	}
+
+	var r0 *config.FeatureConfig
+	if rf, ok := ret.Get(0).(func(string) *config.FeatureConfig); ok {
+		r0 = rf(featureID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*config.FeatureConfig)
+		}
+	}
+
+	return r0
+}
+
+// MockEntitlementService_GetFeature_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFeature'
+type MockEntitlementService_GetFeature_Call struct {
+	*mock.Call
+}
+
+// GetFeature is a helper method to define mock.On call
+//   - featureID string
+func (_e *MockEntitlementService_Expecter) GetFeature(featureID interface{}) *MockEntitlementService_GetFeature_Call {
+	return &MockEntitlementService_GetFeature_Call{Call: _e.mock.On("GetFeature", featureID)}
+}
+
+func (_c *MockEntitlementService_GetFeature_Call) Run(run func(featureID string)) *MockEntitlementService_GetFeature_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockEntitlementService_GetFeature_Call) Return(_a0 *config.FeatureConfig) *MockEntitlementService_GetFeature_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockEntitlementService_GetFeature_Call) RunAndReturn(run func(string) *config.FeatureConfig) *MockEntitlementService_GetFeature_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPlan provides a mock function with given fields: planID
+func (_m *MockEntitlementService) GetPlan(planID string) *config.PlanConfig {
+	ret := _m.Called(planID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPlan")
+	}
+
+	var r0 *config.PlanConfig
+	if rf, ok := ret.Get(0).(func(string) *config.PlanConfig); ok {
+		r0 = rf(planID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*config.PlanConfig)
+		}
+	}
+
+	return r0
+}
+
+// MockEntitlementService_GetPlan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPlan'
+type MockEntitlementService_GetPlan_Call struct {
+	*mock.Call
+}
+
+// GetPlan is a helper method to define mock.On call
+//   - planID string
+func (_e *MockEntitlementService_Expecter) GetPlan(planID interface{}) *MockEntitlementService_GetPlan_Call {
+	return &MockEntitlementService_GetPlan_Call{Call: _e.mock.On("GetPlan", planID)}
+}
+
+func (_c *MockEntitlementService_GetPlan_Call) Run(run func(planID string)) *MockEntitlementService_GetPlan_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockEntitlementService_GetPlan_Call) Return(_a0 *config.PlanConfig) *MockEntitlementService_GetPlan_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockEntitlementService_GetPlan_Call) RunAndReturn(run func(string) *config.PlanConfig) *MockEntitlementService_GetPlan_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPlanUsers provides a mock function with given fields: planID
+func (_m *MockEntitlementService) GetPlanUsers(planID string) ([]string, bool) {
+	ret := _m.Called(planID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPlanUsers")
+	}
+
+	var r0 []string
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(string) ([]string, bool)); ok {
+		return rf(planID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []string); ok {
+		r0 = rf(planID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) bool); ok {
+		r1 = rf(planID)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// MockEntitlementService_GetPlanUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPlanUsers'
+type MockEntitlementService_GetPlanUsers_Call struct {
+	*mock.Call
+}
+
+// GetPlanUsers is a helper method to define mock.On call
+//   - planID string
+func (_e *MockEntitlementService_Expecter) GetPlanUsers(planID interface{}) *MockEntitlementService_GetPlanUsers_Call {
+	return &MockEntitlementService_GetPlanUsers_Call{Call: _e.mock.On("GetPlanUsers", planID)}
+}
+
+func (_c *MockEntitlementService_GetPlanUsers_Call) Run(run func(planID string)) *MockEntitlementService_GetPlanUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockEntitlementService_GetPlanUsers_Call) Return(userIDs []string, ok bool) *MockEntitlementService_GetPlanUsers_Call {
+	_c.Call.Return(userIDs, ok)
+	return _c
+}
+
+func (_c *MockEntitlementService_GetPlanUsers_Call) RunAndReturn(run func(string) ([]string, bool)) *MockEntitlementService_GetPlanUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserFeatures provides a mock function with given fields: userID
+func (_m *MockEntitlementService) GetUserFeatures(userID string) []string {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserFeatures")
+	}
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(string) []string); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
+// MockEntitlementService_GetUserFeatures_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserFeatures'
+type MockEntitlementService_GetUserFeatures_Call struct {
+	*mock.Call
+}
+
+// GetUserFeatures is a helper method to define mock.On call
+//   - userID string
+func (_e *MockEntitlementService_Expecter) GetUserFeatures(userID interface{}) *MockEntitlementService_GetUserFeatures_Call {
+	return &MockEntitlementService_GetUserFeatures_Call{Call: _e.mock.On("GetUserFeatures", userID)}
+}
+
+func (_c *MockEntitlementService_GetUserFeatures_Call) Run(run func(userID string)) *MockEntitlementService_GetUserFeatures_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockEntitlementService_GetUserFeatures_Call) Return(_a0 []string) *MockEntitlementService_GetUserFeatures_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockEntitlementService_GetUserFeatures_Call) RunAndReturn(run func(string) []string) *MockEntitlementService_GetUserFeatures_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserPlan provides a mock function with given fields: userID
+func (_m *MockEntitlementService) GetUserPlan(userID string) string {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserPlan")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// MockEntitlementService_GetUserPlan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserPlan'
+type MockEntitlementService_GetUserPlan_Call struct {
+	*mock.Call
+}
+
+// GetUserPlan is a helper method to define mock.On call
+//   - userID string
+func (_e *MockEntitlementService_Expecter) GetUserPlan(userID interface{}) *MockEntitlementService_GetUserPlan_Call {
+	return &MockEntitlementService_GetUserPlan_Call{Call: _e.mock.On("GetUserPlan", userID)}
+}
+
+func (_c *MockEntitlementService_GetUserPlan_Call) Run(run func(userID string)) *MockEntitlementService_GetUserPlan_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockEntitlementService_GetUserPlan_Call) Return(_a0 string) *MockEntitlementService_GetUserPlan_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockEntitlementService_GetUserPlan_Call) RunAndReturn(run func(string) string) *MockEntitlementService_GetUserPlan_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResolvePlanFromProduct provides a mock function with given fields: productID
+func (_m *MockEntitlementService) ResolvePlanFromProduct(productID int) string {
+	ret := _m.Called(productID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResolvePlanFromProduct")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(int) string); ok {
+		r0 = rf(productID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// MockEntitlementService_ResolvePlanFromProduct_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResolvePlanFromProduct'
+type MockEntitlementService_ResolvePlanFromProduct_Call struct {
+	*mock.Call
+}
+
+// ResolvePlanFromProduct is a helper method to define mock.On call
+//   - productID int
+func (_e *MockEntitlementService_Expecter) ResolvePlanFromProduct(productID interface{}) *MockEntitlementService_ResolvePlanFromProduct_Call {
+	return &MockEntitlementService_ResolvePlanFromProduct_Call{Call: _e.mock.On("ResolvePlanFromProduct", productID)}
+}
+
+func (_c *MockEntitlementService_ResolvePlanFromProduct_Call) Run(run func(productID int)) *MockEntitlementService_ResolvePlanFromProduct_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int))
+	})
+	return _c
+}
+
+func (_c *MockEntitlementService_ResolvePlanFromProduct_Call) Return(_a0 string) *MockEntitlementService_ResolvePlanFromProduct_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockEntitlementService_ResolvePlanFromProduct_Call) RunAndReturn(run func(int) string) *MockEntitlementService_ResolvePlanFromProduct_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockEntitlementService creates a new instance of MockEntitlementService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockEntitlementService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockEntitlementService {
+	mock := &MockEntitlementService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
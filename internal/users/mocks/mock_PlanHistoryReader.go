@@ -0,0 +1,98 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entitlements "github.com/grantsy/grantsy/internal/entitlements"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockPlanHistoryReader is an autogenerated mock type for the PlanHistoryReader type
+type MockPlanHistoryReader struct {
+	mock.Mock
+}
+
+type MockPlanHistoryReader_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockPlanHistoryReader) EXPECT() *MockPlanHistoryReader_Expecter {
+	return &MockPlanHistoryReader_Expecter{mock: &_m.Mock}
+}
+
+// ListTransitions provides a mock function with given fields: ctx, userID, cursor, limit
+func (_m *MockPlanHistoryReader) ListTransitions(ctx context.Context, userID string, cursor *entitlements.TransitionCursor, limit int) ([]entitlements.PlanTransition, error) {
+	ret := _m.Called(ctx, userID, cursor, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTransitions")
+	}
+
+	var r0 []entitlements.PlanTransition
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *entitlements.TransitionCursor, int) ([]entitlements.PlanTransition, error)); ok {
+		return rf(ctx, userID, cursor, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *entitlements.TransitionCursor, int) []entitlements.PlanTransition); ok {
+		r0 = rf(ctx, userID, cursor, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entitlements.PlanTransition)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *entitlements.TransitionCursor, int) error); ok {
+		r1 = rf(ctx, userID, cursor, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPlanHistoryReader_ListTransitions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTransitions'
+type MockPlanHistoryReader_ListTransitions_Call struct {
+	*mock.Call
+}
+
+// ListTransitions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - cursor *entitlements.TransitionCursor
+//   - limit int
+func (_e *MockPlanHistoryReader_Expecter) ListTransitions(ctx interface{}, userID interface{}, cursor interface{}, limit interface{}) *MockPlanHistoryReader_ListTransitions_Call {
+	return &MockPlanHistoryReader_ListTransitions_Call{Call: _e.mock.On("ListTransitions", ctx, userID, cursor, limit)}
+}
+
+func (_c *MockPlanHistoryReader_ListTransitions_Call) Run(run func(ctx context.Context, userID string, cursor *entitlements.TransitionCursor, limit int)) *MockPlanHistoryReader_ListTransitions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*entitlements.TransitionCursor), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *MockPlanHistoryReader_ListTransitions_Call) Return(_a0 []entitlements.PlanTransition, _a1 error) *MockPlanHistoryReader_ListTransitions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPlanHistoryReader_ListTransitions_Call) RunAndReturn(run func(context.Context, string, *entitlements.TransitionCursor, int) ([]entitlements.PlanTransition, error)) *MockPlanHistoryReader_ListTransitions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockPlanHistoryReader creates a new instance of MockPlanHistoryReader. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockPlanHistoryReader(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockPlanHistoryReader {
+	mock := &MockPlanHistoryReader{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,96 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	subscriptions "github.com/grantsy/grantsy/internal/subscriptions"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockSubscriptionLister is an autogenerated mock type for the SubscriptionLister type
+type MockSubscriptionLister struct {
+	mock.Mock
+}
+
+type MockSubscriptionLister_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSubscriptionLister) EXPECT() *MockSubscriptionLister_Expecter {
+	return &MockSubscriptionLister_Expecter{mock: &_m.Mock}
+}
+
+// ListUserSubscriptions provides a mock function with given fields: ctx, filter
+func (_m *MockSubscriptionLister) ListUserSubscriptions(ctx context.Context, filter subscriptions.UserFilter) ([]subscriptions.Subscription, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUserSubscriptions")
+	}
+
+	var r0 []subscriptions.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, subscriptions.UserFilter) ([]subscriptions.Subscription, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, subscriptions.UserFilter) []subscriptions.Subscription); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]subscriptions.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, subscriptions.UserFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionLister_ListUserSubscriptions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUserSubscriptions'
+type MockSubscriptionLister_ListUserSubscriptions_Call struct {
+	*mock.Call
+}
+
+// ListUserSubscriptions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter subscriptions.UserFilter
+func (_e *MockSubscriptionLister_Expecter) ListUserSubscriptions(ctx interface{}, filter interface{}) *MockSubscriptionLister_ListUserSubscriptions_Call {
+	return &MockSubscriptionLister_ListUserSubscriptions_Call{Call: _e.mock.On("ListUserSubscriptions", ctx, filter)}
+}
+
+func (_c *MockSubscriptionLister_ListUserSubscriptions_Call) Run(run func(ctx context.Context, filter subscriptions.UserFilter)) *MockSubscriptionLister_ListUserSubscriptions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(subscriptions.UserFilter))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionLister_ListUserSubscriptions_Call) Return(_a0 []subscriptions.Subscription, _a1 error) *MockSubscriptionLister_ListUserSubscriptions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionLister_ListUserSubscriptions_Call) RunAndReturn(run func(context.Context, subscriptions.UserFilter) ([]subscriptions.Subscription, error)) *MockSubscriptionLister_ListUserSubscriptions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSubscriptionLister creates a new instance of MockSubscriptionLister. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSubscriptionLister(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSubscriptionLister {
+	mock := &MockSubscriptionLister{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
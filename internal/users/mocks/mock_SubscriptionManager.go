@@ -0,0 +1,334 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	subscriptions "github.com/grantsy/grantsy/internal/subscriptions"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockSubscriptionManager is an autogenerated mock type for the SubscriptionManager type
+type MockSubscriptionManager struct {
+	mock.Mock
+}
+
+type MockSubscriptionManager_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSubscriptionManager) EXPECT() *MockSubscriptionManager_Expecter {
+	return &MockSubscriptionManager_Expecter{mock: &_m.Mock}
+}
+
+// CancelSubscription provides a mock function with given fields: ctx, subscriptionID
+func (_m *MockSubscriptionManager) CancelSubscription(ctx context.Context, subscriptionID int) (*subscriptions.Subscription, error) {
+	ret := _m.Called(ctx, subscriptionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelSubscription")
+	}
+
+	var r0 *subscriptions.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*subscriptions.Subscription, error)); ok {
+		return rf(ctx, subscriptionID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *subscriptions.Subscription); ok {
+		r0 = rf(ctx, subscriptionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*subscriptions.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, subscriptionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionManager_CancelSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelSubscription'
+type MockSubscriptionManager_CancelSubscription_Call struct {
+	*mock.Call
+}
+
+// CancelSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subscriptionID int
+func (_e *MockSubscriptionManager_Expecter) CancelSubscription(ctx interface{}, subscriptionID interface{}) *MockSubscriptionManager_CancelSubscription_Call {
+	return &MockSubscriptionManager_CancelSubscription_Call{Call: _e.mock.On("CancelSubscription", ctx, subscriptionID)}
+}
+
+func (_c *MockSubscriptionManager_CancelSubscription_Call) Run(run func(ctx context.Context, subscriptionID int)) *MockSubscriptionManager_CancelSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionManager_CancelSubscription_Call) Return(_a0 *subscriptions.Subscription, _a1 error) *MockSubscriptionManager_CancelSubscription_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionManager_CancelSubscription_Call) RunAndReturn(run func(context.Context, int) (*subscriptions.Subscription, error)) *MockSubscriptionManager_CancelSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ChangeSubscriptionVariant provides a mock function with given fields: ctx, subscriptionID, variantID, invoiceImmediately
+func (_m *MockSubscriptionManager) ChangeSubscriptionVariant(ctx context.Context, subscriptionID int, variantID int, invoiceImmediately bool) (*subscriptions.Subscription, error) {
+	ret := _m.Called(ctx, subscriptionID, variantID, invoiceImmediately)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangeSubscriptionVariant")
+	}
+
+	var r0 *subscriptions.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, bool) (*subscriptions.Subscription, error)); ok {
+		return rf(ctx, subscriptionID, variantID, invoiceImmediately)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, bool) *subscriptions.Subscription); ok {
+		r0 = rf(ctx, subscriptionID, variantID, invoiceImmediately)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*subscriptions.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, bool) error); ok {
+		r1 = rf(ctx, subscriptionID, variantID, invoiceImmediately)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionManager_ChangeSubscriptionVariant_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChangeSubscriptionVariant'
+type MockSubscriptionManager_ChangeSubscriptionVariant_Call struct {
+	*mock.Call
+}
+
+// ChangeSubscriptionVariant is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subscriptionID int
+//   - variantID int
+//   - invoiceImmediately bool
+func (_e *MockSubscriptionManager_Expecter) ChangeSubscriptionVariant(ctx interface{}, subscriptionID interface{}, variantID interface{}, invoiceImmediately interface{}) *MockSubscriptionManager_ChangeSubscriptionVariant_Call {
+	return &MockSubscriptionManager_ChangeSubscriptionVariant_Call{Call: _e.mock.On("ChangeSubscriptionVariant", ctx, subscriptionID, variantID, invoiceImmediately)}
+}
+
+func (_c *MockSubscriptionManager_ChangeSubscriptionVariant_Call) Run(run func(ctx context.Context, subscriptionID int, variantID int, invoiceImmediately bool)) *MockSubscriptionManager_ChangeSubscriptionVariant_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(int), args[3].(bool))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionManager_ChangeSubscriptionVariant_Call) Return(_a0 *subscriptions.Subscription, _a1 error) *MockSubscriptionManager_ChangeSubscriptionVariant_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionManager_ChangeSubscriptionVariant_Call) RunAndReturn(run func(context.Context, int, int, bool) (*subscriptions.Subscription, error)) *MockSubscriptionManager_ChangeSubscriptionVariant_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCustomerPortalURL provides a mock function with given fields: ctx, subscriptionID
+func (_m *MockSubscriptionManager) GetCustomerPortalURL(ctx context.Context, subscriptionID int) (string, error) {
+	ret := _m.Called(ctx, subscriptionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCustomerPortalURL")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (string, error)); ok {
+		return rf(ctx, subscriptionID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) string); ok {
+		r0 = rf(ctx, subscriptionID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, subscriptionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionManager_GetCustomerPortalURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCustomerPortalURL'
+type MockSubscriptionManager_GetCustomerPortalURL_Call struct {
+	*mock.Call
+}
+
+// GetCustomerPortalURL is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subscriptionID int
+func (_e *MockSubscriptionManager_Expecter) GetCustomerPortalURL(ctx interface{}, subscriptionID interface{}) *MockSubscriptionManager_GetCustomerPortalURL_Call {
+	return &MockSubscriptionManager_GetCustomerPortalURL_Call{Call: _e.mock.On("GetCustomerPortalURL", ctx, subscriptionID)}
+}
+
+func (_c *MockSubscriptionManager_GetCustomerPortalURL_Call) Run(run func(ctx context.Context, subscriptionID int)) *MockSubscriptionManager_GetCustomerPortalURL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionManager_GetCustomerPortalURL_Call) Return(_a0 string, _a1 error) *MockSubscriptionManager_GetCustomerPortalURL_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionManager_GetCustomerPortalURL_Call) RunAndReturn(run func(context.Context, int) (string, error)) *MockSubscriptionManager_GetCustomerPortalURL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PauseSubscription provides a mock function with given fields: ctx, subscriptionID, mode, resumesAt
+func (_m *MockSubscriptionManager) PauseSubscription(ctx context.Context, subscriptionID int, mode string, resumesAt *int64) (*subscriptions.Subscription, error) {
+	ret := _m.Called(ctx, subscriptionID, mode, resumesAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PauseSubscription")
+	}
+
+	var r0 *subscriptions.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, *int64) (*subscriptions.Subscription, error)); ok {
+		return rf(ctx, subscriptionID, mode, resumesAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, *int64) *subscriptions.Subscription); ok {
+		r0 = rf(ctx, subscriptionID, mode, resumesAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*subscriptions.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, string, *int64) error); ok {
+		r1 = rf(ctx, subscriptionID, mode, resumesAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionManager_PauseSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PauseSubscription'
+type MockSubscriptionManager_PauseSubscription_Call struct {
+	*mock.Call
+}
+
+// PauseSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subscriptionID int
+//   - mode string
+//   - resumesAt *int64
+func (_e *MockSubscriptionManager_Expecter) PauseSubscription(ctx interface{}, subscriptionID interface{}, mode interface{}, resumesAt interface{}) *MockSubscriptionManager_PauseSubscription_Call {
+	return &MockSubscriptionManager_PauseSubscription_Call{Call: _e.mock.On("PauseSubscription", ctx, subscriptionID, mode, resumesAt)}
+}
+
+func (_c *MockSubscriptionManager_PauseSubscription_Call) Run(run func(ctx context.Context, subscriptionID int, mode string, resumesAt *int64)) *MockSubscriptionManager_PauseSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(string), args[3].(*int64))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionManager_PauseSubscription_Call) Return(_a0 *subscriptions.Subscription, _a1 error) *MockSubscriptionManager_PauseSubscription_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionManager_PauseSubscription_Call) RunAndReturn(run func(context.Context, int, string, *int64) (*subscriptions.Subscription, error)) *MockSubscriptionManager_PauseSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResumeSubscription provides a mock function with given fields: ctx, subscriptionID
+func (_m *MockSubscriptionManager) ResumeSubscription(ctx context.Context, subscriptionID int) (*subscriptions.Subscription, error) {
+	ret := _m.Called(ctx, subscriptionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResumeSubscription")
+	}
+
+	var r0 *subscriptions.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*subscriptions.Subscription, error)); ok {
+		return rf(ctx, subscriptionID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *subscriptions.Subscription); ok {
+		r0 = rf(ctx, subscriptionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*subscriptions.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, subscriptionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionManager_ResumeSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResumeSubscription'
+type MockSubscriptionManager_ResumeSubscription_Call struct {
+	*mock.Call
+}
+
+// ResumeSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subscriptionID int
+func (_e *MockSubscriptionManager_Expecter) ResumeSubscription(ctx interface{}, subscriptionID interface{}) *MockSubscriptionManager_ResumeSubscription_Call {
+	return &MockSubscriptionManager_ResumeSubscription_Call{Call: _e.mock.On("ResumeSubscription", ctx, subscriptionID)}
+}
+
+func (_c *MockSubscriptionManager_ResumeSubscription_Call) Run(run func(ctx context.Context, subscriptionID int)) *MockSubscriptionManager_ResumeSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionManager_ResumeSubscription_Call) Return(_a0 *subscriptions.Subscription, _a1 error) *MockSubscriptionManager_ResumeSubscription_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionManager_ResumeSubscription_Call) RunAndReturn(run func(context.Context, int) (*subscriptions.Subscription, error)) *MockSubscriptionManager_ResumeSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSubscriptionManager creates a new instance of MockSubscriptionManager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSubscriptionManager(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSubscriptionManager {
+	mock := &MockSubscriptionManager{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
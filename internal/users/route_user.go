@@ -9,6 +9,7 @@ import (
 	"github.com/swaggest/openapi-go"
 	"github.com/swaggest/openapi-go/openapi31"
 
+	"github.com/grantsy/grantsy/internal/auth"
 	"github.com/grantsy/grantsy/internal/entitlements"
 	"github.com/grantsy/grantsy/internal/httptools"
 	"github.com/grantsy/grantsy/internal/infra/config"
@@ -20,9 +21,11 @@ import (
 // EntitlementService provides plan and feature data for users.
 type EntitlementService interface {
 	GetUserPlan(userID string) string
+	GetPlanUsers(planID string) (userIDs []string, ok bool)
 	GetPlan(planID string) *config.PlanConfig
 	GetFeature(featureID string) *config.FeatureConfig
 	GetUserFeatures(userID string) []string
+	DiffPlanFeatures(fromPlanID, toPlanID string) (gained, lost []string)
 	ResolvePlanFromProduct(productID int) string
 }
 
@@ -80,9 +83,11 @@ func NewRouteUser(entService EntitlementService, subRepo SubscriptionRepo) *Rout
 }
 
 func (route *RouteUser) Register(mux *http.ServeMux, r *openapi31.Reflector) {
-	mux.Handle("GET /v1/users/{user_id}",
-		valmid.Middleware[UserRequest]()(route.Handler()),
-	)
+	mux.Handle("GET /v1/users/{user_id}", httptools.Wrap(
+		route.Handler(),
+		auth.RequireUserScope(auth.ScopeUsersRead, auth.UserIDFromPath),
+		valmid.Middleware[UserRequest](),
+	))
 	RegisterUserSchema(r)
 }
 
@@ -100,10 +105,12 @@ func RegisterUserSchema(r *openapi31.Reflector) {
 	oa.AddErrorResponses(op)
 	op.SetSummary("Get user state")
 	op.SetDescription(
-		"Get the current state for a user. Always returns plan_id. Use ?expand=plan,features,subscription to include additional details.",
+		"Get the current state for a user. Always returns plan_id. Use ?expand=plan,features,subscription to include additional details. Publishable keys can only read the user in their user token.",
 	)
 	op.SetTags("Users")
-	op.AddSecurity("ApiKeyAuth")
+	op.AddSecurity("ApiKeyAuth", auth.ScopeUsersRead)
+	op.AddSecurity("BearerAuth", auth.ScopeUsersRead)
+	oa.AddUserTokenSecurity(op)
 	r.AddOperation(op)
 }
 
@@ -128,15 +135,7 @@ func (route *RouteUser) Handler() http.Handler {
 
 		if slices.Contains(input.Expand, UserExpandFeatures) {
 			featureIDs := route.entService.GetUserFeatures(input.UserID)
-			featureDTOs := make([]entitlements.Feature, 0, len(featureIDs))
-			for _, fID := range featureIDs {
-				if f := route.entService.GetFeature(fID); f != nil {
-					featureDTOs = append(featureDTOs, entitlements.ToFeature(*f))
-				} else {
-					featureDTOs = append(featureDTOs, entitlements.Feature{ID: fID})
-				}
-			}
-			resp.Features = httptools.Set(featureDTOs)
+			resp.Features = httptools.Set(toFeatures(route.entService, featureIDs))
 		}
 
 		if slices.Contains(input.Expand, UserExpandSubscription) {
@@ -158,3 +157,16 @@ func (route *RouteUser) Handler() http.Handler {
 		httptools.JSON(w, r, http.StatusOK, resp)
 	})
 }
+
+// toFeatures converts feature IDs to DTOs, keeping unknown IDs as bare features.
+func toFeatures(entService EntitlementService, featureIDs []string) []entitlements.Feature {
+	features := make([]entitlements.Feature, 0, len(featureIDs))
+	for _, fID := range featureIDs {
+		if f := entService.GetFeature(fID); f != nil {
+			features = append(features, entitlements.ToFeature(*f))
+		} else {
+			features = append(features, entitlements.Feature{ID: fID})
+		}
+	}
+	return features
+}
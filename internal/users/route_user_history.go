@@ -0,0 +1,150 @@
+package users
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/iamolegga/valmid"
+	"github.com/swaggest/openapi-go"
+	"github.com/swaggest/openapi-go/openapi31"
+
+	"github.com/grantsy/grantsy/internal/auth"
+	"github.com/grantsy/grantsy/internal/entitlements"
+	"github.com/grantsy/grantsy/internal/httptools"
+	"github.com/grantsy/grantsy/internal/infra/logger"
+	oa "github.com/grantsy/grantsy/internal/openapi"
+	"github.com/grantsy/grantsy/internal/subscriptions"
+)
+
+// PlanHistoryReader reads the plan history of users.
+type PlanHistoryReader interface {
+	ListTransitions(
+		ctx context.Context,
+		userID string,
+		cursor *entitlements.TransitionCursor,
+		limit int,
+	) ([]entitlements.PlanTransition, error)
+}
+
+type UserHistoryRequest struct {
+	UserID string `in:"path=user_id"           path:"user_id" validate:"required"       description:"User ID to get the plan history for"`
+	Cursor string `in:"query=cursor"           query:"cursor"                           description:"Pagination cursor (next_cursor from the previous page)"`
+	Limit  int    `in:"query=limit;default=50" query:"limit"  validate:"min=1,max=200" description:"Maximum number of transitions to return"                default:"50"`
+}
+
+type UserHistoryResponse struct {
+	UserID      string           `json:"user_id"               description:"The user ID"                         required:"true"`
+	Transitions []PlanTransition `json:"transitions"           description:"Plan transitions, newest first"      required:"true" nullable:"false"`
+	NextCursor  string           `json:"next_cursor,omitempty" description:"Cursor for the next page, omitted on the last page"`
+}
+
+// PlanTransition is a subscription change in a user's plan history.
+type PlanTransition struct {
+	ID           string            `json:"id"           description:"Transition identifier"                                                           required:"true"`
+	PrevPlan     string            `json:"prev_plan"    description:"Plan before the change"                                                          required:"true"`
+	NewPlan      string            `json:"new_plan"     description:"Plan after the change, equal to prev_plan if the plan did not change"           required:"true"`
+	Active       bool              `json:"active"       description:"Whether the subscription grants access after the change"                        required:"true"`
+	Cause        string            `json:"cause"        description:"Provider event that changed the subscription (e.g. subscription_expired)"        required:"true"`
+	Subscription *UserSubscription `json:"subscription" description:"Subscription state after the change"                                            required:"true"`
+	CreatedAt    int64             `json:"created_at"   description:"Unix timestamp of the change, as reported by the billing provider"                 required:"true"`
+}
+
+type RouteUserHistory struct {
+	entService EntitlementService
+	history    PlanHistoryReader
+}
+
+func NewRouteUserHistory(entService EntitlementService, history PlanHistoryReader) *RouteUserHistory {
+	return &RouteUserHistory{entService: entService, history: history}
+}
+
+func (route *RouteUserHistory) Register(mux *http.ServeMux, r *openapi31.Reflector) {
+	mux.Handle("GET /v1/users/{user_id}/history", httptools.Wrap(
+		route.Handler(),
+		auth.RequireScope(auth.ScopeUsersRead),
+		valmid.Middleware[UserHistoryRequest](),
+	))
+	RegisterUserHistorySchema(r)
+}
+
+func RegisterUserHistorySchema(r *openapi31.Reflector) {
+	op, _ := r.NewOperationContext(http.MethodGet, "/v1/users/{user_id}/history")
+	op.AddReqStructure(new(UserHistoryRequest))
+	op.AddRespStructure(struct {
+		Data UserHistoryResponse `json:"data"`
+		Meta httptools.Meta      `json:"meta"`
+		_    struct{}            `title:"UserHistoryResponse"`
+	}{}, func(cu *openapi.ContentUnit) {
+		cu.HTTPStatus = http.StatusOK
+		cu.Description = "User plan history"
+	})
+	oa.AddErrorResponses(op)
+	op.SetSummary("Get user plan history")
+	op.SetDescription(
+		"List every subscription change applied to the user, newest first by the time of the change, with the plan before and after it, the provider event that caused it and the subscription state it left. Use next_cursor to fetch the next page.",
+	)
+	op.SetTags("Users")
+	op.AddSecurity("ApiKeyAuth", auth.ScopeUsersRead)
+	op.AddSecurity("BearerAuth", auth.ScopeUsersRead)
+	r.AddOperation(op)
+}
+
+func (route *RouteUserHistory) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		input := valmid.Get[UserHistoryRequest](r)
+
+		var cursor *entitlements.TransitionCursor
+		if input.Cursor != "" {
+			var err error
+			if cursor, err = entitlements.ParseTransitionCursor(input.Cursor); err != nil {
+				httptools.BadRequest(w, r, "Invalid cursor")
+				return
+			}
+		}
+
+		// Fetch one extra transition to know whether another page exists.
+		transitions, err := route.history.ListTransitions(r.Context(), input.UserID, cursor, input.Limit+1)
+		if err != nil {
+			logger.FromContext(r.Context()).
+				Error("failed to list plan history", "error", err, "user_id", input.UserID)
+			httptools.InternalError(w, r)
+			return
+		}
+
+		resp := UserHistoryResponse{
+			UserID:      input.UserID,
+			Transitions: make([]PlanTransition, 0, min(len(transitions), input.Limit)),
+		}
+		for i, t := range transitions {
+			if i == input.Limit {
+				resp.NextCursor = transitions[i-1].Cursor().String()
+				break
+			}
+			resp.Transitions = append(resp.Transitions, route.toPlanTransition(r.Context(), &t))
+		}
+
+		httptools.JSON(w, r, http.StatusOK, resp)
+	})
+}
+
+func (route *RouteUserHistory) toPlanTransition(ctx context.Context, t *entitlements.PlanTransition) PlanTransition {
+	transition := PlanTransition{
+		ID:        t.ID,
+		PrevPlan:  t.PrevPlan,
+		NewPlan:   t.NewPlan,
+		Active:    t.Active,
+		Cause:     t.Cause,
+		CreatedAt: t.CreatedAt,
+	}
+	var sub *subscriptions.Subscription
+	if err := json.Unmarshal(t.Subscription, &sub); err != nil {
+		// Keep the rest of the timeline readable.
+		logger.FromContext(ctx).Error("failed to unmarshal subscription snapshot", "error", err, "transition_id", t.ID)
+		return transition
+	}
+	if sub != nil {
+		transition.Subscription = ToUserSubscription(sub, route.entService.ResolvePlanFromProduct(sub.ProductID))
+	}
+	return transition
+}
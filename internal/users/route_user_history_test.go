@@ -0,0 +1,63 @@
+package users_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/openapi-go/openapi31"
+
+	"github.com/grantsy/grantsy/internal/auth/authtest"
+	"github.com/grantsy/grantsy/internal/entitlements"
+	"github.com/grantsy/grantsy/internal/subscriptions"
+	"github.com/grantsy/grantsy/internal/users"
+	"github.com/grantsy/grantsy/internal/users/mocks"
+)
+
+func TestRouteUserHistory(t *testing.T) {
+	snapshot, err := json.Marshal(&subscriptions.Subscription{ID: 42, ProductID: 100, Status: "expired"})
+	require.NoError(t, err)
+
+	history := mocks.NewMockPlanHistoryReader(t)
+	cursor := &entitlements.TransitionCursor{CreatedAt: 4000, ID: "04"}
+	history.EXPECT().ListTransitions(mock.Anything, "user-1", cursor, 3).Return([]entitlements.PlanTransition{
+		{ID: "03", PrevPlan: "pro", NewPlan: "free", Cause: "subscription_expired", Subscription: snapshot, CreatedAt: 3000},
+		{ID: "02", PrevPlan: "pro", NewPlan: "pro", Active: true, Cause: "subscription_cancelled", Subscription: []byte("null"), CreatedAt: 2000},
+		{ID: "01", PrevPlan: "free", NewPlan: "pro", Active: true, Cause: "subscription_created", Subscription: []byte("null"), CreatedAt: 1000},
+	}, nil)
+	ent := mocks.NewMockEntitlementService(t)
+	ent.EXPECT().ResolvePlanFromProduct(100).Return("pro")
+
+	mux := http.NewServeMux()
+	users.NewRouteUserHistory(ent, history).Register(mux, openapi31.NewReflector())
+
+	code, data := serveUser(t, authtest.Handler(mux), http.MethodGet, "/v1/users/user-1/history?limit=2&cursor=4000_04", "")
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "2000_02", data["next_cursor"])
+	transitions := data["transitions"].([]any)
+	require.Len(t, transitions, 2)
+
+	downgrade := transitions[0].(map[string]any)
+	assert.Equal(t, "pro", downgrade["prev_plan"])
+	assert.Equal(t, "free", downgrade["new_plan"])
+	assert.Equal(t, "subscription_expired", downgrade["cause"])
+	subscription := downgrade["subscription"].(map[string]any)
+	assert.Equal(t, "pro", subscription["plan_id"])
+	assert.Equal(t, "expired", subscription["status"])
+
+	assert.Nil(t, transitions[1].(map[string]any)["subscription"])
+}
+
+func TestRouteUserHistory_InvalidCursor(t *testing.T) {
+	mux := http.NewServeMux()
+	users.NewRouteUserHistory(mocks.NewMockEntitlementService(t), mocks.NewMockPlanHistoryReader(t)).
+		Register(mux, openapi31.NewReflector())
+
+	code, _ := serveUser(t, authtest.Handler(mux), http.MethodGet, "/v1/users/user-1/history?cursor=02", "")
+
+	assert.Equal(t, http.StatusBadRequest, code)
+}
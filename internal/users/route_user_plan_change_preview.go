@@ -0,0 +1,126 @@
+package users
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/iamolegga/valmid"
+	"github.com/swaggest/openapi-go"
+	"github.com/swaggest/openapi-go/openapi31"
+
+	"github.com/grantsy/grantsy/internal/auth"
+	"github.com/grantsy/grantsy/internal/entitlements"
+	"github.com/grantsy/grantsy/internal/httptools"
+	"github.com/grantsy/grantsy/internal/infra/logger"
+	oa "github.com/grantsy/grantsy/internal/openapi"
+	"github.com/grantsy/grantsy/internal/subscriptions"
+)
+
+type PlanChangePreviewRequest struct {
+	UserID    string `in:"path=user_id"    path:"user_id"     validate:"required"        description:"User ID to preview the change for"`
+	To        string `in:"query=to"        query:"to"         validate:"required"        description:"Plan to move to"`
+	VariantID int    `in:"query=variant_id" query:"variant_id" validate:"omitempty,min=1" description:"Plan variant to quote; defaults to the plan's first variant"`
+}
+
+type PlanChangePreviewResponse struct {
+	UserID         string                        `json:"user_id"         description:"The user ID"                                                                          required:"true"`
+	FromPlan       string                        `json:"from_plan"       description:"The user's current plan ID"                                                           required:"true"`
+	ToPlan         string                        `json:"to_plan"         description:"The plan being previewed"                                                             required:"true"`
+	GainedFeatures []entitlements.Feature        `json:"gained_features" description:"Features the user would gain"                                                         required:"true"`
+	LostFeatures   []entitlements.Feature        `json:"lost_features"   description:"Features the user would lose"                                                         required:"true"`
+	Proration      *subscriptions.ProrationQuote `json:"proration"       description:"Local estimate of the proration, which may differ from the provider's invoice; null without an active subscription or when the plan has no variants"`
+}
+
+type RouteUserPlanChangePreview struct {
+	entService EntitlementService
+	subRepo    SubscriptionRepo
+	variants   subscriptions.PlanVariantProvider
+}
+
+func NewRouteUserPlanChangePreview(
+	entService EntitlementService,
+	subRepo SubscriptionRepo,
+	variants subscriptions.PlanVariantProvider,
+) *RouteUserPlanChangePreview {
+	return &RouteUserPlanChangePreview{entService: entService, subRepo: subRepo, variants: variants}
+}
+
+func (route *RouteUserPlanChangePreview) Register(mux *http.ServeMux, r *openapi31.Reflector) {
+	mux.Handle("GET /v1/users/{user_id}/plan-change-preview", httptools.Wrap(
+		route.Handler(),
+		auth.RequireScope(auth.ScopeUsersRead),
+		valmid.Middleware[PlanChangePreviewRequest](),
+	))
+	RegisterUserPlanChangePreviewSchema(r)
+}
+
+func RegisterUserPlanChangePreviewSchema(r *openapi31.Reflector) {
+	op, _ := r.NewOperationContext(http.MethodGet, "/v1/users/{user_id}/plan-change-preview")
+	op.AddReqStructure(new(PlanChangePreviewRequest))
+	op.AddRespStructure(struct {
+		Data PlanChangePreviewResponse `json:"data"`
+		Meta httptools.Meta            `json:"meta"`
+		_    struct{}                  `title:"PlanChangePreviewResponse"`
+	}{}, func(cu *openapi.ContentUnit) {
+		cu.HTTPStatus = http.StatusOK
+		cu.Description = "Plan change preview"
+	})
+	oa.AddErrorResponses(op)
+	op.SetSummary("Preview plan change")
+	op.SetDescription(
+		"Preview moving the user to another plan: the features gained and lost, and an estimate of the prorated charge for the user's subscription. The estimate is calculated by grantsy from cached prices, not by the provider, and may differ from the amount on the provider's invoice, e.g. by taxes or discounts. Nothing is changed.",
+	)
+	op.SetTags("Users")
+	op.AddSecurity("ApiKeyAuth", auth.ScopeUsersRead)
+	op.AddSecurity("BearerAuth", auth.ScopeUsersRead)
+	r.AddOperation(op)
+}
+
+func (route *RouteUserPlanChangePreview) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		input := valmid.Get[PlanChangePreviewRequest](r)
+
+		if route.entService.GetPlan(input.To) == nil {
+			httptools.NotFound(w, r, fmt.Sprintf("Plan '%s' not found", input.To))
+			return
+		}
+		fromPlan := route.entService.GetUserPlan(input.UserID)
+		if fromPlan == input.To {
+			httptools.BadRequest(w, r, fmt.Sprintf("User is already on plan '%s'", input.To))
+			return
+		}
+
+		gained, lost := route.entService.DiffPlanFeatures(fromPlan, input.To)
+		resp := PlanChangePreviewResponse{
+			UserID:         input.UserID,
+			FromPlan:       fromPlan,
+			ToPlan:         input.To,
+			GainedFeatures: toFeatures(route.entService, gained),
+			LostFeatures:   toFeatures(route.entService, lost),
+		}
+
+		sub, err := route.subRepo.GetSubscriptionByUserID(r.Context(), input.UserID)
+		if err != nil {
+			logger.FromContext(r.Context()).
+				Error("failed to get subscription", "error", err, "user_id", input.UserID)
+			httptools.InternalError(w, r)
+			return
+		}
+
+		// Moving to a plan without variants, like a free default plan, means
+		// cancelling rather than changing, so there is nothing to prorate.
+		hasVariants := len(route.variants.GetPlanVariants(input.To)) > 0
+		if input.VariantID != 0 || (hasVariants && sub != nil && sub.IsActive()) {
+			variant, ok := resolvePlanVariant(w, r, route.variants, input.To, input.VariantID)
+			if !ok {
+				return
+			}
+			if sub != nil && sub.IsActive() {
+				resp.Proration = subscriptions.QuoteProration(sub, variant, time.Now())
+			}
+		}
+
+		httptools.JSON(w, r, http.StatusOK, resp)
+	})
+}
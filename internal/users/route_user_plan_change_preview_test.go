@@ -0,0 +1,98 @@
+package users_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/swaggest/openapi-go/openapi31"
+
+	"github.com/grantsy/grantsy/internal/auth/authtest"
+	"github.com/grantsy/grantsy/internal/entitlements"
+	"github.com/grantsy/grantsy/internal/infra/config"
+	"github.com/grantsy/grantsy/internal/subscriptions"
+	submocks "github.com/grantsy/grantsy/internal/subscriptions/mocks"
+	"github.com/grantsy/grantsy/internal/users"
+	"github.com/grantsy/grantsy/internal/users/mocks"
+)
+
+func newPreviewMux(t *testing.T, sub *subscriptions.Subscription) http.Handler {
+	t.Helper()
+	ent := mocks.NewMockEntitlementService(t)
+	ent.EXPECT().GetPlan("pro").Return(&config.PlanConfig{ID: "pro"}).Maybe()
+	ent.EXPECT().GetPlan("free").Return(&config.PlanConfig{ID: "free"}).Maybe()
+	ent.EXPECT().GetPlan(mock.Anything).Return(nil).Maybe()
+	ent.EXPECT().GetUserPlan("user-1").Return("free").Maybe()
+	ent.EXPECT().DiffPlanFeatures("free", "pro").Return([]string{"api"}, []string{}).Maybe()
+	ent.EXPECT().GetFeature("api").Return(&config.FeatureConfig{ID: "api", Name: "API"}).Maybe()
+
+	repo := mocks.NewMockSubscriptionRepo(t)
+	repo.EXPECT().GetSubscriptionByUserID(mock.Anything, "user-1").Return(sub, nil).Maybe()
+
+	variants := submocks.NewMockPlanVariantProvider(t)
+	variants.EXPECT().GetPlanVariants("pro").Return([]entitlements.Variant{
+		{ID: 11, Price: 3000, Interval: "month", IntervalCount: 1},
+	}).Maybe()
+
+	mux := http.NewServeMux()
+	users.NewRouteUserPlanChangePreview(ent, repo, variants).Register(mux, openapi31.NewReflector())
+	return authtest.Handler(mux)
+}
+
+func TestRouteUserPlanChangePreview_WithSubscription(t *testing.T) {
+	sub := &subscriptions.Subscription{
+		ID:                      42,
+		Status:                  "active",
+		VariantID:               10,
+		UnitPrice:               1000,
+		RenewsAt:                time.Now().Add(15 * 24 * time.Hour).Unix(),
+		RenewalIntervalUnit:     "month",
+		RenewalIntervalQuantity: 1,
+	}
+
+	code, data := serveUser(t, newPreviewMux(t, sub),
+		http.MethodGet, "/v1/users/user-1/plan-change-preview?to=pro", "")
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "free", data["from_plan"])
+	assert.Equal(t, "pro", data["to_plan"])
+	gained := data["gained_features"].([]any)
+	assert.Len(t, gained, 1)
+	assert.Equal(t, "API", gained[0].(map[string]any)["name"])
+	assert.Empty(t, data["lost_features"])
+
+	proration := data["proration"].(map[string]any)
+	assert.InDelta(t, 11, proration["variant_id"], 0)
+	assert.Greater(t, proration["amount_due"], float64(0))
+}
+
+func TestRouteUserPlanChangePreview_NoSubscription(t *testing.T) {
+	code, data := serveUser(t, newPreviewMux(t, nil),
+		http.MethodGet, "/v1/users/user-1/plan-change-preview?to=pro", "")
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Nil(t, data["proration"])
+}
+
+func TestRouteUserPlanChangePreview_UnknownPlan(t *testing.T) {
+	code, _ := serveUser(t, newPreviewMux(t, nil),
+		http.MethodGet, "/v1/users/user-1/plan-change-preview?to=enterprise", "")
+
+	assert.Equal(t, http.StatusNotFound, code)
+}
+
+func TestRouteUserPlanChangePreview_SamePlan(t *testing.T) {
+	code, _ := serveUser(t, newPreviewMux(t, nil),
+		http.MethodGet, "/v1/users/user-1/plan-change-preview?to=free", "")
+
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestRouteUserPlanChangePreview_ForeignVariant(t *testing.T) {
+	code, _ := serveUser(t, newPreviewMux(t, nil),
+		http.MethodGet, "/v1/users/user-1/plan-change-preview?to=pro&variant_id=99", "")
+
+	assert.Equal(t, http.StatusBadRequest, code)
+}
@@ -0,0 +1,83 @@
+package users
+
+import (
+	"net/http"
+
+	"github.com/iamolegga/valmid"
+	"github.com/swaggest/openapi-go"
+	"github.com/swaggest/openapi-go/openapi31"
+
+	"github.com/grantsy/grantsy/internal/auth"
+	"github.com/grantsy/grantsy/internal/httptools"
+	"github.com/grantsy/grantsy/internal/infra/logger"
+	oa "github.com/grantsy/grantsy/internal/openapi"
+)
+
+type UserPortalRequest struct {
+	UserID string `in:"path=user_id" path:"user_id" validate:"required" description:"User ID to get the portal URL for"`
+}
+
+type UserPortalResponse struct {
+	URL string `json:"url" description:"Signed customer portal URL, valid for 24 hours" required:"true"`
+}
+
+type RouteUserPortal struct {
+	subRepo SubscriptionRepo
+	manager SubscriptionManager
+}
+
+func NewRouteUserPortal(subRepo SubscriptionRepo, manager SubscriptionManager) *RouteUserPortal {
+	return &RouteUserPortal{subRepo: subRepo, manager: manager}
+}
+
+func (route *RouteUserPortal) Register(mux *http.ServeMux, r *openapi31.Reflector) {
+	mux.Handle("GET /v1/users/{user_id}/portal", httptools.Wrap(
+		route.Handler(),
+		auth.RequireScope(auth.ScopeAdminWrite),
+		valmid.Middleware[UserPortalRequest](),
+	))
+	RegisterUserPortalSchema(r)
+}
+
+func RegisterUserPortalSchema(r *openapi31.Reflector) {
+	op, _ := r.NewOperationContext(http.MethodGet, "/v1/users/{user_id}/portal")
+	op.AddReqStructure(new(UserPortalRequest))
+	op.AddRespStructure(struct {
+		Data UserPortalResponse `json:"data"`
+		Meta httptools.Meta     `json:"meta"`
+		_    struct{}           `title:"UserPortalResponse"`
+	}{}, func(cu *openapi.ContentUnit) {
+		cu.HTTPStatus = http.StatusOK
+		cu.Description = "Customer portal URL"
+	})
+	oa.AddErrorResponses(op)
+	op.SetSummary("Get customer portal URL")
+	op.SetDescription(
+		"Get the provider's customer portal URL for the user's subscription, where they can update payment details and download invoices. The URL lets its holder change billing and cancel the subscription, so it requires the admin:write scope",
+	)
+	op.SetTags("Users")
+	op.AddSecurity("ApiKeyAuth", auth.ScopeAdminWrite)
+	op.AddSecurity("BearerAuth", auth.ScopeAdminWrite)
+	r.AddOperation(op)
+}
+
+func (route *RouteUserPortal) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		input := valmid.Get[UserPortalRequest](r)
+
+		sub := loadUserSubscription(w, r, route.subRepo, input.UserID)
+		if sub == nil {
+			return
+		}
+
+		url, err := route.manager.GetCustomerPortalURL(r.Context(), sub.ID)
+		if err != nil {
+			logger.FromContext(r.Context()).
+				Error("failed to get customer portal url", "error", err, "user_id", input.UserID)
+			httptools.InternalError(w, r)
+			return
+		}
+
+		httptools.JSON(w, r, http.StatusOK, UserPortalResponse{URL: url})
+	})
+}
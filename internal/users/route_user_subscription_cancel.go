@@ -0,0 +1,55 @@
+package users
+
+import (
+	"net/http"
+
+	"github.com/iamolegga/valmid"
+	"github.com/swaggest/openapi-go/openapi31"
+
+	"github.com/grantsy/grantsy/internal/auth"
+	"github.com/grantsy/grantsy/internal/httptools"
+)
+
+type RouteUserSubscriptionCancel struct {
+	subRepo SubscriptionRepo
+	manager SubscriptionManager
+}
+
+func NewRouteUserSubscriptionCancel(
+	subRepo SubscriptionRepo,
+	manager SubscriptionManager,
+) *RouteUserSubscriptionCancel {
+	return &RouteUserSubscriptionCancel{subRepo: subRepo, manager: manager}
+}
+
+func (route *RouteUserSubscriptionCancel) Register(mux *http.ServeMux, r *openapi31.Reflector) {
+	mux.Handle("POST /v1/users/{user_id}/subscription/cancel", httptools.Wrap(
+		route.Handler(),
+		auth.RequireScope(auth.ScopeAdminWrite),
+		valmid.Middleware[SubscriptionActionRequest](),
+	))
+	RegisterUserSubscriptionCancelSchema(r)
+}
+
+func RegisterUserSubscriptionCancelSchema(r *openapi31.Reflector) {
+	registerSubscriptionActionSchema(r,
+		"/v1/users/{user_id}/subscription/cancel",
+		new(SubscriptionActionRequest),
+		"Cancel user subscription",
+		"Cancel the user's subscription at the end of the current billing period. The user keeps access until then; local state is updated by the provider webhook.",
+	)
+}
+
+func (route *RouteUserSubscriptionCancel) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		input := valmid.Get[SubscriptionActionRequest](r)
+
+		sub := loadUserSubscription(w, r, route.subRepo, input.UserID)
+		if sub == nil {
+			return
+		}
+
+		updated, err := route.manager.CancelSubscription(r.Context(), sub.ID)
+		writeSubscriptionActionResult(w, r, input.UserID, "cancel", updated, err)
+	})
+}
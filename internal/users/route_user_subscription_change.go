@@ -0,0 +1,89 @@
+package users
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/iamolegga/valmid"
+	"github.com/swaggest/openapi-go/openapi31"
+
+	"github.com/grantsy/grantsy/internal/auth"
+	"github.com/grantsy/grantsy/internal/httptools"
+	"github.com/grantsy/grantsy/internal/subscriptions"
+)
+
+type ChangeSubscriptionBody struct {
+	PlanID             string `json:"plan_id"                       validate:"required"        description:"Plan to move the subscription to"                              required:"true"`
+	VariantID          int    `json:"variant_id,omitempty"          validate:"omitempty,min=1" description:"Plan variant to switch to; defaults to the plan's first variant"`
+	InvoiceImmediately bool   `json:"invoice_immediately,omitempty" description:"Charge the prorated amount now instead of on the next invoice"`
+}
+
+type ChangeSubscriptionRequest struct {
+	UserID string                  `in:"path=user_id" path:"user_id" validate:"required" description:"User whose subscription to change"`
+	Body   *ChangeSubscriptionBody `in:"body=json"                   validate:"required"`
+}
+
+// changeSubscriptionSchema mirrors ChangeSubscriptionRequest for OpenAPI spec generation.
+type changeSubscriptionSchema struct {
+	UserID string `path:"user_id" description:"User whose subscription to change"`
+	ChangeSubscriptionBody
+}
+
+type RouteUserSubscriptionChange struct {
+	subRepo  SubscriptionRepo
+	manager  SubscriptionManager
+	variants subscriptions.PlanVariantProvider
+}
+
+func NewRouteUserSubscriptionChange(
+	subRepo SubscriptionRepo,
+	manager SubscriptionManager,
+	variants subscriptions.PlanVariantProvider,
+) *RouteUserSubscriptionChange {
+	return &RouteUserSubscriptionChange{subRepo: subRepo, manager: manager, variants: variants}
+}
+
+func (route *RouteUserSubscriptionChange) Register(mux *http.ServeMux, r *openapi31.Reflector) {
+	mux.Handle("POST /v1/users/{user_id}/subscription/change", httptools.Wrap(
+		route.Handler(),
+		auth.RequireScope(auth.ScopeAdminWrite),
+		valmid.Middleware[ChangeSubscriptionRequest](),
+	))
+	RegisterUserSubscriptionChangeSchema(r)
+}
+
+func RegisterUserSubscriptionChangeSchema(r *openapi31.Reflector) {
+	registerSubscriptionActionSchema(r,
+		"/v1/users/{user_id}/subscription/change",
+		new(changeSubscriptionSchema),
+		"Change user subscription plan",
+		"Move the user's subscription to another plan variant. Local state is updated by the provider webhook.",
+	)
+}
+
+func (route *RouteUserSubscriptionChange) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		input := valmid.Get[ChangeSubscriptionRequest](r)
+		body := input.Body
+
+		variant, ok := resolvePlanVariant(w, r, route.variants, body.PlanID, body.VariantID)
+		if !ok {
+			return
+		}
+		variantID := variant.ID
+
+		sub := loadUserSubscription(w, r, route.subRepo, input.UserID)
+		if sub == nil {
+			return
+		}
+		if sub.VariantID == variantID {
+			httptools.BadRequest(w, r, fmt.Sprintf("Subscription is already on variant %d", variantID))
+			return
+		}
+
+		updated, err := route.manager.ChangeSubscriptionVariant(
+			r.Context(), sub.ID, variantID, body.InvoiceImmediately,
+		)
+		writeSubscriptionActionResult(w, r, input.UserID, "change", updated, err)
+	})
+}
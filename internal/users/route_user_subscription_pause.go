@@ -0,0 +1,76 @@
+package users
+
+import (
+	"net/http"
+
+	"github.com/iamolegga/valmid"
+	"github.com/swaggest/openapi-go/openapi31"
+
+	"github.com/grantsy/grantsy/internal/auth"
+	"github.com/grantsy/grantsy/internal/httptools"
+)
+
+type PauseSubscriptionBody struct {
+	Mode      string `json:"mode"                 validate:"omitempty,oneof=void free" description:"void stops access while paused, free keeps access without charging (defaults to void)" enum:"void,free"`
+	ResumesAt *int64 `json:"resumes_at,omitempty" validate:"omitempty,gt=0"            description:"Unix timestamp to resume automatically; omit to pause indefinitely"`
+}
+
+type PauseSubscriptionRequest struct {
+	UserID string                 `in:"path=user_id" path:"user_id" validate:"required" description:"User whose subscription to pause"`
+	Body   *PauseSubscriptionBody `in:"body=json" validate:"required"`
+}
+
+// pauseSubscriptionSchema mirrors PauseSubscriptionRequest for OpenAPI spec generation.
+type pauseSubscriptionSchema struct {
+	UserID string `path:"user_id" description:"User whose subscription to pause"`
+	PauseSubscriptionBody
+}
+
+type RouteUserSubscriptionPause struct {
+	subRepo SubscriptionRepo
+	manager SubscriptionManager
+}
+
+func NewRouteUserSubscriptionPause(
+	subRepo SubscriptionRepo,
+	manager SubscriptionManager,
+) *RouteUserSubscriptionPause {
+	return &RouteUserSubscriptionPause{subRepo: subRepo, manager: manager}
+}
+
+func (route *RouteUserSubscriptionPause) Register(mux *http.ServeMux, r *openapi31.Reflector) {
+	mux.Handle("POST /v1/users/{user_id}/subscription/pause", httptools.Wrap(
+		route.Handler(),
+		auth.RequireScope(auth.ScopeAdminWrite),
+		valmid.Middleware[PauseSubscriptionRequest](),
+	))
+	RegisterUserSubscriptionPauseSchema(r)
+}
+
+func RegisterUserSubscriptionPauseSchema(r *openapi31.Reflector) {
+	registerSubscriptionActionSchema(r,
+		"/v1/users/{user_id}/subscription/pause",
+		new(pauseSubscriptionSchema),
+		"Pause user subscription",
+		"Pause payment collection for the user's subscription. Send an empty object to pause indefinitely with access stopped. Local state is updated by the provider webhook.",
+	)
+}
+
+func (route *RouteUserSubscriptionPause) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		input := valmid.Get[PauseSubscriptionRequest](r)
+
+		mode := input.Body.Mode
+		if mode == "" {
+			mode = "void"
+		}
+
+		sub := loadUserSubscription(w, r, route.subRepo, input.UserID)
+		if sub == nil {
+			return
+		}
+
+		updated, err := route.manager.PauseSubscription(r.Context(), sub.ID, mode, input.Body.ResumesAt)
+		writeSubscriptionActionResult(w, r, input.UserID, "pause", updated, err)
+	})
+}
@@ -0,0 +1,55 @@
+package users
+
+import (
+	"net/http"
+
+	"github.com/iamolegga/valmid"
+	"github.com/swaggest/openapi-go/openapi31"
+
+	"github.com/grantsy/grantsy/internal/auth"
+	"github.com/grantsy/grantsy/internal/httptools"
+)
+
+type RouteUserSubscriptionResume struct {
+	subRepo SubscriptionRepo
+	manager SubscriptionManager
+}
+
+func NewRouteUserSubscriptionResume(
+	subRepo SubscriptionRepo,
+	manager SubscriptionManager,
+) *RouteUserSubscriptionResume {
+	return &RouteUserSubscriptionResume{subRepo: subRepo, manager: manager}
+}
+
+func (route *RouteUserSubscriptionResume) Register(mux *http.ServeMux, r *openapi31.Reflector) {
+	mux.Handle("POST /v1/users/{user_id}/subscription/resume", httptools.Wrap(
+		route.Handler(),
+		auth.RequireScope(auth.ScopeAdminWrite),
+		valmid.Middleware[SubscriptionActionRequest](),
+	))
+	RegisterUserSubscriptionResumeSchema(r)
+}
+
+func RegisterUserSubscriptionResumeSchema(r *openapi31.Reflector) {
+	registerSubscriptionActionSchema(r,
+		"/v1/users/{user_id}/subscription/resume",
+		new(SubscriptionActionRequest),
+		"Resume user subscription",
+		"Undo a pending cancellation or lift a pause of the user's subscription. Local state is updated by the provider webhook.",
+	)
+}
+
+func (route *RouteUserSubscriptionResume) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		input := valmid.Get[SubscriptionActionRequest](r)
+
+		sub := loadUserSubscription(w, r, route.subRepo, input.UserID)
+		if sub == nil {
+			return
+		}
+
+		updated, err := route.manager.ResumeSubscription(r.Context(), sub.ID)
+		writeSubscriptionActionResult(w, r, input.UserID, "resume", updated, err)
+	})
+}
@@ -0,0 +1,211 @@
+package users_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/openapi-go/openapi31"
+
+	"github.com/grantsy/grantsy/internal/auth"
+	"github.com/grantsy/grantsy/internal/auth/authtest"
+	"github.com/grantsy/grantsy/internal/entitlements"
+	"github.com/grantsy/grantsy/internal/httptools"
+	"github.com/grantsy/grantsy/internal/subscriptions"
+	submocks "github.com/grantsy/grantsy/internal/subscriptions/mocks"
+	"github.com/grantsy/grantsy/internal/users"
+	"github.com/grantsy/grantsy/internal/users/mocks"
+
+	_ "github.com/grantsy/grantsy/internal/infra/validation"
+)
+
+func newSubscriptionMux(
+	t *testing.T,
+	sub *subscriptions.Subscription,
+	manager users.SubscriptionManager,
+) http.Handler {
+	t.Helper()
+	repo := mocks.NewMockSubscriptionRepo(t)
+	repo.EXPECT().GetSubscriptionByUserID(mock.Anything, "user-1").Return(sub, nil).Maybe()
+
+	variants := submocks.NewMockPlanVariantProvider(t)
+	variants.EXPECT().GetPlanVariants("pro").Return([]entitlements.Variant{
+		{ID: 11, Sort: 1},
+		{ID: 12, Sort: 2},
+	}).Maybe()
+	variants.EXPECT().GetPlanVariants(mock.Anything).Return(nil).Maybe()
+
+	mux := http.NewServeMux()
+	r := openapi31.NewReflector()
+	users.NewRouteUserPortal(repo, manager).Register(mux, r)
+	users.NewRouteUserSubscriptionCancel(repo, manager).Register(mux, r)
+	users.NewRouteUserSubscriptionResume(repo, manager).Register(mux, r)
+	users.NewRouteUserSubscriptionPause(repo, manager).Register(mux, r)
+	users.NewRouteUserSubscriptionChange(repo, manager, variants).Register(mux, r)
+	return authtest.Handler(mux)
+}
+
+func serveUser(t *testing.T, mux http.Handler, method, target, body string) (int, map[string]any) {
+	t.Helper()
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	var resp httptools.Response
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	data, _ := resp.Data.(map[string]any)
+	return w.Code, data
+}
+
+var activeSub = &subscriptions.Subscription{ID: 42, UserID: "user-1", VariantID: 11, Status: "active"}
+
+func TestRouteUserPortal(t *testing.T) {
+	manager := mocks.NewMockSubscriptionManager(t)
+	manager.EXPECT().GetCustomerPortalURL(mock.Anything, 42).Return("https://portal.example.com/x", nil)
+
+	code, data := serveUser(t, newSubscriptionMux(t, activeSub, manager),
+		http.MethodGet, "/v1/users/user-1/portal", "")
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "https://portal.example.com/x", data["url"])
+}
+
+func TestRouteUserPortal_NoSubscription(t *testing.T) {
+	code, _ := serveUser(t, newSubscriptionMux(t, nil, mocks.NewMockSubscriptionManager(t)),
+		http.MethodGet, "/v1/users/user-1/portal", "")
+
+	assert.Equal(t, http.StatusNotFound, code)
+}
+
+func TestRouteUserPortal_RequiresAdminScope(t *testing.T) {
+	repo := mocks.NewMockSubscriptionRepo(t)
+	mux := http.NewServeMux()
+	users.NewRouteUserPortal(repo, mocks.NewMockSubscriptionManager(t)).Register(mux, openapi31.NewReflector())
+
+	code, _ := serveUser(t, authtest.Handler(mux, auth.ScopeUsersRead),
+		http.MethodGet, "/v1/users/user-1/portal", "")
+
+	assert.Equal(t, http.StatusForbidden, code)
+}
+
+func TestRouteUserSubscriptionCancel(t *testing.T) {
+	manager := mocks.NewMockSubscriptionManager(t)
+	manager.EXPECT().
+		CancelSubscription(mock.Anything, 42).
+		Return(&subscriptions.Subscription{ID: 42, VariantID: 11, Status: "cancelled", Cancelled: true}, nil)
+
+	code, data := serveUser(t, newSubscriptionMux(t, activeSub, manager),
+		http.MethodPost, "/v1/users/user-1/subscription/cancel", "")
+
+	assert.Equal(t, http.StatusAccepted, code)
+	assert.Equal(t, "cancelled", data["status"])
+	assert.Equal(t, true, data["cancelled"])
+}
+
+func TestRouteUserSubscriptionResume_ProviderError(t *testing.T) {
+	manager := mocks.NewMockSubscriptionManager(t)
+	manager.EXPECT().ResumeSubscription(mock.Anything, 42).Return(nil, assert.AnError)
+
+	code, _ := serveUser(t, newSubscriptionMux(t, activeSub, manager),
+		http.MethodPost, "/v1/users/user-1/subscription/resume", "")
+
+	assert.Equal(t, http.StatusInternalServerError, code)
+}
+
+func TestRouteUserSubscriptionCancel_ProviderRejects(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		want   int
+	}{
+		{"invalid_state", http.StatusUnprocessableEntity, http.StatusUnprocessableEntity},
+		{"conflict", http.StatusConflict, http.StatusConflict},
+		{"not_found", http.StatusNotFound, http.StatusConflict},
+		{"unauthorized", http.StatusUnauthorized, http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := mocks.NewMockSubscriptionManager(t)
+			manager.EXPECT().
+				CancelSubscription(mock.Anything, 42).
+				Return(nil, fmt.Errorf("cancel: %w", &subscriptions.ProviderError{
+					StatusCode: tt.status,
+					Message:    "Subscription is already cancelled",
+				}))
+
+			req := httptest.NewRequest(http.MethodPost, "/v1/users/user-1/subscription/cancel", nil)
+			w := httptest.NewRecorder()
+			newSubscriptionMux(t, activeSub, manager).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.want, w.Code)
+			if tt.want != http.StatusInternalServerError {
+				var resp httptools.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+				assert.Equal(t, "Subscription is already cancelled", resp.Error.Detail)
+			}
+		})
+	}
+}
+
+func TestRouteUserSubscriptionPause_Defaults(t *testing.T) {
+	manager := mocks.NewMockSubscriptionManager(t)
+	manager.EXPECT().
+		PauseSubscription(mock.Anything, 42, "void", (*int64)(nil)).
+		Return(&subscriptions.Subscription{ID: 42, Status: "paused"}, nil)
+
+	code, _ := serveUser(t, newSubscriptionMux(t, activeSub, manager),
+		http.MethodPost, "/v1/users/user-1/subscription/pause", `{}`)
+
+	assert.Equal(t, http.StatusAccepted, code)
+}
+
+func TestRouteUserSubscriptionPause_FreeUntil(t *testing.T) {
+	resumesAt := int64(1800000000)
+	manager := mocks.NewMockSubscriptionManager(t)
+	manager.EXPECT().
+		PauseSubscription(mock.Anything, 42, "free", &resumesAt).
+		Return(&subscriptions.Subscription{ID: 42, Status: "active"}, nil)
+
+	code, _ := serveUser(t, newSubscriptionMux(t, activeSub, manager),
+		http.MethodPost, "/v1/users/user-1/subscription/pause",
+		`{"mode":"free","resumes_at":1800000000}`)
+
+	assert.Equal(t, http.StatusAccepted, code)
+}
+
+func TestRouteUserSubscriptionChange(t *testing.T) {
+	manager := mocks.NewMockSubscriptionManager(t)
+	manager.EXPECT().
+		ChangeSubscriptionVariant(mock.Anything, 42, 12, true).
+		Return(&subscriptions.Subscription{ID: 42, VariantID: 12, Status: "active"}, nil)
+
+	code, data := serveUser(t, newSubscriptionMux(t, activeSub, manager),
+		http.MethodPost, "/v1/users/user-1/subscription/change",
+		`{"plan_id":"pro","variant_id":12,"invoice_immediately":true}`)
+
+	assert.Equal(t, http.StatusAccepted, code)
+	assert.InDelta(t, 12, data["variant_id"], 0)
+}
+
+func TestRouteUserSubscriptionChange_SameVariant(t *testing.T) {
+	code, _ := serveUser(t, newSubscriptionMux(t, activeSub, mocks.NewMockSubscriptionManager(t)),
+		http.MethodPost, "/v1/users/user-1/subscription/change", `{"plan_id":"pro"}`)
+
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestRouteUserSubscriptionChange_UnknownPlan(t *testing.T) {
+	code, _ := serveUser(t, newSubscriptionMux(t, activeSub, mocks.NewMockSubscriptionManager(t)),
+		http.MethodPost, "/v1/users/user-1/subscription/change", `{"plan_id":"free"}`)
+
+	assert.Equal(t, http.StatusBadRequest, code)
+}
@@ -0,0 +1,200 @@
+package users
+
+import (
+	"context"
+	"net/http"
+	"slices"
+
+	"github.com/iamolegga/valmid"
+	"github.com/swaggest/openapi-go"
+	"github.com/swaggest/openapi-go/openapi31"
+
+	"github.com/grantsy/grantsy/internal/auth"
+	"github.com/grantsy/grantsy/internal/httptools"
+	"github.com/grantsy/grantsy/internal/infra/logger"
+	oa "github.com/grantsy/grantsy/internal/openapi"
+	"github.com/grantsy/grantsy/internal/subscriptions"
+)
+
+// SubscriptionLister lists users by their subscriptions.
+type SubscriptionLister interface {
+	ListUserSubscriptions(ctx context.Context, filter subscriptions.UserFilter) ([]subscriptions.Subscription, error)
+}
+
+type UsersRequest struct {
+	PlanID string `in:"query=plan_id"          query:"plan_id"                                                                               description:"Filter by current plan"`
+	Status string `in:"query=status"           query:"status"  validate:"omitempty,oneof=on_trial active paused past_due unpaid cancelled expired" description:"Filter by subscription status" enum:"on_trial,active,paused,past_due,unpaid,cancelled,expired"`
+	Cursor string `in:"query=cursor"           query:"cursor"                                                                                description:"Pagination cursor (next_cursor from the previous page)"`
+	Limit  int    `in:"query=limit;default=50" query:"limit"   validate:"min=1,max=200"                                                       description:"Maximum number of users to return"                      default:"50"`
+}
+
+type UsersResponse struct {
+	Users      []UserSummary `json:"users"                 description:"Users ordered by ID"               required:"true" nullable:"false"`
+	NextCursor string        `json:"next_cursor,omitempty" description:"Cursor for the next page, omitted on the last page"`
+}
+
+// UserSummary is a user with a subscription and the plan it currently has.
+type UserSummary struct {
+	UserID       string            `json:"user_id"      description:"The user ID"                                                           required:"true"`
+	PlanID       string            `json:"plan_id"      description:"The user's current plan ID"                                            required:"true"`
+	Subscription *UserSubscription `json:"subscription" description:"The user's current subscription, as returned by GET /v1/users/{user_id}" required:"true"`
+}
+
+type RouteUsers struct {
+	entService EntitlementService
+	subLister  SubscriptionLister
+}
+
+func NewRouteUsers(entService EntitlementService, subLister SubscriptionLister) *RouteUsers {
+	return &RouteUsers{entService: entService, subLister: subLister}
+}
+
+func (route *RouteUsers) Register(mux *http.ServeMux, r *openapi31.Reflector) {
+	mux.Handle("GET /v1/users", httptools.Wrap(
+		route.Handler(),
+		auth.RequireScope(auth.ScopeUsersRead),
+		valmid.Middleware[UsersRequest](),
+	))
+	RegisterUsersSchema(r)
+}
+
+func RegisterUsersSchema(r *openapi31.Reflector) {
+	op, _ := r.NewOperationContext(http.MethodGet, "/v1/users")
+	op.AddReqStructure(new(UsersRequest))
+	op.AddRespStructure(struct {
+		Data UsersResponse  `json:"data"`
+		Meta httptools.Meta `json:"meta"`
+		_    struct{}       `title:"UsersResponse"`
+	}{}, func(cu *openapi.ContentUnit) {
+		cu.HTTPStatus = http.StatusOK
+		cu.Description = "Users"
+	})
+	oa.AddErrorResponses(op)
+	op.SetSummary("List users")
+	op.SetDescription(
+		"List users that have a subscription, ordered by ID, optionally filtered by their current plan and the status of their current subscription. Users on the default plan who never subscribed are not known to grantsy and are not listed. Filtering by the default plan scans subscriptions, so a page may hold fewer users than limit; use next_cursor to fetch the next page.",
+	)
+	op.SetTags("Users")
+	op.AddSecurity("ApiKeyAuth", auth.ScopeUsersRead)
+	op.AddSecurity("BearerAuth", auth.ScopeUsersRead)
+	r.AddOperation(op)
+}
+
+func (route *RouteUsers) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		input := valmid.Get[UsersRequest](r)
+
+		// Plans are resolved by the enforcer, not stored with subscriptions.
+		var userIDs []string
+		assigned := false
+		if input.PlanID != "" {
+			userIDs, assigned = route.entService.GetPlanUsers(input.PlanID)
+		}
+
+		var subs []subscriptions.Subscription
+		var nextCursor string
+		var err error
+		if assigned {
+			subs, nextCursor, err = route.listPlanUsers(r.Context(), input, userIDs)
+		} else {
+			subs, nextCursor, err = route.scanUsers(r.Context(), input)
+		}
+		if err != nil {
+			logger.FromContext(r.Context()).Error("failed to list users", "error", err)
+			httptools.InternalError(w, r)
+			return
+		}
+
+		resp := UsersResponse{Users: make([]UserSummary, 0, len(subs)), NextCursor: nextCursor}
+		for i := range subs {
+			resp.Users = append(resp.Users, UserSummary{
+				UserID:       subs[i].UserID,
+				PlanID:       route.entService.GetUserPlan(subs[i].UserID),
+				Subscription: ToUserSubscription(&subs[i], route.entService.ResolvePlanFromProduct(subs[i].ProductID)),
+			})
+		}
+
+		httptools.JSON(w, r, http.StatusOK, resp)
+	})
+}
+
+// maxUserIDsPerQuery bounds the number of user IDs matched by one query, well
+// below the bind parameter limits of the databases.
+const maxUserIDsPerQuery = 500
+
+// listPlanUsers returns a page of the subscriptions of userIDs, the sorted
+// users assigned the requested plan, and the cursor of the next page.
+func (route *RouteUsers) listPlanUsers(
+	ctx context.Context,
+	input UsersRequest,
+	userIDs []string,
+) ([]subscriptions.Subscription, string, error) {
+	start, found := slices.BinarySearch(userIDs, input.Cursor)
+	if found {
+		start++
+	}
+	userIDs = userIDs[start:]
+
+	// Collect one extra user to know whether another page exists.
+	var subs []subscriptions.Subscription
+	for len(userIDs) > 0 && len(subs) <= input.Limit {
+		chunk := userIDs[:min(len(userIDs), maxUserIDsPerQuery)]
+		userIDs = userIDs[len(chunk):]
+		batch, err := route.subLister.ListUserSubscriptions(ctx, subscriptions.UserFilter{
+			Status:  input.Status,
+			UserIDs: chunk,
+			Limit:   input.Limit + 1 - len(subs),
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		subs = append(subs, batch...)
+	}
+	return page(subs, input.Limit)
+}
+
+// maxScanBatches bounds the batches of subscriptions scanned for one page.
+const maxScanBatches = 10
+
+// scanUsers returns a page of subscriptions of users on the requested plan,
+// if any, and the cursor of the next page. Users on the default plan are not
+// assigned it, so they are found by scanning subscriptions. A scan that
+// reaches maxScanBatches returns the users found so far with a cursor after
+// the last user scanned.
+func (route *RouteUsers) scanUsers(
+	ctx context.Context,
+	input UsersRequest,
+) ([]subscriptions.Subscription, string, error) {
+	// Collect one extra user to know whether another page exists.
+	var subs []subscriptions.Subscription
+	cursor := input.Cursor
+	for range maxScanBatches {
+		batch, err := route.subLister.ListUserSubscriptions(ctx, subscriptions.UserFilter{
+			Status: input.Status,
+			Cursor: cursor,
+			Limit:  input.Limit + 1,
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		for _, sub := range batch {
+			if input.PlanID == "" || route.entService.GetUserPlan(sub.UserID) == input.PlanID {
+				subs = append(subs, sub)
+			}
+		}
+		if len(subs) > input.Limit || len(batch) <= input.Limit {
+			return page(subs, input.Limit)
+		}
+		cursor = batch[len(batch)-1].UserID
+	}
+	return subs, cursor, nil
+}
+
+// page returns the first limit subscriptions and, if there are more, the
+// cursor of the next page.
+func page(subs []subscriptions.Subscription, limit int) ([]subscriptions.Subscription, string, error) {
+	if len(subs) <= limit {
+		return subs, "", nil
+	}
+	return subs[:limit], subs[limit-1].UserID, nil
+}
@@ -0,0 +1,164 @@
+package users_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/swaggest/openapi-go/openapi31"
+
+	"github.com/grantsy/grantsy/internal/auth/authtest"
+	"github.com/grantsy/grantsy/internal/subscriptions"
+	"github.com/grantsy/grantsy/internal/users"
+	"github.com/grantsy/grantsy/internal/users/mocks"
+)
+
+func TestRouteUsers_FiltersByPlan(t *testing.T) {
+	lister := mocks.NewMockSubscriptionLister(t)
+	lister.EXPECT().
+		ListUserSubscriptions(mock.Anything, subscriptions.UserFilter{
+			Status:  "past_due",
+			UserIDs: []string{"user-c", "user-d"},
+			Limit:   2,
+		}).
+		Return([]subscriptions.Subscription{
+			{ID: 3, UserID: "user-c", ProductID: 100, Status: "past_due"},
+			{ID: 4, UserID: "user-d", ProductID: 100, Status: "past_due"},
+		}, nil)
+	ent := mocks.NewMockEntitlementService(t)
+	ent.EXPECT().GetPlanUsers("pro").Return([]string{"user-a", "user-c", "user-d"}, true)
+	ent.EXPECT().GetUserPlan("user-c").Return("pro")
+	ent.EXPECT().ResolvePlanFromProduct(100).Return("pro")
+
+	mux := http.NewServeMux()
+	users.NewRouteUsers(ent, lister).Register(mux, openapi31.NewReflector())
+
+	code, data := serveUser(t, authtest.Handler(mux), http.MethodGet, "/v1/users?plan_id=pro&status=past_due&limit=1&cursor=user-a", "")
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "user-c", data["next_cursor"])
+	list := data["users"].([]any)
+	assert.Len(t, list, 1)
+	user := list[0].(map[string]any)
+	assert.Equal(t, "user-c", user["user_id"])
+	assert.Equal(t, "pro", user["plan_id"])
+	assert.Equal(t, "past_due", user["subscription"].(map[string]any)["status"])
+}
+
+func TestRouteUsers_FiltersByPlan_QueriesUsersInChunks(t *testing.T) {
+	planUsers := make([]string, 501)
+	for i := range planUsers {
+		planUsers[i] = fmt.Sprintf("user-%03d", i)
+	}
+	lister := mocks.NewMockSubscriptionLister(t)
+	lister.EXPECT().
+		ListUserSubscriptions(mock.Anything, subscriptions.UserFilter{UserIDs: planUsers[:500], Limit: 51}).
+		Return(nil, nil)
+	lister.EXPECT().
+		ListUserSubscriptions(mock.Anything, subscriptions.UserFilter{UserIDs: planUsers[500:], Limit: 51}).
+		Return([]subscriptions.Subscription{{ID: 1, UserID: "user-500", ProductID: 100, Status: "active"}}, nil)
+	ent := mocks.NewMockEntitlementService(t)
+	ent.EXPECT().GetPlanUsers("pro").Return(planUsers, true)
+	ent.EXPECT().GetUserPlan("user-500").Return("pro")
+	ent.EXPECT().ResolvePlanFromProduct(100).Return("pro")
+
+	mux := http.NewServeMux()
+	users.NewRouteUsers(ent, lister).Register(mux, openapi31.NewReflector())
+
+	code, data := serveUser(t, authtest.Handler(mux), http.MethodGet, "/v1/users?plan_id=pro", "")
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.NotContains(t, data, "next_cursor")
+	assert.Len(t, data["users"], 1)
+}
+
+func TestRouteUsers_FiltersByDefaultPlan(t *testing.T) {
+	lister := mocks.NewMockSubscriptionLister(t)
+	lister.EXPECT().
+		ListUserSubscriptions(mock.Anything, subscriptions.UserFilter{Status: "expired", Limit: 2}).
+		Return([]subscriptions.Subscription{
+			{ID: 1, UserID: "user-a", ProductID: 100, Status: "expired"},
+			{ID: 2, UserID: "user-b", ProductID: 200, Status: "expired"},
+		}, nil)
+	// The first batch filled no page, so the next one starts after it.
+	lister.EXPECT().
+		ListUserSubscriptions(mock.Anything, subscriptions.UserFilter{Status: "expired", Cursor: "user-b", Limit: 2}).
+		Return([]subscriptions.Subscription{
+			{ID: 3, UserID: "user-c", ProductID: 100, Status: "expired"},
+			{ID: 4, UserID: "user-d", ProductID: 100, Status: "expired"},
+		}, nil)
+	ent := mocks.NewMockEntitlementService(t)
+	ent.EXPECT().GetPlanUsers("free").Return(nil, false)
+	ent.EXPECT().GetUserPlan("user-a").Return("free")
+	ent.EXPECT().GetUserPlan("user-b").Return("team")
+	ent.EXPECT().GetUserPlan("user-c").Return("free")
+	ent.EXPECT().GetUserPlan("user-d").Return("free")
+	ent.EXPECT().ResolvePlanFromProduct(100).Return("pro")
+
+	mux := http.NewServeMux()
+	users.NewRouteUsers(ent, lister).Register(mux, openapi31.NewReflector())
+
+	code, data := serveUser(t, authtest.Handler(mux), http.MethodGet, "/v1/users?plan_id=free&status=expired&limit=1", "")
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "user-a", data["next_cursor"])
+	list := data["users"].([]any)
+	assert.Len(t, list, 1)
+	assert.Equal(t, "user-a", list[0].(map[string]any)["user_id"])
+	assert.Equal(t, "free", list[0].(map[string]any)["plan_id"])
+}
+
+func TestRouteUsers_ScanIsBounded(t *testing.T) {
+	lister := mocks.NewMockSubscriptionLister(t)
+	lister.EXPECT().
+		ListUserSubscriptions(mock.Anything, mock.Anything).
+		Return([]subscriptions.Subscription{
+			{ID: 1, UserID: "user-a", ProductID: 200, Status: "active"},
+			{ID: 2, UserID: "user-b", ProductID: 200, Status: "active"},
+		}, nil).
+		Times(10)
+	ent := mocks.NewMockEntitlementService(t)
+	ent.EXPECT().GetPlanUsers("free").Return(nil, false)
+	ent.EXPECT().GetUserPlan(mock.Anything).Return("team")
+
+	mux := http.NewServeMux()
+	users.NewRouteUsers(ent, lister).Register(mux, openapi31.NewReflector())
+
+	code, data := serveUser(t, authtest.Handler(mux), http.MethodGet, "/v1/users?plan_id=free&limit=1", "")
+
+	// A short page continues after the last user scanned.
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "user-b", data["next_cursor"])
+	assert.Empty(t, data["users"])
+}
+
+func TestRouteUsers_LastPage(t *testing.T) {
+	lister := mocks.NewMockSubscriptionLister(t)
+	lister.EXPECT().
+		ListUserSubscriptions(mock.Anything, subscriptions.UserFilter{Cursor: "user-a", Limit: 51}).
+		Return([]subscriptions.Subscription{{ID: 2, UserID: "user-b", ProductID: 100, Status: "active"}}, nil)
+	ent := mocks.NewMockEntitlementService(t)
+	ent.EXPECT().GetUserPlan("user-b").Return("pro")
+	ent.EXPECT().ResolvePlanFromProduct(100).Return("pro")
+
+	mux := http.NewServeMux()
+	users.NewRouteUsers(ent, lister).Register(mux, openapi31.NewReflector())
+
+	code, data := serveUser(t, authtest.Handler(mux), http.MethodGet, "/v1/users?cursor=user-a", "")
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.NotContains(t, data, "next_cursor")
+	assert.Len(t, data["users"], 1)
+}
+
+func TestRouteUsers_InvalidStatus(t *testing.T) {
+	mux := http.NewServeMux()
+	users.NewRouteUsers(mocks.NewMockEntitlementService(t), mocks.NewMockSubscriptionLister(t)).
+		Register(mux, openapi31.NewReflector())
+
+	code, _ := serveUser(t, authtest.Handler(mux), http.MethodGet, "/v1/users?status=bogus", "")
+
+	assert.Equal(t, http.StatusUnprocessableEntity, code)
+}
@@ -0,0 +1,186 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/swaggest/openapi-go"
+	"github.com/swaggest/openapi-go/openapi31"
+
+	"github.com/grantsy/grantsy/internal/auth"
+	"github.com/grantsy/grantsy/internal/entitlements"
+	"github.com/grantsy/grantsy/internal/httptools"
+	"github.com/grantsy/grantsy/internal/infra/logger"
+	oa "github.com/grantsy/grantsy/internal/openapi"
+	"github.com/grantsy/grantsy/internal/subscriptions"
+)
+
+// SubscriptionManager changes subscriptions with the billing provider.
+// Local state is updated by the resulting provider webhooks.
+type SubscriptionManager interface {
+	GetCustomerPortalURL(ctx context.Context, subscriptionID int) (string, error)
+	CancelSubscription(ctx context.Context, subscriptionID int) (*subscriptions.Subscription, error)
+	ResumeSubscription(ctx context.Context, subscriptionID int) (*subscriptions.Subscription, error)
+	PauseSubscription(
+		ctx context.Context,
+		subscriptionID int,
+		mode string,
+		resumesAt *int64,
+	) (*subscriptions.Subscription, error)
+	ChangeSubscriptionVariant(
+		ctx context.Context,
+		subscriptionID int,
+		variantID int,
+		invoiceImmediately bool,
+	) (*subscriptions.Subscription, error)
+}
+
+type SubscriptionActionRequest struct {
+	UserID string `in:"path=user_id" path:"user_id" validate:"required" description:"User whose subscription to change"`
+}
+
+// SubscriptionActionResponse acknowledges a subscription change accepted by the provider.
+type SubscriptionActionResponse struct {
+	UserID         string `json:"user_id"         description:"The user ID"                                                    required:"true"`
+	SubscriptionID int    `json:"subscription_id" description:"Provider subscription ID"                                       required:"true"`
+	Status         string `json:"status"          description:"Subscription status reported by the provider after the change" required:"true"`
+	Cancelled      bool   `json:"cancelled"       description:"Whether the subscription is set to cancel"                       required:"true"`
+	VariantID      int    `json:"variant_id"      description:"Provider variant ID after the change"                            required:"true"`
+}
+
+// loadUserSubscription writes an error response and returns nil when the
+// user's subscription can't be loaded.
+func loadUserSubscription(
+	w http.ResponseWriter,
+	r *http.Request,
+	repo SubscriptionRepo,
+	userID string,
+) *subscriptions.Subscription {
+	sub, err := repo.GetSubscriptionByUserID(r.Context(), userID)
+	if err != nil {
+		logger.FromContext(r.Context()).
+			Error("failed to get subscription", "error", err, "user_id", userID)
+		httptools.InternalError(w, r)
+		return nil
+	}
+	if sub == nil {
+		httptools.NotFound(w, r, fmt.Sprintf("User '%s' has no subscription", userID))
+		return nil
+	}
+	return sub
+}
+
+// resolvePlanVariant picks the requested variant of a plan, or its first variant
+// when variantID is 0. It writes a 400 response and returns false if the plan
+// has no variants or the variant belongs to another plan.
+func resolvePlanVariant(
+	w http.ResponseWriter,
+	r *http.Request,
+	variants subscriptions.PlanVariantProvider,
+	planID string,
+	variantID int,
+) (entitlements.Variant, bool) {
+	planVariants := variants.GetPlanVariants(planID)
+	if len(planVariants) == 0 {
+		httptools.BadRequest(w, r, fmt.Sprintf("Plan '%s' has no purchasable variants", planID))
+		return entitlements.Variant{}, false
+	}
+	if variantID == 0 {
+		return planVariants[0], true
+	}
+	i := slices.IndexFunc(planVariants, func(v entitlements.Variant) bool {
+		return v.ID == variantID
+	})
+	if i < 0 {
+		httptools.BadRequest(w, r, fmt.Sprintf(
+			"Variant %d does not belong to plan '%s'", variantID, planID,
+		))
+		return entitlements.Variant{}, false
+	}
+	return planVariants[i], true
+}
+
+// writeSubscriptionActionResult responds to a subscription change performed with the provider.
+func writeSubscriptionActionResult(
+	w http.ResponseWriter,
+	r *http.Request,
+	userID string,
+	action string,
+	sub *subscriptions.Subscription,
+	err error,
+) {
+	var rejected *subscriptions.ProviderError
+	switch {
+	case errors.As(err, &rejected) && rejected.StatusCode == http.StatusUnprocessableEntity:
+		httptools.Error(w, r, http.StatusUnprocessableEntity,
+			httptools.ErrTypeValidationFailed,
+			"Unprocessable Entity",
+			rejected.Message,
+		)
+		return
+	case errors.As(err, &rejected) && providerConflict(rejected.StatusCode):
+		httptools.Conflict(w, r, rejected.Message)
+		return
+	case err != nil:
+		logger.FromContext(r.Context()).
+			Error("failed to "+action+" subscription", "error", err, "user_id", userID)
+		httptools.InternalError(w, r)
+		return
+	}
+
+	httptools.JSON(w, r, http.StatusAccepted, SubscriptionActionResponse{
+		UserID:         userID,
+		SubscriptionID: sub.ID,
+		Status:         sub.Status,
+		Cancelled:      sub.Cancelled,
+		VariantID:      sub.VariantID,
+	})
+}
+
+// providerConflict reports whether the provider rejected a change with status
+// because of the subscription's state. Other 4xx statuses, such as an invalid
+// API key or rate limiting, are grantsy's own failures.
+func providerConflict(status int) bool {
+	switch status {
+	case http.StatusBadRequest, http.StatusNotFound, http.StatusConflict:
+		return true
+	default:
+		return false
+	}
+}
+
+// registerSubscriptionActionSchema documents a subscription action endpoint.
+func registerSubscriptionActionSchema(
+	r *openapi31.Reflector,
+	path string,
+	req any,
+	summary, description string,
+) {
+	op, _ := r.NewOperationContext(http.MethodPost, path)
+	op.AddReqStructure(req)
+	op.AddRespStructure(struct {
+		Data SubscriptionActionResponse `json:"data"`
+		Meta httptools.Meta             `json:"meta"`
+		_    struct{}                   `title:"SubscriptionActionResponse"`
+	}{}, func(cu *openapi.ContentUnit) {
+		cu.HTTPStatus = http.StatusAccepted
+		cu.Description = "Change accepted by the provider"
+	})
+	op.AddRespStructure(
+		new(httptools.ErrorResponse),
+		func(cu *openapi.ContentUnit) {
+			cu.HTTPStatus = http.StatusConflict
+			cu.Description = "The provider rejected the change in the subscription's current state"
+		},
+	)
+	oa.AddErrorResponses(op)
+	op.SetSummary(summary)
+	op.SetDescription(description)
+	op.SetTags("Users")
+	op.AddSecurity("ApiKeyAuth", auth.ScopeAdminWrite)
+	op.AddSecurity("BearerAuth", auth.ScopeAdminWrite)
+	r.AddOperation(op)
+}
@@ -0,0 +1,217 @@
+package webhooks
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/grantsy/grantsy/internal/infra/config"
+)
+
+// ErrEndpointExists is returned when an endpoint with the same URL is already configured.
+var ErrEndpointExists = errors.New("webhooks: endpoint with this URL already exists")
+
+// secretPrefix marks secrets in the Standard Webhooks format: base64-encoded
+// key bytes behind a "whsec_" prefix.
+const secretPrefix = "whsec_"
+
+// Endpoint is an outgoing webhook destination, from the config file or the database.
+type Endpoint struct {
+	ID                  string // Empty for endpoints from the config file
+	URL                 string
+	Secret              string
+	PrevSecret          string // Secret replaced by the last rotation, still signed with until PrevSecretExpiresAt
+	PrevSecretExpiresAt int64
+	Events              []string
+	Plans               []string
+	Retry               config.WebhookRetry
+	CreatedAt           int64
+	UpdatedAt           int64
+}
+
+// Secrets returns the secrets payloads are signed with at now: the current
+// secret and, during a rollover window, the previous one.
+func (e *Endpoint) Secrets(now time.Time) []string {
+	if e.PrevSecret != "" && now.Unix() < e.PrevSecretExpiresAt {
+		return []string{e.Secret, e.PrevSecret}
+	}
+	return []string{e.Secret}
+}
+
+// EndpointInput holds the user-editable fields of an endpoint.
+type EndpointInput struct {
+	URL    string
+	Events []string
+	Plans  []string
+}
+
+// EndpointStore persists endpoints managed through the API.
+type EndpointStore interface {
+	ListEndpoints(ctx context.Context) ([]Endpoint, error)
+	GetEndpoint(ctx context.Context, id string) (*Endpoint, error)
+	GetEndpointByURL(ctx context.Context, url string) (*Endpoint, error)
+	InsertEndpoint(ctx context.Context, e *Endpoint) error
+	UpdateEndpoint(ctx context.Context, e *Endpoint) error
+	DeleteEndpoint(ctx context.Context, id string) (bool, error)
+}
+
+// EndpointRegistry combines the endpoints from the config file, which are
+// read-only, with the endpoints stored in the database.
+type EndpointRegistry struct {
+	static []Endpoint
+	store  EndpointStore
+}
+
+// NewEndpointRegistry creates a registry serving the configured endpoints and
+// those in store.
+func NewEndpointRegistry(configured []config.WebhookEndpoint, store EndpointStore) *EndpointRegistry {
+	static := make([]Endpoint, len(configured))
+	for i, ep := range configured {
+		static[i] = Endpoint{
+			URL:    ep.URL,
+			Secret: ep.Secret,
+			Events: ep.Events,
+			Plans:  ep.Plans,
+			Retry:  ep.Retry,
+		}
+	}
+	return &EndpointRegistry{static: static, store: store}
+}
+
+// AllEndpoints returns the configured endpoints followed by the stored ones.
+func (r *EndpointRegistry) AllEndpoints(ctx context.Context) ([]Endpoint, error) {
+	stored, err := r.store.ListEndpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+	all := make([]Endpoint, 0, len(r.static)+len(stored))
+	all = append(all, r.static...)
+	for _, e := range stored {
+		e.Retry = config.DefaultWebhookRetry
+		all = append(all, e)
+	}
+	return all, nil
+}
+
+// ListEndpoints returns the stored endpoints.
+func (r *EndpointRegistry) ListEndpoints(ctx context.Context) ([]Endpoint, error) {
+	return r.store.ListEndpoints(ctx)
+}
+
+// GetEndpoint returns the stored endpoint with the given ID, or nil if not found.
+func (r *EndpointRegistry) GetEndpoint(ctx context.Context, id string) (*Endpoint, error) {
+	return r.store.GetEndpoint(ctx, id)
+}
+
+// CreateEndpoint stores a new endpoint with a generated secret.
+func (r *EndpointRegistry) CreateEndpoint(ctx context.Context, in EndpointInput) (*Endpoint, error) {
+	if err := r.checkURL(ctx, in.URL, ""); err != nil {
+		return nil, err
+	}
+	secret, err := GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().Unix()
+	e := &Endpoint{
+		ID:        uuid.Must(uuid.NewV7()).String(),
+		URL:       in.URL,
+		Secret:    secret,
+		Events:    in.Events,
+		Plans:     in.Plans,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := r.store.InsertEndpoint(ctx, e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// UpdateEndpoint replaces the URL and filters of a stored endpoint.
+// It returns nil if the endpoint does not exist.
+func (r *EndpointRegistry) UpdateEndpoint(ctx context.Context, id string, in EndpointInput) (*Endpoint, error) {
+	e, err := r.store.GetEndpoint(ctx, id)
+	if err != nil || e == nil {
+		return nil, err
+	}
+	if err := r.checkURL(ctx, in.URL, id); err != nil {
+		return nil, err
+	}
+
+	e.URL = in.URL
+	e.Events = in.Events
+	e.Plans = in.Plans
+	e.UpdatedAt = time.Now().Unix()
+	if err := r.store.UpdateEndpoint(ctx, e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// DeleteEndpoint removes a stored endpoint and reports whether it existed.
+func (r *EndpointRegistry) DeleteEndpoint(ctx context.Context, id string) (bool, error) {
+	return r.store.DeleteEndpoint(ctx, id)
+}
+
+// RotateSecret replaces an endpoint's secret with a generated one. Payloads
+// are signed with both the new and the old secret for the rollover duration,
+// so consumers can switch secrets without rejecting deliveries. It returns nil
+// if the endpoint does not exist.
+func (r *EndpointRegistry) RotateSecret(ctx context.Context, id string, rollover time.Duration) (*Endpoint, error) {
+	e, err := r.store.GetEndpoint(ctx, id)
+	if err != nil || e == nil {
+		return nil, err
+	}
+	secret, err := GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	e.PrevSecret, e.PrevSecretExpiresAt = "", 0
+	if rollover > 0 {
+		e.PrevSecret = e.Secret
+		e.PrevSecretExpiresAt = now.Add(rollover).Unix()
+	}
+	e.Secret = secret
+	e.UpdatedAt = now.Unix()
+	if err := r.store.UpdateEndpoint(ctx, e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// checkURL returns ErrEndpointExists if url is used by a configured endpoint
+// or by a stored endpoint other than exceptID.
+func (r *EndpointRegistry) checkURL(ctx context.Context, url, exceptID string) error {
+	for _, e := range r.static {
+		if e.URL == url {
+			return ErrEndpointExists
+		}
+	}
+	existing, err := r.store.GetEndpointByURL(ctx, url)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.ID != exceptID {
+		return ErrEndpointExists
+	}
+	return nil
+}
+
+// GenerateSecret returns a random signing secret in the Standard Webhooks
+// "whsec_" format.
+func GenerateSecret() (string, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return "", fmt.Errorf("webhooks: failed to generate secret: %w", err)
+	}
+	return secretPrefix + base64.StdEncoding.EncodeToString(key), nil
+}
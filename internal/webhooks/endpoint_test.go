@@ -0,0 +1,98 @@
+package webhooks_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grantsy/grantsy/internal/infra/config"
+	"github.com/grantsy/grantsy/internal/webhooks"
+	"github.com/grantsy/grantsy/internal/webhooks/mocks"
+)
+
+var configuredEndpoints = []config.WebhookEndpoint{{
+	URL:    "https://static.example.com/hook",
+	Secret: "static-secret",
+	Retry:  config.DefaultWebhookRetry,
+}}
+
+func TestEndpointRegistry_AllEndpoints(t *testing.T) {
+	store := mocks.NewMockEndpointStore(t)
+	store.EXPECT().ListEndpoints(mock.Anything).Return([]webhooks.Endpoint{
+		{ID: "01", URL: "https://db.example.com/hook", Secret: "whsec_c2VjcmV0"},
+	}, nil)
+
+	all, err := webhooks.NewEndpointRegistry(configuredEndpoints, store).AllEndpoints(t.Context())
+
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	assert.Equal(t, "https://static.example.com/hook", all[0].URL)
+	assert.Equal(t, "https://db.example.com/hook", all[1].URL)
+	assert.Equal(t, config.DefaultWebhookRetry, all[1].Retry)
+}
+
+func TestEndpointRegistry_CreateEndpoint(t *testing.T) {
+	store := mocks.NewMockEndpointStore(t)
+	store.EXPECT().GetEndpointByURL(mock.Anything, "https://db.example.com/hook").Return(nil, nil)
+	store.EXPECT().InsertEndpoint(mock.Anything, mock.Anything).Return(nil)
+
+	e, err := webhooks.NewEndpointRegistry(configuredEndpoints, store).
+		CreateEndpoint(t.Context(), webhooks.EndpointInput{URL: "https://db.example.com/hook"})
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, e.ID)
+	assert.True(t, strings.HasPrefix(e.Secret, "whsec_"))
+}
+
+func TestEndpointRegistry_CreateEndpoint_ConfiguredURL(t *testing.T) {
+	store := mocks.NewMockEndpointStore(t)
+
+	_, err := webhooks.NewEndpointRegistry(configuredEndpoints, store).
+		CreateEndpoint(t.Context(), webhooks.EndpointInput{URL: "https://static.example.com/hook"})
+
+	assert.ErrorIs(t, err, webhooks.ErrEndpointExists)
+}
+
+func TestEndpointRegistry_UpdateEndpoint_TakenURL(t *testing.T) {
+	store := mocks.NewMockEndpointStore(t)
+	store.EXPECT().GetEndpoint(mock.Anything, "01").Return(&webhooks.Endpoint{ID: "01"}, nil)
+	store.EXPECT().GetEndpointByURL(mock.Anything, "https://other.example.com/hook").
+		Return(&webhooks.Endpoint{ID: "02"}, nil)
+
+	_, err := webhooks.NewEndpointRegistry(nil, store).
+		UpdateEndpoint(t.Context(), "01", webhooks.EndpointInput{URL: "https://other.example.com/hook"})
+
+	assert.ErrorIs(t, err, webhooks.ErrEndpointExists)
+}
+
+func TestEndpointRegistry_RotateSecret(t *testing.T) {
+	store := mocks.NewMockEndpointStore(t)
+	store.EXPECT().GetEndpoint(mock.Anything, "01").
+		Return(&webhooks.Endpoint{ID: "01", Secret: "whsec_b2xk"}, nil)
+	store.EXPECT().UpdateEndpoint(mock.Anything, mock.Anything).Return(nil)
+
+	e, err := webhooks.NewEndpointRegistry(nil, store).RotateSecret(t.Context(), "01", time.Hour)
+
+	require.NoError(t, err)
+	assert.NotEqual(t, "whsec_b2xk", e.Secret)
+	assert.Equal(t, "whsec_b2xk", e.PrevSecret)
+	assert.Equal(t, []string{e.Secret, "whsec_b2xk"}, e.Secrets(time.Now()))
+	assert.Equal(t, []string{e.Secret}, e.Secrets(time.Now().Add(2*time.Hour)))
+}
+
+func TestEndpointRegistry_RotateSecret_NoRollover(t *testing.T) {
+	store := mocks.NewMockEndpointStore(t)
+	store.EXPECT().GetEndpoint(mock.Anything, "01").
+		Return(&webhooks.Endpoint{ID: "01", Secret: "whsec_b2xk"}, nil)
+	store.EXPECT().UpdateEndpoint(mock.Anything, mock.Anything).Return(nil)
+
+	e, err := webhooks.NewEndpointRegistry(nil, store).RotateSecret(t.Context(), "01", 0)
+
+	require.NoError(t, err)
+	assert.Empty(t, e.PrevSecret)
+	assert.Len(t, e.Secrets(time.Now()), 1)
+}
@@ -0,0 +1,155 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	webhooks "github.com/grantsy/grantsy/internal/webhooks"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockDeadLetterReader is an autogenerated mock type for the DeadLetterReader type
+type MockDeadLetterReader struct {
+	mock.Mock
+}
+
+type MockDeadLetterReader_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDeadLetterReader) EXPECT() *MockDeadLetterReader_Expecter {
+	return &MockDeadLetterReader_Expecter{mock: &_m.Mock}
+}
+
+// GetDeadLetter provides a mock function with given fields: ctx, id
+func (_m *MockDeadLetterReader) GetDeadLetter(ctx context.Context, id string) (*webhooks.DeadLetter, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeadLetter")
+	}
+
+	var r0 *webhooks.DeadLetter
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*webhooks.DeadLetter, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *webhooks.DeadLetter); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*webhooks.DeadLetter)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDeadLetterReader_GetDeadLetter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDeadLetter'
+type MockDeadLetterReader_GetDeadLetter_Call struct {
+	*mock.Call
+}
+
+// GetDeadLetter is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockDeadLetterReader_Expecter) GetDeadLetter(ctx interface{}, id interface{}) *MockDeadLetterReader_GetDeadLetter_Call {
+	return &MockDeadLetterReader_GetDeadLetter_Call{Call: _e.mock.On("GetDeadLetter", ctx, id)}
+}
+
+func (_c *MockDeadLetterReader_GetDeadLetter_Call) Run(run func(ctx context.Context, id string)) *MockDeadLetterReader_GetDeadLetter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockDeadLetterReader_GetDeadLetter_Call) Return(_a0 *webhooks.DeadLetter, _a1 error) *MockDeadLetterReader_GetDeadLetter_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDeadLetterReader_GetDeadLetter_Call) RunAndReturn(run func(context.Context, string) (*webhooks.DeadLetter, error)) *MockDeadLetterReader_GetDeadLetter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListDeadLetters provides a mock function with given fields: ctx, filter
+func (_m *MockDeadLetterReader) ListDeadLetters(ctx context.Context, filter webhooks.DeadLetterFilter) ([]webhooks.DeadLetter, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeadLetters")
+	}
+
+	var r0 []webhooks.DeadLetter
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, webhooks.DeadLetterFilter) ([]webhooks.DeadLetter, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, webhooks.DeadLetterFilter) []webhooks.DeadLetter); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]webhooks.DeadLetter)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, webhooks.DeadLetterFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDeadLetterReader_ListDeadLetters_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDeadLetters'
+type MockDeadLetterReader_ListDeadLetters_Call struct {
+	*mock.Call
+}
+
+// ListDeadLetters is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter webhooks.DeadLetterFilter
+func (_e *MockDeadLetterReader_Expecter) ListDeadLetters(ctx interface{}, filter interface{}) *MockDeadLetterReader_ListDeadLetters_Call {
+	return &MockDeadLetterReader_ListDeadLetters_Call{Call: _e.mock.On("ListDeadLetters", ctx, filter)}
+}
+
+func (_c *MockDeadLetterReader_ListDeadLetters_Call) Run(run func(ctx context.Context, filter webhooks.DeadLetterFilter)) *MockDeadLetterReader_ListDeadLetters_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(webhooks.DeadLetterFilter))
+	})
+	return _c
+}
+
+func (_c *MockDeadLetterReader_ListDeadLetters_Call) Return(_a0 []webhooks.DeadLetter, _a1 error) *MockDeadLetterReader_ListDeadLetters_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDeadLetterReader_ListDeadLetters_Call) RunAndReturn(run func(context.Context, webhooks.DeadLetterFilter) ([]webhooks.DeadLetter, error)) *MockDeadLetterReader_ListDeadLetters_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockDeadLetterReader creates a new instance of MockDeadLetterReader. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDeadLetterReader(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDeadLetterReader {
+	mock := &MockDeadLetterReader{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
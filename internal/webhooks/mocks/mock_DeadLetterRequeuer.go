@@ -0,0 +1,84 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	webhooks "github.com/grantsy/grantsy/internal/webhooks"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockDeadLetterRequeuer is an autogenerated mock type for the DeadLetterRequeuer type
+type MockDeadLetterRequeuer struct {
+	mock.Mock
+}
+
+type MockDeadLetterRequeuer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDeadLetterRequeuer) EXPECT() *MockDeadLetterRequeuer_Expecter {
+	return &MockDeadLetterRequeuer_Expecter{mock: &_m.Mock}
+}
+
+// RequeueDeadLetter provides a mock function with given fields: ctx, dl
+func (_m *MockDeadLetterRequeuer) RequeueDeadLetter(ctx context.Context, dl *webhooks.DeadLetter) error {
+	ret := _m.Called(ctx, dl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequeueDeadLetter")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *webhooks.DeadLetter) error); ok {
+		r0 = rf(ctx, dl)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDeadLetterRequeuer_RequeueDeadLetter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RequeueDeadLetter'
+type MockDeadLetterRequeuer_RequeueDeadLetter_Call struct {
+	*mock.Call
+}
+
+// RequeueDeadLetter is a helper method to define mock.On call
+//   - ctx context.Context
+//   - dl *webhooks.DeadLetter
+func (_e *MockDeadLetterRequeuer_Expecter) RequeueDeadLetter(ctx interface{}, dl interface{}) *MockDeadLetterRequeuer_RequeueDeadLetter_Call {
+	return &MockDeadLetterRequeuer_RequeueDeadLetter_Call{Call: _e.mock.On("RequeueDeadLetter", ctx, dl)}
+}
+
+func (_c *MockDeadLetterRequeuer_RequeueDeadLetter_Call) Run(run func(ctx context.Context, dl *webhooks.DeadLetter)) *MockDeadLetterRequeuer_RequeueDeadLetter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*webhooks.DeadLetter))
+	})
+	return _c
+}
+
+func (_c *MockDeadLetterRequeuer_RequeueDeadLetter_Call) Return(_a0 error) *MockDeadLetterRequeuer_RequeueDeadLetter_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDeadLetterRequeuer_RequeueDeadLetter_Call) RunAndReturn(run func(context.Context, *webhooks.DeadLetter) error) *MockDeadLetterRequeuer_RequeueDeadLetter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockDeadLetterRequeuer creates a new instance of MockDeadLetterRequeuer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDeadLetterRequeuer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDeadLetterRequeuer {
+	mock := &MockDeadLetterRequeuer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
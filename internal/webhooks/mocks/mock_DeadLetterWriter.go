@@ -0,0 +1,142 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	webhooks "github.com/grantsy/grantsy/internal/webhooks"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockDeadLetterWriter is an autogenerated mock type for the DeadLetterWriter type
+type MockDeadLetterWriter struct {
+	mock.Mock
+}
+
+type MockDeadLetterWriter_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDeadLetterWriter) EXPECT() *MockDeadLetterWriter_Expecter {
+	return &MockDeadLetterWriter_Expecter{mock: &_m.Mock}
+}
+
+// CountDeadLetters provides a mock function with given fields: ctx
+func (_m *MockDeadLetterWriter) CountDeadLetters(ctx context.Context) (map[string]int, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountDeadLetters")
+	}
+
+	var r0 map[string]int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (map[string]int, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) map[string]int); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDeadLetterWriter_CountDeadLetters_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountDeadLetters'
+type MockDeadLetterWriter_CountDeadLetters_Call struct {
+	*mock.Call
+}
+
+// CountDeadLetters is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockDeadLetterWriter_Expecter) CountDeadLetters(ctx interface{}) *MockDeadLetterWriter_CountDeadLetters_Call {
+	return &MockDeadLetterWriter_CountDeadLetters_Call{Call: _e.mock.On("CountDeadLetters", ctx)}
+}
+
+func (_c *MockDeadLetterWriter_CountDeadLetters_Call) Run(run func(ctx context.Context)) *MockDeadLetterWriter_CountDeadLetters_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockDeadLetterWriter_CountDeadLetters_Call) Return(_a0 map[string]int, _a1 error) *MockDeadLetterWriter_CountDeadLetters_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDeadLetterWriter_CountDeadLetters_Call) RunAndReturn(run func(context.Context) (map[string]int, error)) *MockDeadLetterWriter_CountDeadLetters_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertDeadLetter provides a mock function with given fields: ctx, dl
+func (_m *MockDeadLetterWriter) InsertDeadLetter(ctx context.Context, dl *webhooks.DeadLetter) error {
+	ret := _m.Called(ctx, dl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertDeadLetter")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *webhooks.DeadLetter) error); ok {
+		r0 = rf(ctx, dl)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDeadLetterWriter_InsertDeadLetter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertDeadLetter'
+type MockDeadLetterWriter_InsertDeadLetter_Call struct {
+	*mock.Call
+}
+
+// InsertDeadLetter is a helper method to define mock.On call
+//   - ctx context.Context
+//   - dl *webhooks.DeadLetter
+func (_e *MockDeadLetterWriter_Expecter) InsertDeadLetter(ctx interface{}, dl interface{}) *MockDeadLetterWriter_InsertDeadLetter_Call {
+	return &MockDeadLetterWriter_InsertDeadLetter_Call{Call: _e.mock.On("InsertDeadLetter", ctx, dl)}
+}
+
+func (_c *MockDeadLetterWriter_InsertDeadLetter_Call) Run(run func(ctx context.Context, dl *webhooks.DeadLetter)) *MockDeadLetterWriter_InsertDeadLetter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*webhooks.DeadLetter))
+	})
+	return _c
+}
+
+func (_c *MockDeadLetterWriter_InsertDeadLetter_Call) Return(_a0 error) *MockDeadLetterWriter_InsertDeadLetter_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDeadLetterWriter_InsertDeadLetter_Call) RunAndReturn(run func(context.Context, *webhooks.DeadLetter) error) *MockDeadLetterWriter_InsertDeadLetter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockDeadLetterWriter creates a new instance of MockDeadLetterWriter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDeadLetterWriter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDeadLetterWriter {
+	mock := &MockDeadLetterWriter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
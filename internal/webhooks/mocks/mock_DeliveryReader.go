@@ -0,0 +1,155 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	webhooks "github.com/grantsy/grantsy/internal/webhooks"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockDeliveryReader is an autogenerated mock type for the DeliveryReader type
+type MockDeliveryReader struct {
+	mock.Mock
+}
+
+type MockDeliveryReader_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDeliveryReader) EXPECT() *MockDeliveryReader_Expecter {
+	return &MockDeliveryReader_Expecter{mock: &_m.Mock}
+}
+
+// GetDelivery provides a mock function with given fields: ctx, id
+func (_m *MockDeliveryReader) GetDelivery(ctx context.Context, id string) (*webhooks.Delivery, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDelivery")
+	}
+
+	var r0 *webhooks.Delivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*webhooks.Delivery, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *webhooks.Delivery); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*webhooks.Delivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDeliveryReader_GetDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDelivery'
+type MockDeliveryReader_GetDelivery_Call struct {
+	*mock.Call
+}
+
+// GetDelivery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockDeliveryReader_Expecter) GetDelivery(ctx interface{}, id interface{}) *MockDeliveryReader_GetDelivery_Call {
+	return &MockDeliveryReader_GetDelivery_Call{Call: _e.mock.On("GetDelivery", ctx, id)}
+}
+
+func (_c *MockDeliveryReader_GetDelivery_Call) Run(run func(ctx context.Context, id string)) *MockDeliveryReader_GetDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockDeliveryReader_GetDelivery_Call) Return(_a0 *webhooks.Delivery, _a1 error) *MockDeliveryReader_GetDelivery_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDeliveryReader_GetDelivery_Call) RunAndReturn(run func(context.Context, string) (*webhooks.Delivery, error)) *MockDeliveryReader_GetDelivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListDeliveries provides a mock function with given fields: ctx, filter
+func (_m *MockDeliveryReader) ListDeliveries(ctx context.Context, filter webhooks.DeliveryFilter) ([]webhooks.Delivery, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeliveries")
+	}
+
+	var r0 []webhooks.Delivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, webhooks.DeliveryFilter) ([]webhooks.Delivery, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, webhooks.DeliveryFilter) []webhooks.Delivery); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]webhooks.Delivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, webhooks.DeliveryFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDeliveryReader_ListDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDeliveries'
+type MockDeliveryReader_ListDeliveries_Call struct {
+	*mock.Call
+}
+
+// ListDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter webhooks.DeliveryFilter
+func (_e *MockDeliveryReader_Expecter) ListDeliveries(ctx interface{}, filter interface{}) *MockDeliveryReader_ListDeliveries_Call {
+	return &MockDeliveryReader_ListDeliveries_Call{Call: _e.mock.On("ListDeliveries", ctx, filter)}
+}
+
+func (_c *MockDeliveryReader_ListDeliveries_Call) Run(run func(ctx context.Context, filter webhooks.DeliveryFilter)) *MockDeliveryReader_ListDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(webhooks.DeliveryFilter))
+	})
+	return _c
+}
+
+func (_c *MockDeliveryReader_ListDeliveries_Call) Return(_a0 []webhooks.Delivery, _a1 error) *MockDeliveryReader_ListDeliveries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDeliveryReader_ListDeliveries_Call) RunAndReturn(run func(context.Context, webhooks.DeliveryFilter) ([]webhooks.Delivery, error)) *MockDeliveryReader_ListDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockDeliveryReader creates a new instance of MockDeliveryReader. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDeliveryReader(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDeliveryReader {
+	mock := &MockDeliveryReader{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,84 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	webhooks "github.com/grantsy/grantsy/internal/webhooks"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockDeliveryRecorder is an autogenerated mock type for the DeliveryRecorder type
+type MockDeliveryRecorder struct {
+	mock.Mock
+}
+
+type MockDeliveryRecorder_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDeliveryRecorder) EXPECT() *MockDeliveryRecorder_Expecter {
+	return &MockDeliveryRecorder_Expecter{mock: &_m.Mock}
+}
+
+// InsertDelivery provides a mock function with given fields: ctx, d
+func (_m *MockDeliveryRecorder) InsertDelivery(ctx context.Context, d *webhooks.Delivery) error {
+	ret := _m.Called(ctx, d)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertDelivery")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *webhooks.Delivery) error); ok {
+		r0 = rf(ctx, d)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDeliveryRecorder_InsertDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertDelivery'
+type MockDeliveryRecorder_InsertDelivery_Call struct {
+	*mock.Call
+}
+
+// InsertDelivery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - d *webhooks.Delivery
+func (_e *MockDeliveryRecorder_Expecter) InsertDelivery(ctx interface{}, d interface{}) *MockDeliveryRecorder_InsertDelivery_Call {
+	return &MockDeliveryRecorder_InsertDelivery_Call{Call: _e.mock.On("InsertDelivery", ctx, d)}
+}
+
+func (_c *MockDeliveryRecorder_InsertDelivery_Call) Run(run func(ctx context.Context, d *webhooks.Delivery)) *MockDeliveryRecorder_InsertDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*webhooks.Delivery))
+	})
+	return _c
+}
+
+func (_c *MockDeliveryRecorder_InsertDelivery_Call) Return(_a0 error) *MockDeliveryRecorder_InsertDelivery_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDeliveryRecorder_InsertDelivery_Call) RunAndReturn(run func(context.Context, *webhooks.Delivery) error) *MockDeliveryRecorder_InsertDelivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockDeliveryRecorder creates a new instance of MockDeliveryRecorder. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDeliveryRecorder(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDeliveryRecorder {
+	mock := &MockDeliveryRecorder{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,95 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	webhooks "github.com/grantsy/grantsy/internal/webhooks"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockEndpointLister is an autogenerated mock type for the EndpointLister type
+type MockEndpointLister struct {
+	mock.Mock
+}
+
+type MockEndpointLister_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockEndpointLister) EXPECT() *MockEndpointLister_Expecter {
+	return &MockEndpointLister_Expecter{mock: &_m.Mock}
+}
+
+// AllEndpoints provides a mock function with given fields: ctx
+func (_m *MockEndpointLister) AllEndpoints(ctx context.Context) ([]webhooks.Endpoint, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AllEndpoints")
+	}
+
+	var r0 []webhooks.Endpoint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]webhooks.Endpoint, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []webhooks.Endpoint); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]webhooks.Endpoint)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockEndpointLister_AllEndpoints_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AllEndpoints'
+type MockEndpointLister_AllEndpoints_Call struct {
+	*mock.Call
+}
+
+// AllEndpoints is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockEndpointLister_Expecter) AllEndpoints(ctx interface{}) *MockEndpointLister_AllEndpoints_Call {
+	return &MockEndpointLister_AllEndpoints_Call{Call: _e.mock.On("AllEndpoints", ctx)}
+}
+
+func (_c *MockEndpointLister_AllEndpoints_Call) Run(run func(ctx context.Context)) *MockEndpointLister_AllEndpoints_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockEndpointLister_AllEndpoints_Call) Return(_a0 []webhooks.Endpoint, _a1 error) *MockEndpointLister_AllEndpoints_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockEndpointLister_AllEndpoints_Call) RunAndReturn(run func(context.Context) ([]webhooks.Endpoint, error)) *MockEndpointLister_AllEndpoints_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockEndpointLister creates a new instance of MockEndpointLister. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockEndpointLister(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockEndpointLister {
+	mock := &MockEndpointLister{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,154 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	webhooks "github.com/grantsy/grantsy/internal/webhooks"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockEndpointReader is an autogenerated mock type for the EndpointReader type
+type MockEndpointReader struct {
+	mock.Mock
+}
+
+type MockEndpointReader_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockEndpointReader) EXPECT() *MockEndpointReader_Expecter {
+	return &MockEndpointReader_Expecter{mock: &_m.Mock}
+}
+
+// GetEndpoint provides a mock function with given fields: ctx, id
+func (_m *MockEndpointReader) GetEndpoint(ctx context.Context, id string) (*webhooks.Endpoint, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEndpoint")
+	}
+
+	var r0 *webhooks.Endpoint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*webhooks.Endpoint, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *webhooks.Endpoint); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*webhooks.Endpoint)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockEndpointReader_GetEndpoint_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEndpoint'
+type MockEndpointReader_GetEndpoint_Call struct {
+	*mock.Call
+}
+
+// GetEndpoint is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockEndpointReader_Expecter) GetEndpoint(ctx interface{}, id interface{}) *MockEndpointReader_GetEndpoint_Call {
+	return &MockEndpointReader_GetEndpoint_Call{Call: _e.mock.On("GetEndpoint", ctx, id)}
+}
+
+func (_c *MockEndpointReader_GetEndpoint_Call) Run(run func(ctx context.Context, id string)) *MockEndpointReader_GetEndpoint_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockEndpointReader_GetEndpoint_Call) Return(_a0 *webhooks.Endpoint, _a1 error) *MockEndpointReader_GetEndpoint_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockEndpointReader_GetEndpoint_Call) RunAndReturn(run func(context.Context, string) (*webhooks.Endpoint, error)) *MockEndpointReader_GetEndpoint_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListEndpoints provides a mock function with given fields: ctx
+func (_m *MockEndpointReader) ListEndpoints(ctx context.Context) ([]webhooks.Endpoint, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListEndpoints")
+	}
+
+	var r0 []webhooks.Endpoint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]webhooks.Endpoint, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []webhooks.Endpoint); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]webhooks.Endpoint)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockEndpointReader_ListEndpoints_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListEndpoints'
+type MockEndpointReader_ListEndpoints_Call struct {
+	*mock.Call
+}
+
+// ListEndpoints is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockEndpointReader_Expecter) ListEndpoints(ctx interface{}) *MockEndpointReader_ListEndpoints_Call {
+	return &MockEndpointReader_ListEndpoints_Call{Call: _e.mock.On("ListEndpoints", ctx)}
+}
+
+func (_c *MockEndpointReader_ListEndpoints_Call) Run(run func(ctx context.Context)) *MockEndpointReader_ListEndpoints_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockEndpointReader_ListEndpoints_Call) Return(_a0 []webhooks.Endpoint, _a1 error) *MockEndpointReader_ListEndpoints_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockEndpointReader_ListEndpoints_Call) RunAndReturn(run func(context.Context) ([]webhooks.Endpoint, error)) *MockEndpointReader_ListEndpoints_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockEndpointReader creates a new instance of MockEndpointReader. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockEndpointReader(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockEndpointReader {
+	mock := &MockEndpointReader{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,364 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	webhooks "github.com/grantsy/grantsy/internal/webhooks"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockEndpointStore is an autogenerated mock type for the EndpointStore type
+type MockEndpointStore struct {
+	mock.Mock
+}
+
+type MockEndpointStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockEndpointStore) EXPECT() *MockEndpointStore_Expecter {
+	return &MockEndpointStore_Expecter{mock: &_m.Mock}
+}
+
+// DeleteEndpoint provides a mock function with given fields: ctx, id
+func (_m *MockEndpointStore) DeleteEndpoint(ctx context.Context, id string) (bool, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteEndpoint")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockEndpointStore_DeleteEndpoint_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteEndpoint'
+type MockEndpointStore_DeleteEndpoint_Call struct {
+	*mock.Call
+}
+
+// DeleteEndpoint is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockEndpointStore_Expecter) DeleteEndpoint(ctx interface{}, id interface{}) *MockEndpointStore_DeleteEndpoint_Call {
+	return &MockEndpointStore_DeleteEndpoint_Call{Call: _e.mock.On("DeleteEndpoint", ctx, id)}
+}
+
+func (_c *MockEndpointStore_DeleteEndpoint_Call) Run(run func(ctx context.Context, id string)) *MockEndpointStore_DeleteEndpoint_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockEndpointStore_DeleteEndpoint_Call) Return(_a0 bool, _a1 error) *MockEndpointStore_DeleteEndpoint_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockEndpointStore_DeleteEndpoint_Call) RunAndReturn(run func(context.Context, string) (bool, error)) *MockEndpointStore_DeleteEndpoint_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEndpoint provides a mock function with given fields: ctx, id
+func (_m *MockEndpointStore) GetEndpoint(ctx context.Context, id string) (*webhooks.Endpoint, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEndpoint")
+	}
+
+	var r0 *webhooks.Endpoint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*webhooks.Endpoint, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *webhooks.Endpoint); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*webhooks.Endpoint)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockEndpointStore_GetEndpoint_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEndpoint'
+type MockEndpointStore_GetEndpoint_Call struct {
+	*mock.Call
+}
+
+// GetEndpoint is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockEndpointStore_Expecter) GetEndpoint(ctx interface{}, id interface{}) *MockEndpointStore_GetEndpoint_Call {
+	return &MockEndpointStore_GetEndpoint_Call{Call: _e.mock.On("GetEndpoint", ctx, id)}
+}
+
+func (_c *MockEndpointStore_GetEndpoint_Call) Run(run func(ctx context.Context, id string)) *MockEndpointStore_GetEndpoint_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockEndpointStore_GetEndpoint_Call) Return(_a0 *webhooks.Endpoint, _a1 error) *MockEndpointStore_GetEndpoint_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockEndpointStore_GetEndpoint_Call) RunAndReturn(run func(context.Context, string) (*webhooks.Endpoint, error)) *MockEndpointStore_GetEndpoint_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEndpointByURL provides a mock function with given fields: ctx, url
+func (_m *MockEndpointStore) GetEndpointByURL(ctx context.Context, url string) (*webhooks.Endpoint, error) {
+	ret := _m.Called(ctx, url)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEndpointByURL")
+	}
+
+	var r0 *webhooks.Endpoint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*webhooks.Endpoint, error)); ok {
+		return rf(ctx, url)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *webhooks.Endpoint); ok {
+		r0 = rf(ctx, url)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*webhooks.Endpoint)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, url)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockEndpointStore_GetEndpointByURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEndpointByURL'
+type MockEndpointStore_GetEndpointByURL_Call struct {
+	*mock.Call
+}
+
+// GetEndpointByURL is a helper method to define mock.On call
+//   - ctx context.Context
+//   - url string
+func (_e *MockEndpointStore_Expecter) GetEndpointByURL(ctx interface{}, url interface{}) *MockEndpointStore_GetEndpointByURL_Call {
+	return &MockEndpointStore_GetEndpointByURL_Call{Call: _e.mock.On("GetEndpointByURL", ctx, url)}
+}
+
+func (_c *MockEndpointStore_GetEndpointByURL_Call) Run(run func(ctx context.Context, url string)) *MockEndpointStore_GetEndpointByURL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockEndpointStore_GetEndpointByURL_Call) Return(_a0 *webhooks.Endpoint, _a1 error) *MockEndpointStore_GetEndpointByURL_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockEndpointStore_GetEndpointByURL_Call) RunAndReturn(run func(context.Context, string) (*webhooks.Endpoint, error)) *MockEndpointStore_GetEndpointByURL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertEndpoint provides a mock function with given fields: ctx, e
+func (_m *MockEndpointStore) InsertEndpoint(ctx context.Context, e *webhooks.Endpoint) error {
+	ret := _m.Called(ctx, e)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertEndpoint")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *webhooks.Endpoint) error); ok {
+		r0 = rf(ctx, e)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockEndpointStore_InsertEndpoint_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertEndpoint'
+type MockEndpointStore_InsertEndpoint_Call struct {
+	*mock.Call
+}
+
+// InsertEndpoint is a helper method to define mock.On call
+//   - ctx context.Context
+//   - e *webhooks.Endpoint
+func (_e *MockEndpointStore_Expecter) InsertEndpoint(ctx interface{}, e interface{}) *MockEndpointStore_InsertEndpoint_Call {
+	return &MockEndpointStore_InsertEndpoint_Call{Call: _e.mock.On("InsertEndpoint", ctx, e)}
+}
+
+func (_c *MockEndpointStore_InsertEndpoint_Call) Run(run func(ctx context.Context, e *webhooks.Endpoint)) *MockEndpointStore_InsertEndpoint_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*webhooks.Endpoint))
+	})
+	return _c
+}
+
+func (_c *MockEndpointStore_InsertEndpoint_Call) Return(_a0 error) *MockEndpointStore_InsertEndpoint_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockEndpointStore_InsertEndpoint_Call) RunAndReturn(run func(context.Context, *webhooks.Endpoint) error) *MockEndpointStore_InsertEndpoint_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListEndpoints provides a mock function with given fields: ctx
+func (_m *MockEndpointStore) ListEndpoints(ctx context.Context) ([]webhooks.Endpoint, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListEndpoints")
+	}
+
+	var r0 []webhooks.Endpoint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]webhooks.Endpoint, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []webhooks.Endpoint); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]webhooks.Endpoint)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockEndpointStore_ListEndpoints_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListEndpoints'
+type MockEndpointStore_ListEndpoints_Call struct {
+	*mock.Call
+}
+
+// ListEndpoints is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockEndpointStore_Expecter) ListEndpoints(ctx interface{}) *MockEndpointStore_ListEndpoints_Call {
+	return &MockEndpointStore_ListEndpoints_Call{Call: _e.mock.On("ListEndpoints", ctx)}
+}
+
+func (_c *MockEndpointStore_ListEndpoints_Call) Run(run func(ctx context.Context)) *MockEndpointStore_ListEndpoints_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockEndpointStore_ListEndpoints_Call) Return(_a0 []webhooks.Endpoint, _a1 error) *MockEndpointStore_ListEndpoints_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockEndpointStore_ListEndpoints_Call) RunAndReturn(run func(context.Context) ([]webhooks.Endpoint, error)) *MockEndpointStore_ListEndpoints_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateEndpoint provides a mock function with given fields: ctx, e
+func (_m *MockEndpointStore) UpdateEndpoint(ctx context.Context, e *webhooks.Endpoint) error {
+	ret := _m.Called(ctx, e)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateEndpoint")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *webhooks.Endpoint) error); ok {
+		r0 = rf(ctx, e)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockEndpointStore_UpdateEndpoint_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateEndpoint'
+type MockEndpointStore_UpdateEndpoint_Call struct {
+	*mock.Call
+}
+
+// UpdateEndpoint is a helper method to define mock.On call
+//   - ctx context.Context
+//   - e *webhooks.Endpoint
+func (_e *MockEndpointStore_Expecter) UpdateEndpoint(ctx interface{}, e interface{}) *MockEndpointStore_UpdateEndpoint_Call {
+	return &MockEndpointStore_UpdateEndpoint_Call{Call: _e.mock.On("UpdateEndpoint", ctx, e)}
+}
+
+func (_c *MockEndpointStore_UpdateEndpoint_Call) Run(run func(ctx context.Context, e *webhooks.Endpoint)) *MockEndpointStore_UpdateEndpoint_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*webhooks.Endpoint))
+	})
+	return _c
+}
+
+func (_c *MockEndpointStore_UpdateEndpoint_Call) Return(_a0 error) *MockEndpointStore_UpdateEndpoint_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockEndpointStore_UpdateEndpoint_Call) RunAndReturn(run func(context.Context, *webhooks.Endpoint) error) *MockEndpointStore_UpdateEndpoint_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockEndpointStore creates a new instance of MockEndpointStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockEndpointStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockEndpointStore {
+	mock := &MockEndpointStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
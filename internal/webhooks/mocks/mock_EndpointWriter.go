@@ -0,0 +1,275 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+
+	webhooks "github.com/grantsy/grantsy/internal/webhooks"
+)
+
+// MockEndpointWriter is an autogenerated mock type for the EndpointWriter type
+type MockEndpointWriter struct {
+	mock.Mock
+}
+
+type MockEndpointWriter_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockEndpointWriter) EXPECT() *MockEndpointWriter_Expecter {
+	return &MockEndpointWriter_Expecter{mock: &_m.Mock}
+}
+
+// CreateEndpoint provides a mock function with given fields: ctx, in
+func (_m *MockEndpointWriter) CreateEndpoint(ctx context.Context, in webhooks.EndpointInput) (*webhooks.Endpoint, error) {
+	ret := _m.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateEndpoint")
+	}
+
+	var r0 *webhooks.Endpoint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, webhooks.EndpointInput) (*webhooks.Endpoint, error)); ok {
+		return rf(ctx, in)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, webhooks.EndpointInput) *webhooks.Endpoint); ok {
+		r0 = rf(ctx, in)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*webhooks.Endpoint)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, webhooks.EndpointInput) error); ok {
+		r1 = rf(ctx, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockEndpointWriter_CreateEndpoint_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateEndpoint'
+type MockEndpointWriter_CreateEndpoint_Call struct {
+	*mock.Call
+}
+
+// CreateEndpoint is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in webhooks.EndpointInput
+func (_e *MockEndpointWriter_Expecter) CreateEndpoint(ctx interface{}, in interface{}) *MockEndpointWriter_CreateEndpoint_Call {
+	return &MockEndpointWriter_CreateEndpoint_Call{Call: _e.mock.On("CreateEndpoint", ctx, in)}
+}
+
+func (_c *MockEndpointWriter_CreateEndpoint_Call) Run(run func(ctx context.Context, in webhooks.EndpointInput)) *MockEndpointWriter_CreateEndpoint_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(webhooks.EndpointInput))
+	})
+	return _c
+}
+
+func (_c *MockEndpointWriter_CreateEndpoint_Call) Return(_a0 *webhooks.Endpoint, _a1 error) *MockEndpointWriter_CreateEndpoint_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockEndpointWriter_CreateEndpoint_Call) RunAndReturn(run func(context.Context, webhooks.EndpointInput) (*webhooks.Endpoint, error)) *MockEndpointWriter_CreateEndpoint_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteEndpoint provides a mock function with given fields: ctx, id
+func (_m *MockEndpointWriter) DeleteEndpoint(ctx context.Context, id string) (bool, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteEndpoint")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockEndpointWriter_DeleteEndpoint_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteEndpoint'
+type MockEndpointWriter_DeleteEndpoint_Call struct {
+	*mock.Call
+}
+
+// DeleteEndpoint is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockEndpointWriter_Expecter) DeleteEndpoint(ctx interface{}, id interface{}) *MockEndpointWriter_DeleteEndpoint_Call {
+	return &MockEndpointWriter_DeleteEndpoint_Call{Call: _e.mock.On("DeleteEndpoint", ctx, id)}
+}
+
+func (_c *MockEndpointWriter_DeleteEndpoint_Call) Run(run func(ctx context.Context, id string)) *MockEndpointWriter_DeleteEndpoint_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockEndpointWriter_DeleteEndpoint_Call) Return(_a0 bool, _a1 error) *MockEndpointWriter_DeleteEndpoint_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockEndpointWriter_DeleteEndpoint_Call) RunAndReturn(run func(context.Context, string) (bool, error)) *MockEndpointWriter_DeleteEndpoint_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RotateSecret provides a mock function with given fields: ctx, id, rollover
+func (_m *MockEndpointWriter) RotateSecret(ctx context.Context, id string, rollover time.Duration) (*webhooks.Endpoint, error) {
+	ret := _m.Called(ctx, id, rollover)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RotateSecret")
+	}
+
+	var r0 *webhooks.Endpoint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) (*webhooks.Endpoint, error)); ok {
+		return rf(ctx, id, rollover)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) *webhooks.Endpoint); ok {
+		r0 = rf(ctx, id, rollover)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*webhooks.Endpoint)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration) error); ok {
+		r1 = rf(ctx, id, rollover)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockEndpointWriter_RotateSecret_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RotateSecret'
+type MockEndpointWriter_RotateSecret_Call struct {
+	*mock.Call
+}
+
+// RotateSecret is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - rollover time.Duration
+func (_e *MockEndpointWriter_Expecter) RotateSecret(ctx interface{}, id interface{}, rollover interface{}) *MockEndpointWriter_RotateSecret_Call {
+	return &MockEndpointWriter_RotateSecret_Call{Call: _e.mock.On("RotateSecret", ctx, id, rollover)}
+}
+
+func (_c *MockEndpointWriter_RotateSecret_Call) Run(run func(ctx context.Context, id string, rollover time.Duration)) *MockEndpointWriter_RotateSecret_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockEndpointWriter_RotateSecret_Call) Return(_a0 *webhooks.Endpoint, _a1 error) *MockEndpointWriter_RotateSecret_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockEndpointWriter_RotateSecret_Call) RunAndReturn(run func(context.Context, string, time.Duration) (*webhooks.Endpoint, error)) *MockEndpointWriter_RotateSecret_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateEndpoint provides a mock function with given fields: ctx, id, in
+func (_m *MockEndpointWriter) UpdateEndpoint(ctx context.Context, id string, in webhooks.EndpointInput) (*webhooks.Endpoint, error) {
+	ret := _m.Called(ctx, id, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateEndpoint")
+	}
+
+	var r0 *webhooks.Endpoint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, webhooks.EndpointInput) (*webhooks.Endpoint, error)); ok {
+		return rf(ctx, id, in)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, webhooks.EndpointInput) *webhooks.Endpoint); ok {
+		r0 = rf(ctx, id, in)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*webhooks.Endpoint)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, webhooks.EndpointInput) error); ok {
+		r1 = rf(ctx, id, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockEndpointWriter_UpdateEndpoint_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateEndpoint'
+type MockEndpointWriter_UpdateEndpoint_Call struct {
+	*mock.Call
+}
+
+// UpdateEndpoint is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - in webhooks.EndpointInput
+func (_e *MockEndpointWriter_Expecter) UpdateEndpoint(ctx interface{}, id interface{}, in interface{}) *MockEndpointWriter_UpdateEndpoint_Call {
+	return &MockEndpointWriter_UpdateEndpoint_Call{Call: _e.mock.On("UpdateEndpoint", ctx, id, in)}
+}
+
+func (_c *MockEndpointWriter_UpdateEndpoint_Call) Run(run func(ctx context.Context, id string, in webhooks.EndpointInput)) *MockEndpointWriter_UpdateEndpoint_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(webhooks.EndpointInput))
+	})
+	return _c
+}
+
+func (_c *MockEndpointWriter_UpdateEndpoint_Call) Return(_a0 *webhooks.Endpoint, _a1 error) *MockEndpointWriter_UpdateEndpoint_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockEndpointWriter_UpdateEndpoint_Call) RunAndReturn(run func(context.Context, string, webhooks.EndpointInput) (*webhooks.Endpoint, error)) *MockEndpointWriter_UpdateEndpoint_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockEndpointWriter creates a new instance of MockEndpointWriter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockEndpointWriter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockEndpointWriter {
+	mock := &MockEndpointWriter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,87 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+
+	webhooks "github.com/grantsy/grantsy/internal/webhooks"
+)
+
+// MockEnqueuer is an autogenerated mock type for the Enqueuer type
+type MockEnqueuer struct {
+	mock.Mock
+}
+
+type MockEnqueuer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockEnqueuer) EXPECT() *MockEnqueuer_Expecter {
+	return &MockEnqueuer_Expecter{mock: &_m.Mock}
+}
+
+// Enqueue provides a mock function with given fields: ctx, msg, delay
+func (_m *MockEnqueuer) Enqueue(ctx context.Context, msg webhooks.Message, delay time.Duration) error {
+	ret := _m.Called(ctx, msg, delay)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Enqueue")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, webhooks.Message, time.Duration) error); ok {
+		r0 = rf(ctx, msg, delay)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockEnqueuer_Enqueue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Enqueue'
+type MockEnqueuer_Enqueue_Call struct {
+	*mock.Call
+}
+
+// Enqueue is a helper method to define mock.On call
+//   - ctx context.Context
+//   - msg webhooks.Message
+//   - delay time.Duration
+func (_e *MockEnqueuer_Expecter) Enqueue(ctx interface{}, msg interface{}, delay interface{}) *MockEnqueuer_Enqueue_Call {
+	return &MockEnqueuer_Enqueue_Call{Call: _e.mock.On("Enqueue", ctx, msg, delay)}
+}
+
+func (_c *MockEnqueuer_Enqueue_Call) Run(run func(ctx context.Context, msg webhooks.Message, delay time.Duration)) *MockEnqueuer_Enqueue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(webhooks.Message), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockEnqueuer_Enqueue_Call) Return(_a0 error) *MockEnqueuer_Enqueue_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockEnqueuer_Enqueue_Call) RunAndReturn(run func(context.Context, webhooks.Message, time.Duration) error) *MockEnqueuer_Enqueue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockEnqueuer creates a new instance of MockEnqueuer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockEnqueuer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockEnqueuer {
+	mock := &MockEnqueuer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,84 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	webhooks "github.com/grantsy/grantsy/internal/webhooks"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockRedeliverer is an autogenerated mock type for the Redeliverer type
+type MockRedeliverer struct {
+	mock.Mock
+}
+
+type MockRedeliverer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRedeliverer) EXPECT() *MockRedeliverer_Expecter {
+	return &MockRedeliverer_Expecter{mock: &_m.Mock}
+}
+
+// Redeliver provides a mock function with given fields: ctx, d
+func (_m *MockRedeliverer) Redeliver(ctx context.Context, d *webhooks.Delivery) error {
+	ret := _m.Called(ctx, d)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Redeliver")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *webhooks.Delivery) error); ok {
+		r0 = rf(ctx, d)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRedeliverer_Redeliver_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Redeliver'
+type MockRedeliverer_Redeliver_Call struct {
+	*mock.Call
+}
+
+// Redeliver is a helper method to define mock.On call
+//   - ctx context.Context
+//   - d *webhooks.Delivery
+func (_e *MockRedeliverer_Expecter) Redeliver(ctx interface{}, d interface{}) *MockRedeliverer_Redeliver_Call {
+	return &MockRedeliverer_Redeliver_Call{Call: _e.mock.On("Redeliver", ctx, d)}
+}
+
+func (_c *MockRedeliverer_Redeliver_Call) Run(run func(ctx context.Context, d *webhooks.Delivery)) *MockRedeliverer_Redeliver_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*webhooks.Delivery))
+	})
+	return _c
+}
+
+func (_c *MockRedeliverer_Redeliver_Call) Return(_a0 error) *MockRedeliverer_Redeliver_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRedeliverer_Redeliver_Call) RunAndReturn(run func(context.Context, *webhooks.Delivery) error) *MockRedeliverer_Redeliver_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRedeliverer creates a new instance of MockRedeliverer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRedeliverer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRedeliverer {
+	mock := &MockRedeliverer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -1,15 +1,48 @@
 package webhooks
 
+import "encoding/json"
+
+// EventType identifies the kind of event sent to webhook endpoints
+type EventType string
+
+const (
+	// EventUserPlanChanged is sent when a user's active plan changes.
+	EventUserPlanChanged EventType = "user.plan_changed"
+	// EventSubscriptionUpdated is sent on every subscription state change.
+	EventSubscriptionUpdated EventType = "subscription.updated"
+	// EventSubscriptionPaymentSucceeded is sent when a renewal payment succeeds.
+	EventSubscriptionPaymentSucceeded EventType = "subscription.payment_succeeded"
+	// EventSubscriptionPaymentFailed is sent when a renewal payment fails.
+	EventSubscriptionPaymentFailed EventType = "subscription.payment_failed"
+	// EventSubscriptionPaymentRecovered is sent when a failed payment is recovered.
+	EventSubscriptionPaymentRecovered EventType = "subscription.payment_recovered"
+	// EventGrantExpired is sent when a subscription stops granting its plan.
+	EventGrantExpired EventType = "grant.expired"
+)
+
 // Payload is the structure sent to webhook endpoints
 type Payload struct {
-	Endpoint   string `json:"endpoint"`
-	UserID     string `json:"user_id"`
-	ActivePlan string `json:"active_plan"`
-	Meta       Meta   `json:"meta"`
+	ID         string    `json:"id"`       // Message ID, also sent as the webhook-id header; the same across retries
+	Sequence   int64     `json:"sequence"` // Per-user sequence number, increasing with every event
+	Type       EventType `json:"type"`
+	Endpoint   string    `json:"endpoint"`
+	EndpointID string    `json:"endpoint_id,omitempty"` // Empty for endpoints from the config file
+	UserID     string    `json:"user_id"`
+	ActivePlan string    `json:"active_plan"`
+	Meta       Meta      `json:"meta"`
 }
 
-// Meta contains additional context about the plan update
+// Meta contains additional context about the event
 type Meta struct {
-	PrevPlan     string `json:"prev_plan"`
-	Subscription any    `json:"subscription"` // Full subscription object
+	PrevPlan     string `json:"prev_plan,omitempty"`
+	Subscription any    `json:"subscription"`      // Full subscription object
+	Payment      any    `json:"payment,omitempty"` // Payment object for subscription.payment_* events
+}
+
+// Message is the body of a queued webhook job: the payload for one endpoint
+// and how often delivery has been attempted so far.
+type Message struct {
+	Payload  json.RawMessage `json:"payload"`
+	Attempt  int             `json:"attempt"`
+	QueuedAt int64           `json:"queued_at"` // Unix time the payload was first queued
 }
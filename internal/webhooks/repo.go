@@ -0,0 +1,348 @@
+package webhooks
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/grantsy/grantsy/internal/infra/db"
+)
+
+// Delivery is a single attempt to send a webhook to an endpoint.
+type Delivery struct {
+	ID         string
+	Endpoint   string
+	EventType  string
+	UserID     string
+	Payload    []byte
+	Success    bool
+	StatusCode int
+	DurationMs int64
+	Response   string
+	Error      string
+	CreatedAt  int64
+}
+
+// DeliveryFilter narrows ListDeliveries results.
+// Deliveries are returned newest first; Cursor is the ID of the last delivery of the previous page.
+type DeliveryFilter struct {
+	Endpoint  string
+	EventType string
+	Success   *bool
+	Cursor    string
+	Limit     int
+}
+
+type Repo struct {
+	db *db.DB
+}
+
+func NewRepo(database *db.DB) *Repo {
+	return &Repo{db: database}
+}
+
+const deliveryColumns = `id, endpoint, event_type, user_id, payload, success,
+			status_code, duration_ms, response, error, created_at`
+
+// InsertDelivery records a delivery attempt.
+func (r *Repo) InsertDelivery(ctx context.Context, d *Delivery) error {
+	table := r.db.TableName("webhook_deliveries")
+	query := r.db.Rebind(fmt.Sprintf(`
+		INSERT INTO %s (%s)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`, table, deliveryColumns))
+
+	_, err := r.db.ExecContext(
+		ctx,
+		query,
+		d.ID,
+		d.Endpoint,
+		d.EventType,
+		d.UserID,
+		string(d.Payload),
+		d.Success,
+		d.StatusCode,
+		d.DurationMs,
+		d.Response,
+		d.Error,
+		d.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("webhooks: failed to insert delivery: %w", err)
+	}
+	return nil
+}
+
+// GetDelivery returns the delivery with the given ID, or nil if not found.
+func (r *Repo) GetDelivery(ctx context.Context, id string) (*Delivery, error) {
+	table := r.db.TableName("webhook_deliveries")
+	query := r.db.Rebind(fmt.Sprintf(`
+		SELECT %s FROM %s WHERE id = $1
+	`, deliveryColumns, table))
+
+	d, err := scanDelivery(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("webhooks: failed to get delivery: %w", err)
+	}
+	return d, nil
+}
+
+// ListDeliveries returns deliveries matching the filter, newest first.
+func (r *Repo) ListDeliveries(ctx context.Context, filter DeliveryFilter) ([]Delivery, error) {
+	var conds []string
+	var args []any
+	if filter.Endpoint != "" {
+		args = append(args, filter.Endpoint)
+		conds = append(conds, fmt.Sprintf("endpoint = $%d", len(args)))
+	}
+	if filter.EventType != "" {
+		args = append(args, filter.EventType)
+		conds = append(conds, fmt.Sprintf("event_type = $%d", len(args)))
+	}
+	if filter.Success != nil {
+		args = append(args, *filter.Success)
+		conds = append(conds, fmt.Sprintf("success = $%d", len(args)))
+	}
+	if filter.Cursor != "" {
+		args = append(args, filter.Cursor)
+		conds = append(conds, fmt.Sprintf("id < $%d", len(args)))
+	}
+	where := ""
+	if len(conds) > 0 {
+		where = "WHERE " + strings.Join(conds, " AND ")
+	}
+	args = append(args, filter.Limit)
+
+	table := r.db.TableName("webhook_deliveries")
+	query := r.db.Rebind(fmt.Sprintf(`
+		SELECT %s FROM %s
+		%s
+		ORDER BY id DESC
+		LIMIT $%d
+	`, deliveryColumns, table, where, len(args)))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("webhooks: failed to query deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var result []Delivery
+	for rows.Next() {
+		d, err := scanDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("webhooks: failed to scan row: %w", err)
+		}
+		result = append(result, *d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("webhooks: rows error: %w", err)
+	}
+
+	return result, nil
+}
+
+func scanDelivery(row interface{ Scan(dest ...any) error }) (*Delivery, error) {
+	var d Delivery
+	var payload string
+	if err := row.Scan(
+		&d.ID, &d.Endpoint, &d.EventType, &d.UserID, &payload, &d.Success,
+		&d.StatusCode, &d.DurationMs, &d.Response, &d.Error, &d.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	d.Payload = []byte(payload)
+	return &d, nil
+}
+
+// DeadLetter is a webhook message that exhausted its retries.
+type DeadLetter struct {
+	ID            string
+	Endpoint      string
+	EventType     string
+	UserID        string
+	Payload       []byte
+	Attempts      int
+	LastError     string
+	FirstQueuedAt int64
+	CreatedAt     int64
+}
+
+// DeadLetterFilter narrows ListDeadLetters results.
+// Dead letters are returned newest first; Cursor is the ID of the last dead letter of the previous page.
+type DeadLetterFilter struct {
+	Endpoint string
+	Cursor   string
+	Limit    int
+}
+
+const deadLetterColumns = `id, endpoint, event_type, user_id, payload, attempts,
+			last_error, first_queued_at, created_at`
+
+// InsertDeadLetter stores a message that will not be retried any more.
+func (r *Repo) InsertDeadLetter(ctx context.Context, dl *DeadLetter) error {
+	table := r.db.TableName("webhook_dead_letters")
+	query := r.db.Rebind(fmt.Sprintf(`
+		INSERT INTO %s (%s)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`, table, deadLetterColumns))
+
+	_, err := r.db.ExecContext(
+		ctx,
+		query,
+		dl.ID,
+		dl.Endpoint,
+		dl.EventType,
+		dl.UserID,
+		string(dl.Payload),
+		dl.Attempts,
+		dl.LastError,
+		dl.FirstQueuedAt,
+		dl.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("webhooks: failed to insert dead letter: %w", err)
+	}
+	return nil
+}
+
+// GetDeadLetter returns the dead letter with the given ID, or nil if not found.
+func (r *Repo) GetDeadLetter(ctx context.Context, id string) (*DeadLetter, error) {
+	table := r.db.TableName("webhook_dead_letters")
+	query := r.db.Rebind(fmt.Sprintf(`
+		SELECT %s FROM %s WHERE id = $1
+	`, deadLetterColumns, table))
+
+	dl, err := scanDeadLetter(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("webhooks: failed to get dead letter: %w", err)
+	}
+	return dl, nil
+}
+
+// ListDeadLetters returns dead letters matching the filter, newest first.
+func (r *Repo) ListDeadLetters(ctx context.Context, filter DeadLetterFilter) ([]DeadLetter, error) {
+	var conds []string
+	var args []any
+	if filter.Endpoint != "" {
+		args = append(args, filter.Endpoint)
+		conds = append(conds, fmt.Sprintf("endpoint = $%d", len(args)))
+	}
+	if filter.Cursor != "" {
+		args = append(args, filter.Cursor)
+		conds = append(conds, fmt.Sprintf("id < $%d", len(args)))
+	}
+	where := ""
+	if len(conds) > 0 {
+		where = "WHERE " + strings.Join(conds, " AND ")
+	}
+	args = append(args, filter.Limit)
+
+	table := r.db.TableName("webhook_dead_letters")
+	query := r.db.Rebind(fmt.Sprintf(`
+		SELECT %s FROM %s
+		%s
+		ORDER BY id DESC
+		LIMIT $%d
+	`, deadLetterColumns, table, where, len(args)))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("webhooks: failed to query dead letters: %w", err)
+	}
+	defer rows.Close()
+
+	var result []DeadLetter
+	for rows.Next() {
+		dl, err := scanDeadLetter(rows)
+		if err != nil {
+			return nil, fmt.Errorf("webhooks: failed to scan row: %w", err)
+		}
+		result = append(result, *dl)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("webhooks: rows error: %w", err)
+	}
+
+	return result, nil
+}
+
+// DeleteDeadLetter removes a dead letter.
+func (r *Repo) DeleteDeadLetter(ctx context.Context, id string) error {
+	table := r.db.TableName("webhook_dead_letters")
+	query := r.db.Rebind(fmt.Sprintf(`DELETE FROM %s WHERE id = $1`, table))
+
+	if _, err := r.db.ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("webhooks: failed to delete dead letter: %w", err)
+	}
+	return nil
+}
+
+// CountDeadLetters returns the number of dead letters per endpoint.
+func (r *Repo) CountDeadLetters(ctx context.Context) (map[string]int, error) {
+	table := r.db.TableName("webhook_dead_letters")
+	query := fmt.Sprintf(`SELECT endpoint, COUNT(*) FROM %s GROUP BY endpoint`, table)
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("webhooks: failed to count dead letters: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var endpoint string
+		var count int
+		if err := rows.Scan(&endpoint, &count); err != nil {
+			return nil, fmt.Errorf("webhooks: failed to scan row: %w", err)
+		}
+		counts[endpoint] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("webhooks: rows error: %w", err)
+	}
+
+	return counts, nil
+}
+
+func scanDeadLetter(row interface{ Scan(dest ...any) error }) (*DeadLetter, error) {
+	var dl DeadLetter
+	var payload string
+	if err := row.Scan(
+		&dl.ID, &dl.Endpoint, &dl.EventType, &dl.UserID, &payload, &dl.Attempts,
+		&dl.LastError, &dl.FirstQueuedAt, &dl.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	dl.Payload = []byte(payload)
+	return &dl, nil
+}
+
+// NextSequence increments and returns the user's webhook sequence number,
+// starting at 1.
+func (r *Repo) NextSequence(ctx context.Context, userID string) (int64, error) {
+	table := r.db.TableName("webhook_sequences")
+	query := r.db.Rebind(fmt.Sprintf(`
+		INSERT INTO %s (user_id, last_sequence)
+		VALUES ($1, 1)
+		ON CONFLICT(user_id) DO UPDATE SET last_sequence = %s.last_sequence + 1
+		RETURNING last_sequence
+	`, table, table))
+
+	var seq int64
+	if err := r.db.QueryRowContext(ctx, query, userID).Scan(&seq); err != nil {
+		return 0, fmt.Errorf("webhooks: failed to increment sequence: %w", err)
+	}
+	return seq, nil
+}
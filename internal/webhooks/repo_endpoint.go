@@ -0,0 +1,188 @@
+package webhooks
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+const endpointColumns = `id, url, secret, prev_secret, prev_secret_expires_at,
+			events, plans, created_at, updated_at`
+
+// ListEndpoints returns the endpoints stored in the database, oldest first.
+func (r *Repo) ListEndpoints(ctx context.Context) ([]Endpoint, error) {
+	table := r.db.TableName("webhook_endpoints")
+	query := fmt.Sprintf(`SELECT %s FROM %s ORDER BY id`, endpointColumns, table)
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("webhooks: failed to query endpoints: %w", err)
+	}
+	defer rows.Close()
+
+	var result []Endpoint
+	for rows.Next() {
+		e, err := scanEndpoint(rows)
+		if err != nil {
+			return nil, fmt.Errorf("webhooks: failed to scan row: %w", err)
+		}
+		result = append(result, *e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("webhooks: rows error: %w", err)
+	}
+
+	return result, nil
+}
+
+// GetEndpoint returns the endpoint with the given ID, or nil if not found.
+func (r *Repo) GetEndpoint(ctx context.Context, id string) (*Endpoint, error) {
+	return r.getEndpoint(ctx, "id", id)
+}
+
+// GetEndpointByURL returns the endpoint with the given URL, or nil if not found.
+func (r *Repo) GetEndpointByURL(ctx context.Context, url string) (*Endpoint, error) {
+	return r.getEndpoint(ctx, "url", url)
+}
+
+func (r *Repo) getEndpoint(ctx context.Context, column, value string) (*Endpoint, error) {
+	table := r.db.TableName("webhook_endpoints")
+	query := r.db.Rebind(fmt.Sprintf(`
+		SELECT %s FROM %s WHERE %s = $1
+	`, endpointColumns, table, column))
+
+	e, err := scanEndpoint(r.db.QueryRowContext(ctx, query, value))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("webhooks: failed to get endpoint: %w", err)
+	}
+	return e, nil
+}
+
+// InsertEndpoint stores a new endpoint.
+func (r *Repo) InsertEndpoint(ctx context.Context, e *Endpoint) error {
+	events, plans, err := marshalEndpointFilters(e)
+	if err != nil {
+		return err
+	}
+
+	table := r.db.TableName("webhook_endpoints")
+	query := r.db.Rebind(fmt.Sprintf(`
+		INSERT INTO %s (%s)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`, table, endpointColumns))
+
+	_, err = r.db.ExecContext(
+		ctx,
+		query,
+		e.ID,
+		e.URL,
+		e.Secret,
+		e.PrevSecret,
+		e.PrevSecretExpiresAt,
+		events,
+		plans,
+		e.CreatedAt,
+		e.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("webhooks: failed to insert endpoint: %w", err)
+	}
+	return nil
+}
+
+// UpdateEndpoint overwrites a stored endpoint.
+func (r *Repo) UpdateEndpoint(ctx context.Context, e *Endpoint) error {
+	events, plans, err := marshalEndpointFilters(e)
+	if err != nil {
+		return err
+	}
+
+	table := r.db.TableName("webhook_endpoints")
+	query := r.db.Rebind(fmt.Sprintf(`
+		UPDATE %s SET
+			url = $1,
+			secret = $2,
+			prev_secret = $3,
+			prev_secret_expires_at = $4,
+			events = $5,
+			plans = $6,
+			updated_at = $7
+		WHERE id = $8
+	`, table))
+
+	_, err = r.db.ExecContext(
+		ctx,
+		query,
+		e.URL,
+		e.Secret,
+		e.PrevSecret,
+		e.PrevSecretExpiresAt,
+		events,
+		plans,
+		e.UpdatedAt,
+		e.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("webhooks: failed to update endpoint: %w", err)
+	}
+	return nil
+}
+
+// DeleteEndpoint removes an endpoint and reports whether it existed.
+func (r *Repo) DeleteEndpoint(ctx context.Context, id string) (bool, error) {
+	table := r.db.TableName("webhook_endpoints")
+	query := r.db.Rebind(fmt.Sprintf(`DELETE FROM %s WHERE id = $1`, table))
+
+	res, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return false, fmt.Errorf("webhooks: failed to delete endpoint: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("webhooks: failed to delete endpoint: %w", err)
+	}
+	return n > 0, nil
+}
+
+func marshalEndpointFilters(e *Endpoint) (events, plans string, err error) {
+	eventsJSON, err := json.Marshal(nonNil(e.Events))
+	if err != nil {
+		return "", "", fmt.Errorf("webhooks: failed to marshal events: %w", err)
+	}
+	plansJSON, err := json.Marshal(nonNil(e.Plans))
+	if err != nil {
+		return "", "", fmt.Errorf("webhooks: failed to marshal plans: %w", err)
+	}
+	return string(eventsJSON), string(plansJSON), nil
+}
+
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
+
+func scanEndpoint(row interface{ Scan(dest ...any) error }) (*Endpoint, error) {
+	var e Endpoint
+	var events, plans string
+	if err := row.Scan(
+		&e.ID, &e.URL, &e.Secret, &e.PrevSecret, &e.PrevSecretExpiresAt,
+		&events, &plans, &e.CreatedAt, &e.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(events), &e.Events); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal events: %w", err)
+	}
+	if err := json.Unmarshal([]byte(plans), &e.Plans); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal plans: %w", err)
+	}
+	return &e, nil
+}
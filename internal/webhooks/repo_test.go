@@ -0,0 +1,136 @@
+package webhooks_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grantsy/grantsy/internal/infra/db"
+	"github.com/grantsy/grantsy/internal/webhooks"
+)
+
+func newSQLiteRepo(t *testing.T) *webhooks.Repo {
+	t.Helper()
+
+	dsn := filepath.Join(t.TempDir(), "test.db")
+	require.NoError(t, db.Migrate("sqlite", dsn, ""), "sqlite migration failed")
+
+	database, err := db.New("sqlite", dsn, "")
+	require.NoError(t, err, "sqlite connection failed")
+	t.Cleanup(func() { database.Close() })
+
+	return webhooks.NewRepo(database)
+}
+
+func TestRepo_Deliveries(t *testing.T) {
+	ctx := context.Background()
+	repo := newSQLiteRepo(t)
+
+	require.NoError(t, repo.InsertDelivery(ctx, testDelivery("01", false)))
+	require.NoError(t, repo.InsertDelivery(ctx, testDelivery("02", true)))
+	require.NoError(t, repo.InsertDelivery(ctx, testDelivery("03", false)))
+
+	got, err := repo.GetDelivery(ctx, "01")
+	require.NoError(t, err)
+	assert.Equal(t, testDelivery("01", false), got)
+
+	missing, err := repo.GetDelivery(ctx, "missing")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	failed := false
+	list, err := repo.ListDeliveries(ctx, webhooks.DeliveryFilter{Success: &failed, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, "03", list[0].ID)
+	assert.Equal(t, "01", list[1].ID)
+
+	page, err := repo.ListDeliveries(ctx, webhooks.DeliveryFilter{Cursor: "03", Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, "02", page[0].ID)
+}
+
+func TestRepo_DeadLetters(t *testing.T) {
+	ctx := context.Background()
+	repo := newSQLiteRepo(t)
+
+	other := testDeadLetter("02")
+	other.Endpoint = "https://other.example.com/hook"
+	require.NoError(t, repo.InsertDeadLetter(ctx, testDeadLetter("01")))
+	require.NoError(t, repo.InsertDeadLetter(ctx, other))
+	require.NoError(t, repo.InsertDeadLetter(ctx, testDeadLetter("03")))
+
+	got, err := repo.GetDeadLetter(ctx, "01")
+	require.NoError(t, err)
+	assert.Equal(t, testDeadLetter("01"), got)
+
+	counts, err := repo.CountDeadLetters(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"https://example.com/hook": 2, "https://other.example.com/hook": 1}, counts)
+
+	list, err := repo.ListDeadLetters(ctx, webhooks.DeadLetterFilter{Endpoint: "https://example.com/hook", Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, "03", list[0].ID)
+
+	require.NoError(t, repo.DeleteDeadLetter(ctx, "01"))
+	missing, err := repo.GetDeadLetter(ctx, "01")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
+func TestRepo_NextSequence(t *testing.T) {
+	ctx := context.Background()
+	repo := newSQLiteRepo(t)
+
+	for want := int64(1); want <= 3; want++ {
+		seq, err := repo.NextSequence(ctx, "user-1")
+		require.NoError(t, err)
+		assert.Equal(t, want, seq)
+	}
+
+	seq, err := repo.NextSequence(ctx, "user-2")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), seq)
+}
+
+func TestRepo_Endpoints(t *testing.T) {
+	ctx := context.Background()
+	repo := newSQLiteRepo(t)
+
+	e := &webhooks.Endpoint{
+		ID:        "01",
+		URL:       "https://example.com/hook",
+		Secret:    "whsec_c2VjcmV0",
+		Events:    []string{"user.plan_changed"},
+		Plans:     []string{},
+		CreatedAt: 1700000000,
+		UpdatedAt: 1700000000,
+	}
+	require.NoError(t, repo.InsertEndpoint(ctx, e))
+
+	got, err := repo.GetEndpointByURL(ctx, "https://example.com/hook")
+	require.NoError(t, err)
+	assert.Equal(t, e, got)
+
+	e.PrevSecret, e.PrevSecretExpiresAt, e.Secret = e.Secret, 1700086400, "whsec_bmV3"
+	require.NoError(t, repo.UpdateEndpoint(ctx, e))
+	got, err = repo.GetEndpoint(ctx, "01")
+	require.NoError(t, err)
+	assert.Equal(t, e, got)
+
+	list, err := repo.ListEndpoints(ctx)
+	require.NoError(t, err)
+	assert.Len(t, list, 1)
+
+	deleted, err := repo.DeleteEndpoint(ctx, "01")
+	require.NoError(t, err)
+	assert.True(t, deleted)
+	deleted, err = repo.DeleteEndpoint(ctx, "01")
+	require.NoError(t, err)
+	assert.False(t, deleted)
+}
@@ -0,0 +1,97 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/iamolegga/valmid"
+	"github.com/swaggest/openapi-go"
+	"github.com/swaggest/openapi-go/openapi31"
+
+	"github.com/grantsy/grantsy/internal/auth"
+	"github.com/grantsy/grantsy/internal/httptools"
+	"github.com/grantsy/grantsy/internal/infra/logger"
+	oa "github.com/grantsy/grantsy/internal/openapi"
+)
+
+// DeadLetterRequeuer moves a dead-lettered message back to the queue.
+type DeadLetterRequeuer interface {
+	RequeueDeadLetter(ctx context.Context, dl *DeadLetter) error
+}
+
+type DeadLetterRequeueRequest struct {
+	DeadLetterID string `in:"path=dead_letter_id" path:"dead_letter_id" validate:"required" description:"Dead letter ID"`
+}
+
+type DeadLetterRequeueResponse struct {
+	DeadLetter DeadLetterDetail `json:"dead_letter" description:"The requeued message" required:"true"`
+}
+
+type RouteDeadLetterRequeue struct {
+	reader   DeadLetterReader
+	requeuer DeadLetterRequeuer
+}
+
+func NewRouteDeadLetterRequeue(reader DeadLetterReader, requeuer DeadLetterRequeuer) *RouteDeadLetterRequeue {
+	return &RouteDeadLetterRequeue{reader: reader, requeuer: requeuer}
+}
+
+func (route *RouteDeadLetterRequeue) Register(mux *http.ServeMux, r *openapi31.Reflector) {
+	mux.Handle("POST /v1/webhooks/dead-letters/{dead_letter_id}/requeue", httptools.Wrap(
+		route.Handler(),
+		auth.RequireScope(auth.ScopeAdminWrite),
+		valmid.Middleware[DeadLetterRequeueRequest](),
+	))
+	RegisterDeadLetterRequeueSchema(r)
+}
+
+func RegisterDeadLetterRequeueSchema(r *openapi31.Reflector) {
+	op, _ := r.NewOperationContext(http.MethodPost, "/v1/webhooks/dead-letters/{dead_letter_id}/requeue")
+	op.AddReqStructure(new(DeadLetterRequeueRequest))
+	op.AddRespStructure(struct {
+		Data DeadLetterRequeueResponse `json:"data"`
+		Meta httptools.Meta            `json:"meta"`
+		_    struct{}                  `title:"DeadLetterRequeueResponse"`
+	}{}, func(cu *openapi.ContentUnit) {
+		cu.HTTPStatus = http.StatusAccepted
+		cu.Description = "Message queued again"
+	})
+	oa.AddErrorResponses(op)
+	op.SetSummary("Requeue webhook dead letter")
+	op.SetDescription(
+		"Queue a dead-lettered message for delivery again with a fresh retry budget and remove it from the dead-letter queue.",
+	)
+	op.SetTags("Webhooks")
+	op.AddSecurity("ApiKeyAuth", auth.ScopeAdminWrite)
+	op.AddSecurity("BearerAuth", auth.ScopeAdminWrite)
+	r.AddOperation(op)
+}
+
+func (route *RouteDeadLetterRequeue) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		input := valmid.Get[DeadLetterRequeueRequest](r)
+		log := logger.FromContext(r.Context()).With("dead_letter_id", input.DeadLetterID)
+
+		dl, err := route.reader.GetDeadLetter(r.Context(), input.DeadLetterID)
+		if err != nil {
+			log.Error("failed to get webhook dead letter", "error", err)
+			httptools.InternalError(w, r)
+			return
+		}
+		if dl == nil {
+			httptools.NotFound(w, r, fmt.Sprintf("Webhook dead letter '%s' not found", input.DeadLetterID))
+			return
+		}
+
+		if err := route.requeuer.RequeueDeadLetter(r.Context(), dl); err != nil {
+			log.Error("failed to requeue webhook dead letter", "error", err)
+			httptools.InternalError(w, r)
+			return
+		}
+
+		httptools.JSON(w, r, http.StatusAccepted, DeadLetterRequeueResponse{
+			DeadLetter: ToDeadLetterDetail(dl),
+		})
+	})
+}
@@ -0,0 +1,129 @@
+package webhooks
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/iamolegga/valmid"
+	"github.com/swaggest/openapi-go"
+	"github.com/swaggest/openapi-go/openapi31"
+
+	"github.com/grantsy/grantsy/internal/auth"
+	"github.com/grantsy/grantsy/internal/httptools"
+	"github.com/grantsy/grantsy/internal/infra/logger"
+	oa "github.com/grantsy/grantsy/internal/openapi"
+)
+
+// DeadLetterReader reads the dead-letter queue.
+type DeadLetterReader interface {
+	ListDeadLetters(ctx context.Context, filter DeadLetterFilter) ([]DeadLetter, error)
+	GetDeadLetter(ctx context.Context, id string) (*DeadLetter, error)
+}
+
+type DeadLettersRequest struct {
+	Endpoint string `in:"query=endpoint"         query:"endpoint" description:"Filter by endpoint URL"`
+	Cursor   string `in:"query=cursor"           query:"cursor"   description:"Pagination cursor (next_cursor from the previous page)"`
+	Limit    int    `in:"query=limit;default=50" query:"limit"    description:"Maximum number of dead letters to return" validate:"min=1,max=200" default:"50"`
+}
+
+type DeadLettersResponse struct {
+	DeadLetters []DeadLetterDetail `json:"dead_letters"          description:"Dead-lettered messages, newest first"        nullable:"false" required:"true"`
+	NextCursor  string             `json:"next_cursor,omitempty" description:"Cursor for the next page, omitted on the last page"`
+}
+
+type DeadLetterDetail struct {
+	ID            string `json:"id"              description:"Dead letter identifier"                        required:"true"`
+	Endpoint      string `json:"endpoint"        description:"Endpoint URL"                                  required:"true"`
+	EventType     string `json:"event_type"      description:"Event type of the payload"                     required:"true"`
+	UserID        string `json:"user_id"         description:"User the event is about"                       required:"true"`
+	Payload       string `json:"payload"         description:"JSON payload that could not be delivered"      required:"true"`
+	Attempts      int    `json:"attempts"        description:"Number of delivery attempts made"              required:"true"`
+	LastError     string `json:"last_error"      description:"Error of the last delivery attempt"            required:"true"`
+	FirstQueuedAt int64  `json:"first_queued_at" description:"Unix timestamp the payload was first queued"   required:"true"`
+	CreatedAt     int64  `json:"created_at"      description:"Unix timestamp the message was dead-lettered" required:"true"`
+}
+
+type RouteDeadLetters struct {
+	reader DeadLetterReader
+}
+
+func NewRouteDeadLetters(reader DeadLetterReader) *RouteDeadLetters {
+	return &RouteDeadLetters{reader: reader}
+}
+
+func (route *RouteDeadLetters) Register(mux *http.ServeMux, r *openapi31.Reflector) {
+	mux.Handle("GET /v1/webhooks/dead-letters", httptools.Wrap(
+		route.Handler(),
+		auth.RequireScope(auth.ScopeAdminWrite),
+		valmid.Middleware[DeadLettersRequest](),
+	))
+	RegisterDeadLettersSchema(r)
+}
+
+func RegisterDeadLettersSchema(r *openapi31.Reflector) {
+	op, _ := r.NewOperationContext(http.MethodGet, "/v1/webhooks/dead-letters")
+	op.AddReqStructure(new(DeadLettersRequest))
+	op.AddRespStructure(struct {
+		Data DeadLettersResponse `json:"data"`
+		Meta httptools.Meta      `json:"meta"`
+		_    struct{}            `title:"DeadLettersResponse"`
+	}{}, func(cu *openapi.ContentUnit) {
+		cu.HTTPStatus = http.StatusOK
+		cu.Description = "Dead-lettered webhook messages"
+	})
+	oa.AddErrorResponses(op)
+	op.SetSummary("List webhook dead letters")
+	op.SetDescription(
+		"List outgoing webhook messages that exhausted their retries or max age, newest first. Use next_cursor to fetch the next page.",
+	)
+	op.SetTags("Webhooks")
+	op.AddSecurity("ApiKeyAuth", auth.ScopeAdminWrite)
+	op.AddSecurity("BearerAuth", auth.ScopeAdminWrite)
+	r.AddOperation(op)
+}
+
+func (route *RouteDeadLetters) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		input := valmid.Get[DeadLettersRequest](r)
+
+		deadLetters, err := route.reader.ListDeadLetters(r.Context(), DeadLetterFilter{
+			Endpoint: input.Endpoint,
+			Cursor:   input.Cursor,
+			// Fetch one extra dead letter to know whether another page exists.
+			Limit: input.Limit + 1,
+		})
+		if err != nil {
+			logger.FromContext(r.Context()).Error("failed to list webhook dead letters", "error", err)
+			httptools.InternalError(w, r)
+			return
+		}
+
+		resp := DeadLettersResponse{
+			DeadLetters: make([]DeadLetterDetail, 0, min(len(deadLetters), input.Limit)),
+		}
+		for i, dl := range deadLetters {
+			if i == input.Limit {
+				resp.NextCursor = deadLetters[i-1].ID
+				break
+			}
+			resp.DeadLetters = append(resp.DeadLetters, ToDeadLetterDetail(&dl))
+		}
+
+		httptools.JSON(w, r, http.StatusOK, resp)
+	})
+}
+
+// ToDeadLetterDetail converts a DeadLetter to its display type.
+func ToDeadLetterDetail(dl *DeadLetter) DeadLetterDetail {
+	return DeadLetterDetail{
+		ID:            dl.ID,
+		Endpoint:      dl.Endpoint,
+		EventType:     dl.EventType,
+		UserID:        dl.UserID,
+		Payload:       string(dl.Payload),
+		Attempts:      dl.Attempts,
+		LastError:     dl.LastError,
+		FirstQueuedAt: dl.FirstQueuedAt,
+		CreatedAt:     dl.CreatedAt,
+	}
+}
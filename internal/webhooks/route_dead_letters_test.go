@@ -0,0 +1,100 @@
+package webhooks_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/openapi-go/openapi31"
+
+	"github.com/grantsy/grantsy/internal/auth/authtest"
+	"github.com/grantsy/grantsy/internal/webhooks"
+	"github.com/grantsy/grantsy/internal/webhooks/mocks"
+)
+
+func newDeadLettersMux(
+	t *testing.T,
+	reader webhooks.DeadLetterReader,
+	requeuer webhooks.DeadLetterRequeuer,
+) http.Handler {
+	t.Helper()
+	mux := http.NewServeMux()
+	r := openapi31.NewReflector()
+	webhooks.NewRouteDeadLetters(reader).Register(mux, r)
+	webhooks.NewRouteDeadLetterRequeue(reader, requeuer).Register(mux, r)
+	return authtest.Handler(mux)
+}
+
+func testDeadLetter(id string) *webhooks.DeadLetter {
+	return &webhooks.DeadLetter{
+		ID:            id,
+		Endpoint:      "https://example.com/hook",
+		EventType:     string(webhooks.EventUserPlanChanged),
+		UserID:        "user-1",
+		Payload:       []byte(`{"type":"user.plan_changed"}`),
+		Attempts:      8,
+		LastError:     "webhook failed: status 500",
+		FirstQueuedAt: 1700000000,
+		CreatedAt:     1700086400,
+	}
+}
+
+func TestRouteDeadLetters_Pagination(t *testing.T) {
+	reader := mocks.NewMockDeadLetterReader(t)
+	reader.EXPECT().
+		ListDeadLetters(mock.Anything, webhooks.DeadLetterFilter{
+			Endpoint: "https://example.com/hook",
+			Limit:    2,
+		}).
+		Return([]webhooks.DeadLetter{*testDeadLetter("02"), *testDeadLetter("01")}, nil)
+
+	mux := newDeadLettersMux(t, reader, mocks.NewMockDeadLetterRequeuer(t))
+	code, data := serveDeliveries(t, mux, http.MethodGet,
+		"/v1/webhooks/dead-letters?endpoint=https://example.com/hook&limit=1")
+
+	assert.Equal(t, http.StatusOK, code)
+	deadLetters := data["dead_letters"].([]any)
+	require.Len(t, deadLetters, 1)
+	assert.Equal(t, "02", deadLetters[0].(map[string]any)["id"])
+	assert.InDelta(t, 8, deadLetters[0].(map[string]any)["attempts"], 0)
+	assert.Equal(t, "02", data["next_cursor"])
+}
+
+func TestRouteDeadLetterRequeue(t *testing.T) {
+	dl := testDeadLetter("01")
+	reader := mocks.NewMockDeadLetterReader(t)
+	reader.EXPECT().GetDeadLetter(mock.Anything, "01").Return(dl, nil)
+	requeuer := mocks.NewMockDeadLetterRequeuer(t)
+	requeuer.EXPECT().RequeueDeadLetter(mock.Anything, dl).Return(nil)
+
+	mux := newDeadLettersMux(t, reader, requeuer)
+	code, data := serveDeliveries(t, mux, http.MethodPost, "/v1/webhooks/dead-letters/01/requeue")
+
+	assert.Equal(t, http.StatusAccepted, code)
+	assert.Equal(t, "01", data["dead_letter"].(map[string]any)["id"])
+}
+
+func TestRouteDeadLetterRequeue_NotFound(t *testing.T) {
+	reader := mocks.NewMockDeadLetterReader(t)
+	reader.EXPECT().GetDeadLetter(mock.Anything, "missing").Return(nil, nil)
+
+	mux := newDeadLettersMux(t, reader, mocks.NewMockDeadLetterRequeuer(t))
+	code, _ := serveDeliveries(t, mux, http.MethodPost, "/v1/webhooks/dead-letters/missing/requeue")
+
+	assert.Equal(t, http.StatusNotFound, code)
+}
+
+func TestRouteDeadLetterRequeue_Error(t *testing.T) {
+	reader := mocks.NewMockDeadLetterReader(t)
+	reader.EXPECT().GetDeadLetter(mock.Anything, "01").Return(testDeadLetter("01"), nil)
+	requeuer := mocks.NewMockDeadLetterRequeuer(t)
+	requeuer.EXPECT().RequeueDeadLetter(mock.Anything, mock.Anything).Return(errors.New("queue down"))
+
+	mux := newDeadLettersMux(t, reader, requeuer)
+	code, _ := serveDeliveries(t, mux, http.MethodPost, "/v1/webhooks/dead-letters/01/requeue")
+
+	assert.Equal(t, http.StatusInternalServerError, code)
+}
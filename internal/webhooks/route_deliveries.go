@@ -0,0 +1,138 @@
+package webhooks
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/iamolegga/valmid"
+	"github.com/swaggest/openapi-go"
+	"github.com/swaggest/openapi-go/openapi31"
+
+	"github.com/grantsy/grantsy/internal/auth"
+	"github.com/grantsy/grantsy/internal/httptools"
+	"github.com/grantsy/grantsy/internal/infra/logger"
+	oa "github.com/grantsy/grantsy/internal/openapi"
+)
+
+// DeliveryReader reads recorded webhook deliveries.
+type DeliveryReader interface {
+	ListDeliveries(ctx context.Context, filter DeliveryFilter) ([]Delivery, error)
+	GetDelivery(ctx context.Context, id string) (*Delivery, error)
+}
+
+type DeliveriesRequest struct {
+	Endpoint  string `in:"query=endpoint"         query:"endpoint"   description:"Filter by endpoint URL"`
+	EventType string `in:"query=event_type"       query:"event_type" description:"Filter by event type (e.g. user.plan_changed)"`
+	Status    string `in:"query=status"           query:"status"     description:"Filter by delivery outcome"                           validate:"omitempty,oneof=succeeded failed" enum:"succeeded,failed"`
+	Cursor    string `in:"query=cursor"           query:"cursor"     description:"Pagination cursor (next_cursor from the previous page)"`
+	Limit     int    `in:"query=limit;default=50" query:"limit"      description:"Maximum number of deliveries to return"               validate:"min=1,max=200"                    default:"50"`
+}
+
+type DeliveriesResponse struct {
+	Deliveries []DeliverySummary `json:"deliveries"            description:"Delivery attempts, newest first" nullable:"false" required:"true"`
+	NextCursor string            `json:"next_cursor,omitempty" description:"Cursor for the next page, omitted on the last page"`
+}
+
+type DeliverySummary struct {
+	ID         string `json:"id"              description:"Delivery attempt identifier"                            required:"true"`
+	Endpoint   string `json:"endpoint"        description:"Endpoint URL"                                           required:"true"`
+	EventType  string `json:"event_type"      description:"Event type of the payload"                              required:"true"`
+	UserID     string `json:"user_id"         description:"User the event is about"                                required:"true"`
+	Success    bool   `json:"success"         description:"Whether the endpoint responded with a 2xx status"       required:"true"`
+	StatusCode int    `json:"status_code"     description:"HTTP status returned by the endpoint, 0 if none"        required:"true"`
+	DurationMs int64  `json:"duration_ms"     description:"Request latency in milliseconds"                        required:"true"`
+	Error      string `json:"error,omitempty" description:"Delivery error, if any"`
+	CreatedAt  int64  `json:"created_at"      description:"Unix timestamp of the attempt"                          required:"true"`
+}
+
+type RouteDeliveries struct {
+	reader DeliveryReader
+}
+
+func NewRouteDeliveries(reader DeliveryReader) *RouteDeliveries {
+	return &RouteDeliveries{reader: reader}
+}
+
+func (route *RouteDeliveries) Register(mux *http.ServeMux, r *openapi31.Reflector) {
+	mux.Handle("GET /v1/webhooks/deliveries", httptools.Wrap(
+		route.Handler(),
+		auth.RequireScope(auth.ScopeAdminWrite),
+		valmid.Middleware[DeliveriesRequest](),
+	))
+	RegisterDeliveriesSchema(r)
+}
+
+func RegisterDeliveriesSchema(r *openapi31.Reflector) {
+	op, _ := r.NewOperationContext(http.MethodGet, "/v1/webhooks/deliveries")
+	op.AddReqStructure(new(DeliveriesRequest))
+	op.AddRespStructure(struct {
+		Data DeliveriesResponse `json:"data"`
+		Meta httptools.Meta     `json:"meta"`
+		_    struct{}           `title:"DeliveriesResponse"`
+	}{}, func(cu *openapi.ContentUnit) {
+		cu.HTTPStatus = http.StatusOK
+		cu.Description = "Webhook delivery attempts"
+	})
+	oa.AddErrorResponses(op)
+	op.SetSummary("List webhook deliveries")
+	op.SetDescription(
+		"List attempts to deliver outgoing webhooks with their outcome, newest first. Use next_cursor to fetch the next page.",
+	)
+	op.SetTags("Webhooks")
+	op.AddSecurity("ApiKeyAuth", auth.ScopeAdminWrite)
+	op.AddSecurity("BearerAuth", auth.ScopeAdminWrite)
+	r.AddOperation(op)
+}
+
+func (route *RouteDeliveries) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		input := valmid.Get[DeliveriesRequest](r)
+
+		filter := DeliveryFilter{
+			Endpoint:  input.Endpoint,
+			EventType: input.EventType,
+			Cursor:    input.Cursor,
+			// Fetch one extra delivery to know whether another page exists.
+			Limit: input.Limit + 1,
+		}
+		if input.Status != "" {
+			success := input.Status == "succeeded"
+			filter.Success = &success
+		}
+
+		deliveries, err := route.reader.ListDeliveries(r.Context(), filter)
+		if err != nil {
+			logger.FromContext(r.Context()).Error("failed to list webhook deliveries", "error", err)
+			httptools.InternalError(w, r)
+			return
+		}
+
+		resp := DeliveriesResponse{
+			Deliveries: make([]DeliverySummary, 0, min(len(deliveries), input.Limit)),
+		}
+		for i, d := range deliveries {
+			if i == input.Limit {
+				resp.NextCursor = deliveries[i-1].ID
+				break
+			}
+			resp.Deliveries = append(resp.Deliveries, ToDeliverySummary(&d))
+		}
+
+		httptools.JSON(w, r, http.StatusOK, resp)
+	})
+}
+
+// ToDeliverySummary converts a Delivery to its list display type.
+func ToDeliverySummary(d *Delivery) DeliverySummary {
+	return DeliverySummary{
+		ID:         d.ID,
+		Endpoint:   d.Endpoint,
+		EventType:  d.EventType,
+		UserID:     d.UserID,
+		Success:    d.Success,
+		StatusCode: d.StatusCode,
+		DurationMs: d.DurationMs,
+		Error:      d.Error,
+		CreatedAt:  d.CreatedAt,
+	}
+}
@@ -0,0 +1,170 @@
+package webhooks_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/openapi-go/openapi31"
+
+	"github.com/grantsy/grantsy/internal/auth/authtest"
+	"github.com/grantsy/grantsy/internal/httptools"
+	"github.com/grantsy/grantsy/internal/webhooks"
+	"github.com/grantsy/grantsy/internal/webhooks/mocks"
+
+	_ "github.com/grantsy/grantsy/internal/infra/validation"
+)
+
+func newDeliveriesMux(
+	t *testing.T,
+	reader webhooks.DeliveryReader,
+	redeliverer webhooks.Redeliverer,
+) http.Handler {
+	t.Helper()
+	mux := http.NewServeMux()
+	r := openapi31.NewReflector()
+	webhooks.NewRouteDeliveries(reader).Register(mux, r)
+	webhooks.NewRouteDelivery(reader).Register(mux, r)
+	webhooks.NewRouteDeliveryRetry(reader, redeliverer).Register(mux, r)
+	return authtest.Handler(mux)
+}
+
+func serveDeliveries(t *testing.T, mux http.Handler, method, target string) (int, map[string]any) {
+	t.Helper()
+	req := httptest.NewRequest(method, target, nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	var resp httptools.Response
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	data, _ := resp.Data.(map[string]any)
+	return w.Code, data
+}
+
+func testDelivery(id string, success bool) *webhooks.Delivery {
+	return &webhooks.Delivery{
+		ID:         id,
+		Endpoint:   "https://example.com/hook",
+		EventType:  string(webhooks.EventUserPlanChanged),
+		UserID:     "user-1",
+		Payload:    []byte(`{"type":"user.plan_changed"}`),
+		Success:    success,
+		StatusCode: 500,
+		Response:   "boom",
+		CreatedAt:  1700000000,
+	}
+}
+
+func TestRouteDeliveries_Pagination(t *testing.T) {
+	failed := false
+	reader := mocks.NewMockDeliveryReader(t)
+	reader.EXPECT().
+		ListDeliveries(mock.Anything, webhooks.DeliveryFilter{
+			EventType: "user.plan_changed",
+			Success:   &failed,
+			Cursor:    "05",
+			Limit:     3,
+		}).
+		Return([]webhooks.Delivery{
+			*testDelivery("04", false),
+			*testDelivery("03", false),
+			*testDelivery("02", false),
+		}, nil)
+
+	mux := newDeliveriesMux(t, reader, mocks.NewMockRedeliverer(t))
+	code, data := serveDeliveries(t, mux, http.MethodGet,
+		"/v1/webhooks/deliveries?event_type=user.plan_changed&status=failed&cursor=05&limit=2")
+
+	assert.Equal(t, http.StatusOK, code)
+	deliveries := data["deliveries"].([]any)
+	require.Len(t, deliveries, 2)
+	assert.Equal(t, "04", deliveries[0].(map[string]any)["id"])
+	assert.Equal(t, "03", data["next_cursor"])
+	_, hasPayload := deliveries[0].(map[string]any)["payload"]
+	assert.False(t, hasPayload)
+}
+
+func TestRouteDeliveries_LastPage(t *testing.T) {
+	reader := mocks.NewMockDeliveryReader(t)
+	reader.EXPECT().
+		ListDeliveries(mock.Anything, webhooks.DeliveryFilter{Limit: 51}).
+		Return(nil, nil)
+
+	mux := newDeliveriesMux(t, reader, mocks.NewMockRedeliverer(t))
+	code, data := serveDeliveries(t, mux, http.MethodGet, "/v1/webhooks/deliveries")
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Empty(t, data["deliveries"])
+	assert.NotContains(t, data, "next_cursor")
+}
+
+func TestRouteDeliveries_InvalidStatus(t *testing.T) {
+	mux := newDeliveriesMux(t, mocks.NewMockDeliveryReader(t), mocks.NewMockRedeliverer(t))
+	code, _ := serveDeliveries(t, mux, http.MethodGet, "/v1/webhooks/deliveries?status=pending")
+
+	assert.Equal(t, http.StatusUnprocessableEntity, code)
+}
+
+func TestRouteDelivery_Found(t *testing.T) {
+	reader := mocks.NewMockDeliveryReader(t)
+	reader.EXPECT().GetDelivery(mock.Anything, "01").Return(testDelivery("01", false), nil)
+
+	mux := newDeliveriesMux(t, reader, mocks.NewMockRedeliverer(t))
+	code, data := serveDeliveries(t, mux, http.MethodGet, "/v1/webhooks/deliveries/01")
+
+	assert.Equal(t, http.StatusOK, code)
+	delivery := data["delivery"].(map[string]any)
+	assert.Equal(t, `{"type":"user.plan_changed"}`, delivery["payload"])
+	assert.Equal(t, "boom", delivery["response"])
+	assert.InDelta(t, 500, delivery["status_code"], 0)
+}
+
+func TestRouteDelivery_NotFound(t *testing.T) {
+	reader := mocks.NewMockDeliveryReader(t)
+	reader.EXPECT().GetDelivery(mock.Anything, "missing").Return(nil, nil)
+
+	mux := newDeliveriesMux(t, reader, mocks.NewMockRedeliverer(t))
+	code, _ := serveDeliveries(t, mux, http.MethodGet, "/v1/webhooks/deliveries/missing")
+
+	assert.Equal(t, http.StatusNotFound, code)
+}
+
+func TestRouteDeliveryRetry(t *testing.T) {
+	d := testDelivery("01", false)
+	reader := mocks.NewMockDeliveryReader(t)
+	reader.EXPECT().GetDelivery(mock.Anything, "01").Return(d, nil)
+	redeliverer := mocks.NewMockRedeliverer(t)
+	redeliverer.EXPECT().Redeliver(mock.Anything, d).Return(nil)
+
+	mux := newDeliveriesMux(t, reader, redeliverer)
+	code, _ := serveDeliveries(t, mux, http.MethodPost, "/v1/webhooks/deliveries/01/retry")
+
+	assert.Equal(t, http.StatusAccepted, code)
+}
+
+func TestRouteDeliveryRetry_NotFound(t *testing.T) {
+	reader := mocks.NewMockDeliveryReader(t)
+	reader.EXPECT().GetDelivery(mock.Anything, "missing").Return(nil, nil)
+
+	mux := newDeliveriesMux(t, reader, mocks.NewMockRedeliverer(t))
+	code, _ := serveDeliveries(t, mux, http.MethodPost, "/v1/webhooks/deliveries/missing/retry")
+
+	assert.Equal(t, http.StatusNotFound, code)
+}
+
+func TestRouteDeliveryRetry_EnqueueError(t *testing.T) {
+	reader := mocks.NewMockDeliveryReader(t)
+	reader.EXPECT().GetDelivery(mock.Anything, "01").Return(testDelivery("01", false), nil)
+	redeliverer := mocks.NewMockRedeliverer(t)
+	redeliverer.EXPECT().Redeliver(mock.Anything, mock.Anything).Return(errors.New("queue down"))
+
+	mux := newDeliveriesMux(t, reader, redeliverer)
+	code, _ := serveDeliveries(t, mux, http.MethodPost, "/v1/webhooks/deliveries/01/retry")
+
+	assert.Equal(t, http.StatusInternalServerError, code)
+}
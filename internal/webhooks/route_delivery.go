@@ -0,0 +1,98 @@
+package webhooks
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/iamolegga/valmid"
+	"github.com/swaggest/openapi-go"
+	"github.com/swaggest/openapi-go/openapi31"
+
+	"github.com/grantsy/grantsy/internal/auth"
+	"github.com/grantsy/grantsy/internal/httptools"
+	"github.com/grantsy/grantsy/internal/infra/logger"
+	oa "github.com/grantsy/grantsy/internal/openapi"
+)
+
+type DeliveryRequest struct {
+	DeliveryID string `in:"path=delivery_id" path:"delivery_id" validate:"required" description:"Delivery attempt ID"`
+}
+
+type DeliveryResponse struct {
+	Delivery DeliveryDetail `json:"delivery" description:"Delivery attempt details" required:"true"`
+}
+
+// DeliveryDetail is a DeliverySummary with the request and response bodies.
+type DeliveryDetail struct {
+	DeliverySummary
+	Payload  string `json:"payload"  description:"JSON payload that was sent"                       required:"true"`
+	Response string `json:"response" description:"Start of the endpoint's response body (up to 1 KB)" required:"true"`
+}
+
+type RouteDelivery struct {
+	reader DeliveryReader
+}
+
+func NewRouteDelivery(reader DeliveryReader) *RouteDelivery {
+	return &RouteDelivery{reader: reader}
+}
+
+func (route *RouteDelivery) Register(mux *http.ServeMux, r *openapi31.Reflector) {
+	mux.Handle("GET /v1/webhooks/deliveries/{delivery_id}", httptools.Wrap(
+		route.Handler(),
+		auth.RequireScope(auth.ScopeAdminWrite),
+		valmid.Middleware[DeliveryRequest](),
+	))
+	RegisterDeliverySchema(r)
+}
+
+func RegisterDeliverySchema(r *openapi31.Reflector) {
+	op, _ := r.NewOperationContext(http.MethodGet, "/v1/webhooks/deliveries/{delivery_id}")
+	op.AddReqStructure(new(DeliveryRequest))
+	op.AddRespStructure(struct {
+		Data DeliveryResponse `json:"data"`
+		Meta httptools.Meta   `json:"meta"`
+		_    struct{}         `title:"DeliveryResponse"`
+	}{}, func(cu *openapi.ContentUnit) {
+		cu.HTTPStatus = http.StatusOK
+		cu.Description = "Webhook delivery details"
+	})
+	oa.AddErrorResponses(op)
+	op.SetSummary("Get webhook delivery")
+	op.SetDescription("Get an outgoing webhook delivery attempt including the payload sent and the endpoint's response")
+	op.SetTags("Webhooks")
+	op.AddSecurity("ApiKeyAuth", auth.ScopeAdminWrite)
+	op.AddSecurity("BearerAuth", auth.ScopeAdminWrite)
+	r.AddOperation(op)
+}
+
+func (route *RouteDelivery) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		input := valmid.Get[DeliveryRequest](r)
+
+		d, err := route.reader.GetDelivery(r.Context(), input.DeliveryID)
+		if err != nil {
+			logger.FromContext(r.Context()).
+				Error("failed to get webhook delivery", "error", err, "delivery_id", input.DeliveryID)
+			httptools.InternalError(w, r)
+			return
+		}
+		if d == nil {
+			httptools.NotFound(w, r, fmt.Sprintf("Webhook delivery '%s' not found", input.DeliveryID))
+			return
+		}
+
+		httptools.JSON(w, r, http.StatusOK, DeliveryResponse{
+			Delivery: ToDeliveryDetail(d),
+		})
+	})
+}
+
+// ToDeliveryDetail converts a Delivery to its detail display type.
+func ToDeliveryDetail(d *Delivery) DeliveryDetail {
+	return DeliveryDetail{
+		DeliverySummary: ToDeliverySummary(d),
+		Payload:         string(d.Payload),
+		Response:        d.Response,
+	}
+}
@@ -0,0 +1,89 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/iamolegga/valmid"
+	"github.com/swaggest/openapi-go"
+	"github.com/swaggest/openapi-go/openapi31"
+
+	"github.com/grantsy/grantsy/internal/auth"
+	"github.com/grantsy/grantsy/internal/httptools"
+	"github.com/grantsy/grantsy/internal/infra/logger"
+	oa "github.com/grantsy/grantsy/internal/openapi"
+)
+
+// Redeliverer queues a recorded delivery to be sent again.
+type Redeliverer interface {
+	Redeliver(ctx context.Context, d *Delivery) error
+}
+
+type RouteDeliveryRetry struct {
+	reader      DeliveryReader
+	redeliverer Redeliverer
+}
+
+func NewRouteDeliveryRetry(reader DeliveryReader, redeliverer Redeliverer) *RouteDeliveryRetry {
+	return &RouteDeliveryRetry{reader: reader, redeliverer: redeliverer}
+}
+
+func (route *RouteDeliveryRetry) Register(mux *http.ServeMux, r *openapi31.Reflector) {
+	mux.Handle("POST /v1/webhooks/deliveries/{delivery_id}/retry", httptools.Wrap(
+		route.Handler(),
+		auth.RequireScope(auth.ScopeAdminWrite),
+		valmid.Middleware[DeliveryRequest](),
+	))
+	RegisterDeliveryRetrySchema(r)
+}
+
+func RegisterDeliveryRetrySchema(r *openapi31.Reflector) {
+	op, _ := r.NewOperationContext(http.MethodPost, "/v1/webhooks/deliveries/{delivery_id}/retry")
+	op.AddReqStructure(new(DeliveryRequest))
+	op.AddRespStructure(struct {
+		Data DeliveryResponse `json:"data"`
+		Meta httptools.Meta   `json:"meta"`
+		_    struct{}         `title:"DeliveryRetryResponse"`
+	}{}, func(cu *openapi.ContentUnit) {
+		cu.HTTPStatus = http.StatusAccepted
+		cu.Description = "Delivery queued again"
+	})
+	oa.AddErrorResponses(op)
+	op.SetSummary("Retry webhook delivery")
+	op.SetDescription(
+		"Queue the payload of a recorded delivery to be sent to its endpoint again. The new attempt is recorded as a separate delivery.",
+	)
+	op.SetTags("Webhooks")
+	op.AddSecurity("ApiKeyAuth", auth.ScopeAdminWrite)
+	op.AddSecurity("BearerAuth", auth.ScopeAdminWrite)
+	r.AddOperation(op)
+}
+
+func (route *RouteDeliveryRetry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		input := valmid.Get[DeliveryRequest](r)
+		log := logger.FromContext(r.Context()).With("delivery_id", input.DeliveryID)
+
+		d, err := route.reader.GetDelivery(r.Context(), input.DeliveryID)
+		if err != nil {
+			log.Error("failed to get webhook delivery", "error", err)
+			httptools.InternalError(w, r)
+			return
+		}
+		if d == nil {
+			httptools.NotFound(w, r, fmt.Sprintf("Webhook delivery '%s' not found", input.DeliveryID))
+			return
+		}
+
+		if err := route.redeliverer.Redeliver(r.Context(), d); err != nil {
+			log.Error("failed to retry webhook delivery", "error", err)
+			httptools.InternalError(w, r)
+			return
+		}
+
+		httptools.JSON(w, r, http.StatusAccepted, DeliveryResponse{
+			Delivery: ToDeliveryDetail(d),
+		})
+	})
+}
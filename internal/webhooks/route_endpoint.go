@@ -0,0 +1,87 @@
+package webhooks
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/iamolegga/valmid"
+	"github.com/swaggest/openapi-go"
+	"github.com/swaggest/openapi-go/openapi31"
+
+	"github.com/grantsy/grantsy/internal/auth"
+	"github.com/grantsy/grantsy/internal/httptools"
+	"github.com/grantsy/grantsy/internal/infra/logger"
+	oa "github.com/grantsy/grantsy/internal/openapi"
+)
+
+type EndpointRequest struct {
+	EndpointID string `in:"path=endpoint_id" path:"endpoint_id" validate:"required" description:"Endpoint ID"`
+}
+
+type EndpointResponse struct {
+	Endpoint EndpointDetail `json:"endpoint" description:"Webhook endpoint" required:"true"`
+}
+
+type RouteEndpoint struct {
+	reader EndpointReader
+}
+
+func NewRouteEndpoint(reader EndpointReader) *RouteEndpoint {
+	return &RouteEndpoint{reader: reader}
+}
+
+func (route *RouteEndpoint) Register(mux *http.ServeMux, r *openapi31.Reflector) {
+	mux.Handle("GET /v1/webhooks/endpoints/{endpoint_id}", httptools.Wrap(
+		route.Handler(),
+		auth.RequireScope(auth.ScopeAdminWrite),
+		valmid.Middleware[EndpointRequest](),
+	))
+	RegisterEndpointSchema(r)
+}
+
+func RegisterEndpointSchema(r *openapi31.Reflector) {
+	op, _ := r.NewOperationContext(http.MethodGet, "/v1/webhooks/endpoints/{endpoint_id}")
+	op.AddReqStructure(new(EndpointRequest))
+	op.AddRespStructure(struct {
+		Data EndpointResponse `json:"data"`
+		Meta httptools.Meta   `json:"meta"`
+		_    struct{}         `title:"EndpointResponse"`
+	}{}, func(cu *openapi.ContentUnit) {
+		cu.HTTPStatus = http.StatusOK
+		cu.Description = "Webhook endpoint"
+	})
+	oa.AddErrorResponses(op)
+	op.SetSummary("Get webhook endpoint")
+	op.SetDescription("Get an outgoing webhook endpoint managed through the API. The secret is only returned on creation and rotation.")
+	op.SetTags("Webhooks")
+	op.AddSecurity("ApiKeyAuth", auth.ScopeAdminWrite)
+	op.AddSecurity("BearerAuth", auth.ScopeAdminWrite)
+	r.AddOperation(op)
+}
+
+func (route *RouteEndpoint) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		input := valmid.Get[EndpointRequest](r)
+
+		e, err := route.reader.GetEndpoint(r.Context(), input.EndpointID)
+		if err != nil {
+			logger.FromContext(r.Context()).
+				Error("failed to get webhook endpoint", "error", err, "endpoint_id", input.EndpointID)
+			httptools.InternalError(w, r)
+			return
+		}
+		if e == nil {
+			writeEndpointNotFound(w, r, input.EndpointID)
+			return
+		}
+
+		httptools.JSON(w, r, http.StatusOK, EndpointResponse{
+			Endpoint: ToEndpointDetail(e, time.Now()),
+		})
+	})
+}
+
+func writeEndpointNotFound(w http.ResponseWriter, r *http.Request, id string) {
+	httptools.NotFound(w, r, fmt.Sprintf("Webhook endpoint '%s' not found", id))
+}
@@ -0,0 +1,109 @@
+package webhooks
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/iamolegga/valmid"
+	"github.com/swaggest/openapi-go"
+	"github.com/swaggest/openapi-go/openapi31"
+
+	"github.com/grantsy/grantsy/internal/auth"
+	"github.com/grantsy/grantsy/internal/httptools"
+	"github.com/grantsy/grantsy/internal/infra/logger"
+	oa "github.com/grantsy/grantsy/internal/openapi"
+)
+
+type EndpointBody struct {
+	URL    string   `json:"url"              validate:"required,url"                                                                                                                                      description:"Destination URL"                                                   required:"true"`
+	Events []string `json:"events,omitempty" validate:"dive,oneof=user.plan_changed subscription.updated subscription.payment_succeeded subscription.payment_failed subscription.payment_recovered grant.expired" description:"Event types to send, all if empty"`
+	Plans  []string `json:"plans,omitempty"  description:"Only send events for users moving from or to these plans, all if empty"`
+}
+
+type EndpointCreateRequest struct {
+	Body *EndpointBody `in:"body=json" validate:"required"`
+}
+
+type EndpointSecretResponse struct {
+	Endpoint EndpointWithSecret `json:"endpoint" description:"Webhook endpoint with its signing secret" required:"true"`
+}
+
+type RouteEndpointCreate struct {
+	writer EndpointWriter
+}
+
+func NewRouteEndpointCreate(writer EndpointWriter) *RouteEndpointCreate {
+	return &RouteEndpointCreate{writer: writer}
+}
+
+func (route *RouteEndpointCreate) Register(mux *http.ServeMux, r *openapi31.Reflector) {
+	mux.Handle("POST /v1/webhooks/endpoints", httptools.Wrap(
+		route.Handler(),
+		auth.RequireScope(auth.ScopeAdminWrite),
+		valmid.Middleware[EndpointCreateRequest](),
+	))
+	RegisterEndpointCreateSchema(r)
+}
+
+func RegisterEndpointCreateSchema(r *openapi31.Reflector) {
+	op, _ := r.NewOperationContext(http.MethodPost, "/v1/webhooks/endpoints")
+	op.AddReqStructure(new(EndpointBody))
+	op.AddRespStructure(struct {
+		Data EndpointSecretResponse `json:"data"`
+		Meta httptools.Meta         `json:"meta"`
+		_    struct{}               `title:"EndpointCreateResponse"`
+	}{}, func(cu *openapi.ContentUnit) {
+		cu.HTTPStatus = http.StatusCreated
+		cu.Description = "Endpoint created"
+	})
+	addConflictResponse(op)
+	oa.AddErrorResponses(op)
+	op.SetSummary("Create webhook endpoint")
+	op.SetDescription(
+		"Add an outgoing webhook endpoint with a generated signing secret. The secret is only returned in this response and when it is rotated.",
+	)
+	op.SetTags("Webhooks")
+	op.AddSecurity("ApiKeyAuth", auth.ScopeAdminWrite)
+	op.AddSecurity("BearerAuth", auth.ScopeAdminWrite)
+	r.AddOperation(op)
+}
+
+func (route *RouteEndpointCreate) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		input := valmid.Get[EndpointCreateRequest](r)
+
+		e, err := route.writer.CreateEndpoint(r.Context(), input.Body.toInput())
+		if errors.Is(err, ErrEndpointExists) {
+			writeEndpointExists(w, r)
+			return
+		}
+		if err != nil {
+			logger.FromContext(r.Context()).Error("failed to create webhook endpoint", "error", err)
+			httptools.InternalError(w, r)
+			return
+		}
+
+		httptools.JSON(w, r, http.StatusCreated, EndpointSecretResponse{
+			Endpoint: ToEndpointWithSecret(e, time.Now()),
+		})
+	})
+}
+
+func (b *EndpointBody) toInput() EndpointInput {
+	return EndpointInput{URL: b.URL, Events: b.Events, Plans: b.Plans}
+}
+
+func writeEndpointExists(w http.ResponseWriter, r *http.Request) {
+	httptools.Conflict(w, r, "A webhook endpoint with this URL already exists")
+}
+
+func addConflictResponse(op openapi.OperationContext) {
+	op.AddRespStructure(
+		new(httptools.ErrorResponse),
+		func(cu *openapi.ContentUnit) {
+			cu.HTTPStatus = http.StatusConflict
+			cu.Description = "An endpoint with this URL already exists"
+		},
+	)
+}
@@ -0,0 +1,69 @@
+package webhooks
+
+import (
+	"net/http"
+
+	"github.com/iamolegga/valmid"
+	"github.com/swaggest/openapi-go"
+	"github.com/swaggest/openapi-go/openapi31"
+
+	"github.com/grantsy/grantsy/internal/auth"
+	"github.com/grantsy/grantsy/internal/httptools"
+	"github.com/grantsy/grantsy/internal/infra/logger"
+	oa "github.com/grantsy/grantsy/internal/openapi"
+)
+
+type RouteEndpointDelete struct {
+	writer EndpointWriter
+}
+
+func NewRouteEndpointDelete(writer EndpointWriter) *RouteEndpointDelete {
+	return &RouteEndpointDelete{writer: writer}
+}
+
+func (route *RouteEndpointDelete) Register(mux *http.ServeMux, r *openapi31.Reflector) {
+	mux.Handle("DELETE /v1/webhooks/endpoints/{endpoint_id}", httptools.Wrap(
+		route.Handler(),
+		auth.RequireScope(auth.ScopeAdminWrite),
+		valmid.Middleware[EndpointRequest](),
+	))
+	RegisterEndpointDeleteSchema(r)
+}
+
+func RegisterEndpointDeleteSchema(r *openapi31.Reflector) {
+	op, _ := r.NewOperationContext(http.MethodDelete, "/v1/webhooks/endpoints/{endpoint_id}")
+	op.AddReqStructure(new(EndpointRequest))
+	op.AddRespStructure(nil, func(cu *openapi.ContentUnit) {
+		cu.HTTPStatus = http.StatusNoContent
+		cu.Description = "Endpoint deleted"
+	})
+	oa.AddErrorResponses(op)
+	op.SetSummary("Delete webhook endpoint")
+	op.SetDescription(
+		"Remove an outgoing webhook endpoint. Messages already queued for it are dropped.",
+	)
+	op.SetTags("Webhooks")
+	op.AddSecurity("ApiKeyAuth", auth.ScopeAdminWrite)
+	op.AddSecurity("BearerAuth", auth.ScopeAdminWrite)
+	r.AddOperation(op)
+}
+
+func (route *RouteEndpointDelete) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		input := valmid.Get[EndpointRequest](r)
+
+		deleted, err := route.writer.DeleteEndpoint(r.Context(), input.EndpointID)
+		if err != nil {
+			logger.FromContext(r.Context()).
+				Error("failed to delete webhook endpoint", "error", err, "endpoint_id", input.EndpointID)
+			httptools.InternalError(w, r)
+			return
+		}
+		if !deleted {
+			writeEndpointNotFound(w, r, input.EndpointID)
+			return
+		}
+
+		httptools.WriteStatus(w, http.StatusNoContent)
+	})
+}
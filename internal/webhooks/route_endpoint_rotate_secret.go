@@ -0,0 +1,99 @@
+package webhooks
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/iamolegga/valmid"
+	"github.com/swaggest/openapi-go"
+	"github.com/swaggest/openapi-go/openapi31"
+
+	"github.com/grantsy/grantsy/internal/auth"
+	"github.com/grantsy/grantsy/internal/httptools"
+	"github.com/grantsy/grantsy/internal/infra/logger"
+	oa "github.com/grantsy/grantsy/internal/openapi"
+)
+
+// defaultRollover is how long the previous secret keeps being used after a rotation.
+const defaultRollover = 24 * time.Hour
+
+type RotateSecretBody struct {
+	RolloverSeconds *int64 `json:"rollover_seconds,omitempty" validate:"omitempty,min=0,max=604800" description:"How long payloads are also signed with the previous secret (defaults to 86400, 0 revokes it immediately)"`
+}
+
+type RotateSecretRequest struct {
+	EndpointID string            `in:"path=endpoint_id" path:"endpoint_id" validate:"required" description:"Endpoint ID"`
+	Body       *RotateSecretBody `in:"body=json" validate:"required"`
+}
+
+// rotateSecretSchema mirrors RotateSecretRequest for OpenAPI spec generation.
+type rotateSecretSchema struct {
+	EndpointID string `path:"endpoint_id" description:"Endpoint ID"`
+	RotateSecretBody
+}
+
+type RouteEndpointRotateSecret struct {
+	writer EndpointWriter
+}
+
+func NewRouteEndpointRotateSecret(writer EndpointWriter) *RouteEndpointRotateSecret {
+	return &RouteEndpointRotateSecret{writer: writer}
+}
+
+func (route *RouteEndpointRotateSecret) Register(mux *http.ServeMux, r *openapi31.Reflector) {
+	mux.Handle("POST /v1/webhooks/endpoints/{endpoint_id}/rotate-secret", httptools.Wrap(
+		route.Handler(),
+		auth.RequireScope(auth.ScopeAdminWrite),
+		valmid.Middleware[RotateSecretRequest](),
+	))
+	RegisterEndpointRotateSecretSchema(r)
+}
+
+func RegisterEndpointRotateSecretSchema(r *openapi31.Reflector) {
+	op, _ := r.NewOperationContext(http.MethodPost, "/v1/webhooks/endpoints/{endpoint_id}/rotate-secret")
+	op.AddReqStructure(new(rotateSecretSchema))
+	op.AddRespStructure(struct {
+		Data EndpointSecretResponse `json:"data"`
+		Meta httptools.Meta         `json:"meta"`
+		_    struct{}               `title:"EndpointRotateSecretResponse"`
+	}{}, func(cu *openapi.ContentUnit) {
+		cu.HTTPStatus = http.StatusOK
+		cu.Description = "Secret rotated"
+	})
+	oa.AddErrorResponses(op)
+	op.SetSummary("Rotate webhook endpoint secret")
+	op.SetDescription(
+		"Generate a new signing secret. During the rollover window payloads carry signatures for both the new and the previous secret, so consumers can switch without rejecting deliveries. Send an empty object for the default 24 hour rollover.",
+	)
+	op.SetTags("Webhooks")
+	op.AddSecurity("ApiKeyAuth", auth.ScopeAdminWrite)
+	op.AddSecurity("BearerAuth", auth.ScopeAdminWrite)
+	r.AddOperation(op)
+}
+
+func (route *RouteEndpointRotateSecret) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		input := valmid.Get[RotateSecretRequest](r)
+
+		rollover := defaultRollover
+		if input.Body.RolloverSeconds != nil {
+			rollover = time.Duration(*input.Body.RolloverSeconds) * time.Second
+		}
+
+		e, err := route.writer.RotateSecret(r.Context(), input.EndpointID, rollover)
+		if err != nil {
+			logger.FromContext(r.Context()).
+				Error("failed to rotate webhook endpoint secret", "error", err, "endpoint_id", input.EndpointID)
+			httptools.InternalError(w, r)
+			return
+		}
+		if e == nil {
+			writeEndpointNotFound(w, r, input.EndpointID)
+			return
+		}
+
+		httptools.JSON(w, r, http.StatusOK, EndpointSecretResponse{
+			Endpoint: ToEndpointWithSecret(e, time.Now()),
+		})
+	})
+}
@@ -0,0 +1,91 @@
+package webhooks
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/iamolegga/valmid"
+	"github.com/swaggest/openapi-go"
+	"github.com/swaggest/openapi-go/openapi31"
+
+	"github.com/grantsy/grantsy/internal/auth"
+	"github.com/grantsy/grantsy/internal/httptools"
+	"github.com/grantsy/grantsy/internal/infra/logger"
+	oa "github.com/grantsy/grantsy/internal/openapi"
+)
+
+type EndpointUpdateRequest struct {
+	EndpointID string        `in:"path=endpoint_id" path:"endpoint_id" validate:"required" description:"Endpoint ID"`
+	Body       *EndpointBody `in:"body=json" validate:"required"`
+}
+
+// endpointUpdateSchema mirrors EndpointUpdateRequest for OpenAPI spec generation.
+type endpointUpdateSchema struct {
+	EndpointID string `path:"endpoint_id" description:"Endpoint ID"`
+	EndpointBody
+}
+
+type RouteEndpointUpdate struct {
+	writer EndpointWriter
+}
+
+func NewRouteEndpointUpdate(writer EndpointWriter) *RouteEndpointUpdate {
+	return &RouteEndpointUpdate{writer: writer}
+}
+
+func (route *RouteEndpointUpdate) Register(mux *http.ServeMux, r *openapi31.Reflector) {
+	mux.Handle("PUT /v1/webhooks/endpoints/{endpoint_id}", httptools.Wrap(
+		route.Handler(),
+		auth.RequireScope(auth.ScopeAdminWrite),
+		valmid.Middleware[EndpointUpdateRequest](),
+	))
+	RegisterEndpointUpdateSchema(r)
+}
+
+func RegisterEndpointUpdateSchema(r *openapi31.Reflector) {
+	op, _ := r.NewOperationContext(http.MethodPut, "/v1/webhooks/endpoints/{endpoint_id}")
+	op.AddReqStructure(new(endpointUpdateSchema))
+	op.AddRespStructure(struct {
+		Data EndpointResponse `json:"data"`
+		Meta httptools.Meta   `json:"meta"`
+		_    struct{}         `title:"EndpointUpdateResponse"`
+	}{}, func(cu *openapi.ContentUnit) {
+		cu.HTTPStatus = http.StatusOK
+		cu.Description = "Endpoint updated"
+	})
+	addConflictResponse(op)
+	oa.AddErrorResponses(op)
+	op.SetSummary("Update webhook endpoint")
+	op.SetDescription("Replace the URL and filters of an outgoing webhook endpoint. The secret is kept.")
+	op.SetTags("Webhooks")
+	op.AddSecurity("ApiKeyAuth", auth.ScopeAdminWrite)
+	op.AddSecurity("BearerAuth", auth.ScopeAdminWrite)
+	r.AddOperation(op)
+}
+
+func (route *RouteEndpointUpdate) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		input := valmid.Get[EndpointUpdateRequest](r)
+
+		e, err := route.writer.UpdateEndpoint(r.Context(), input.EndpointID, input.Body.toInput())
+		if errors.Is(err, ErrEndpointExists) {
+			writeEndpointExists(w, r)
+			return
+		}
+		if err != nil {
+			logger.FromContext(r.Context()).
+				Error("failed to update webhook endpoint", "error", err, "endpoint_id", input.EndpointID)
+			httptools.InternalError(w, r)
+			return
+		}
+		if e == nil {
+			writeEndpointNotFound(w, r, input.EndpointID)
+			return
+		}
+
+		httptools.JSON(w, r, http.StatusOK, EndpointResponse{
+			Endpoint: ToEndpointDetail(e, time.Now()),
+		})
+	})
+}
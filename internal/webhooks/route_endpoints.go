@@ -0,0 +1,125 @@
+package webhooks
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/swaggest/openapi-go"
+	"github.com/swaggest/openapi-go/openapi31"
+
+	"github.com/grantsy/grantsy/internal/auth"
+	"github.com/grantsy/grantsy/internal/httptools"
+	"github.com/grantsy/grantsy/internal/infra/logger"
+	oa "github.com/grantsy/grantsy/internal/openapi"
+)
+
+// EndpointReader reads endpoints managed through the API.
+type EndpointReader interface {
+	ListEndpoints(ctx context.Context) ([]Endpoint, error)
+	GetEndpoint(ctx context.Context, id string) (*Endpoint, error)
+}
+
+// EndpointWriter manages endpoints through the API.
+type EndpointWriter interface {
+	CreateEndpoint(ctx context.Context, in EndpointInput) (*Endpoint, error)
+	UpdateEndpoint(ctx context.Context, id string, in EndpointInput) (*Endpoint, error)
+	DeleteEndpoint(ctx context.Context, id string) (bool, error)
+	RotateSecret(ctx context.Context, id string, rollover time.Duration) (*Endpoint, error)
+}
+
+type EndpointsResponse struct {
+	Endpoints []EndpointDetail `json:"endpoints" description:"Endpoints managed through the API, oldest first" nullable:"false" required:"true"`
+}
+
+type EndpointDetail struct {
+	ID                      string   `json:"id"                                  description:"Endpoint identifier"                                              required:"true"`
+	URL                     string   `json:"url"                                 description:"Destination URL"                                                  required:"true"`
+	Events                  []string `json:"events"                              description:"Event types sent to the endpoint, all if empty"                   required:"true" nullable:"false"`
+	Plans                   []string `json:"plans"                               description:"Only events for users moving from or to these plans, all if empty" required:"true" nullable:"false"`
+	PreviousSecretExpiresAt *int64   `json:"previous_secret_expires_at,omitempty" description:"Unix timestamp until which payloads are also signed with the previous secret"`
+	CreatedAt               int64    `json:"created_at"                          description:"Unix timestamp of creation"                                       required:"true"`
+	UpdatedAt               int64    `json:"updated_at"                          description:"Unix timestamp of the last change"                                required:"true"`
+}
+
+// EndpointWithSecret is an endpoint together with its signing secret, only
+// returned when the secret is generated.
+type EndpointWithSecret struct {
+	EndpointDetail
+	Secret string `json:"secret" description:"Signing secret (whsec_ prefixed, base64-encoded key)" required:"true"`
+}
+
+type RouteEndpoints struct {
+	reader EndpointReader
+}
+
+func NewRouteEndpoints(reader EndpointReader) *RouteEndpoints {
+	return &RouteEndpoints{reader: reader}
+}
+
+func (route *RouteEndpoints) Register(mux *http.ServeMux, r *openapi31.Reflector) {
+	mux.Handle("GET /v1/webhooks/endpoints",
+		auth.RequireScope(auth.ScopeAdminWrite)(route.Handler()),
+	)
+	RegisterEndpointsSchema(r)
+}
+
+func RegisterEndpointsSchema(r *openapi31.Reflector) {
+	op, _ := r.NewOperationContext(http.MethodGet, "/v1/webhooks/endpoints")
+	op.AddRespStructure(struct {
+		Data EndpointsResponse `json:"data"`
+		Meta httptools.Meta    `json:"meta"`
+		_    struct{}          `title:"EndpointsResponse"`
+	}{}, func(cu *openapi.ContentUnit) {
+		cu.HTTPStatus = http.StatusOK
+		cu.Description = "Webhook endpoints"
+	})
+	oa.AddErrorResponses(op)
+	op.SetSummary("List webhook endpoints")
+	op.SetDescription(
+		"List the outgoing webhook endpoints managed through the API. Endpoints from the config file are not included.",
+	)
+	op.SetTags("Webhooks")
+	op.AddSecurity("ApiKeyAuth", auth.ScopeAdminWrite)
+	op.AddSecurity("BearerAuth", auth.ScopeAdminWrite)
+	r.AddOperation(op)
+}
+
+func (route *RouteEndpoints) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		endpoints, err := route.reader.ListEndpoints(r.Context())
+		if err != nil {
+			logger.FromContext(r.Context()).Error("failed to list webhook endpoints", "error", err)
+			httptools.InternalError(w, r)
+			return
+		}
+
+		resp := EndpointsResponse{Endpoints: make([]EndpointDetail, 0, len(endpoints))}
+		for _, e := range endpoints {
+			resp.Endpoints = append(resp.Endpoints, ToEndpointDetail(&e, time.Now()))
+		}
+
+		httptools.JSON(w, r, http.StatusOK, resp)
+	})
+}
+
+// ToEndpointDetail converts an Endpoint to its display type, without the secret.
+func ToEndpointDetail(e *Endpoint, now time.Time) EndpointDetail {
+	detail := EndpointDetail{
+		ID:        e.ID,
+		URL:       e.URL,
+		Events:    nonNil(e.Events),
+		Plans:     nonNil(e.Plans),
+		CreatedAt: e.CreatedAt,
+		UpdatedAt: e.UpdatedAt,
+	}
+	if len(e.Secrets(now)) > 1 {
+		detail.PreviousSecretExpiresAt = &e.PrevSecretExpiresAt
+	}
+	return detail
+}
+
+// ToEndpointWithSecret converts an Endpoint to its display type including the secret.
+func ToEndpointWithSecret(e *Endpoint, now time.Time) EndpointWithSecret {
+	return EndpointWithSecret{EndpointDetail: ToEndpointDetail(e, now), Secret: e.Secret}
+}
@@ -0,0 +1,170 @@
+package webhooks_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/openapi-go/openapi31"
+
+	"github.com/grantsy/grantsy/internal/auth"
+	"github.com/grantsy/grantsy/internal/auth/authtest"
+	"github.com/grantsy/grantsy/internal/httptools"
+	"github.com/grantsy/grantsy/internal/webhooks"
+	"github.com/grantsy/grantsy/internal/webhooks/mocks"
+)
+
+func newEndpointsMux(t *testing.T, reader webhooks.EndpointReader, writer webhooks.EndpointWriter) http.Handler {
+	t.Helper()
+	mux := http.NewServeMux()
+	r := openapi31.NewReflector()
+	webhooks.NewRouteEndpoints(reader).Register(mux, r)
+	webhooks.NewRouteEndpoint(reader).Register(mux, r)
+	webhooks.NewRouteEndpointCreate(writer).Register(mux, r)
+	webhooks.NewRouteEndpointUpdate(writer).Register(mux, r)
+	webhooks.NewRouteEndpointDelete(writer).Register(mux, r)
+	webhooks.NewRouteEndpointRotateSecret(writer).Register(mux, r)
+	return authtest.Handler(mux)
+}
+
+func serveEndpoints(t *testing.T, mux http.Handler, method, target, body string) (int, map[string]any) {
+	t.Helper()
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Body.Len() == 0 {
+		return w.Code, nil
+	}
+	var resp httptools.Response
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	data, _ := resp.Data.(map[string]any)
+	return w.Code, data
+}
+
+func testEndpoint() *webhooks.Endpoint {
+	return &webhooks.Endpoint{
+		ID:        "01",
+		URL:       "https://example.com/hook",
+		Secret:    "whsec_c2VjcmV0",
+		Events:    []string{"user.plan_changed"},
+		CreatedAt: 1700000000,
+		UpdatedAt: 1700000000,
+	}
+}
+
+func TestRouteEndpoints_List_HidesSecret(t *testing.T) {
+	reader := mocks.NewMockEndpointReader(t)
+	reader.EXPECT().ListEndpoints(mock.Anything).Return([]webhooks.Endpoint{*testEndpoint()}, nil)
+
+	mux := newEndpointsMux(t, reader, mocks.NewMockEndpointWriter(t))
+	code, data := serveEndpoints(t, mux, http.MethodGet, "/v1/webhooks/endpoints", "")
+
+	assert.Equal(t, http.StatusOK, code)
+	endpoints := data["endpoints"].([]any)
+	require.Len(t, endpoints, 1)
+	assert.NotContains(t, endpoints[0], "secret")
+	assert.Equal(t, []any{}, endpoints[0].(map[string]any)["plans"])
+}
+
+func TestRouteEndpointCreate(t *testing.T) {
+	writer := mocks.NewMockEndpointWriter(t)
+	writer.EXPECT().
+		CreateEndpoint(mock.Anything, webhooks.EndpointInput{
+			URL:    "https://example.com/hook",
+			Events: []string{"user.plan_changed"},
+		}).
+		Return(testEndpoint(), nil)
+
+	mux := newEndpointsMux(t, mocks.NewMockEndpointReader(t), writer)
+	code, data := serveEndpoints(t, mux, http.MethodPost, "/v1/webhooks/endpoints",
+		`{"url":"https://example.com/hook","events":["user.plan_changed"]}`)
+
+	assert.Equal(t, http.StatusCreated, code)
+	assert.Equal(t, "whsec_c2VjcmV0", data["endpoint"].(map[string]any)["secret"])
+}
+
+func TestRouteEndpointCreate_InvalidEvent(t *testing.T) {
+	mux := newEndpointsMux(t, mocks.NewMockEndpointReader(t), mocks.NewMockEndpointWriter(t))
+	code, _ := serveEndpoints(t, mux, http.MethodPost, "/v1/webhooks/endpoints",
+		`{"url":"https://example.com/hook","events":["user.deleted"]}`)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, code)
+}
+
+func TestRouteEndpointCreate_Exists(t *testing.T) {
+	writer := mocks.NewMockEndpointWriter(t)
+	writer.EXPECT().CreateEndpoint(mock.Anything, mock.Anything).Return(nil, webhooks.ErrEndpointExists)
+
+	mux := newEndpointsMux(t, mocks.NewMockEndpointReader(t), writer)
+	code, _ := serveEndpoints(t, mux, http.MethodPost, "/v1/webhooks/endpoints",
+		`{"url":"https://example.com/hook"}`)
+
+	assert.Equal(t, http.StatusConflict, code)
+}
+
+func TestRouteEndpointUpdate_NotFound(t *testing.T) {
+	writer := mocks.NewMockEndpointWriter(t)
+	writer.EXPECT().UpdateEndpoint(mock.Anything, "missing", mock.Anything).Return(nil, nil)
+
+	mux := newEndpointsMux(t, mocks.NewMockEndpointReader(t), writer)
+	code, _ := serveEndpoints(t, mux, http.MethodPut, "/v1/webhooks/endpoints/missing",
+		`{"url":"https://example.com/hook"}`)
+
+	assert.Equal(t, http.StatusNotFound, code)
+}
+
+func TestRouteEndpointDelete(t *testing.T) {
+	writer := mocks.NewMockEndpointWriter(t)
+	writer.EXPECT().DeleteEndpoint(mock.Anything, "01").Return(true, nil)
+
+	mux := newEndpointsMux(t, mocks.NewMockEndpointReader(t), writer)
+	code, _ := serveEndpoints(t, mux, http.MethodDelete, "/v1/webhooks/endpoints/01", "")
+
+	assert.Equal(t, http.StatusNoContent, code)
+}
+
+func TestRouteEndpointRotateSecret_DefaultRollover(t *testing.T) {
+	rotated := testEndpoint()
+	rotated.PrevSecret = "whsec_b2xk"
+	rotated.PrevSecretExpiresAt = time.Now().Add(24 * time.Hour).Unix()
+	writer := mocks.NewMockEndpointWriter(t)
+	writer.EXPECT().RotateSecret(mock.Anything, "01", 24*time.Hour).Return(rotated, nil)
+
+	mux := newEndpointsMux(t, mocks.NewMockEndpointReader(t), writer)
+	code, data := serveEndpoints(t, mux, http.MethodPost, "/v1/webhooks/endpoints/01/rotate-secret", `{}`)
+
+	assert.Equal(t, http.StatusOK, code)
+	endpoint := data["endpoint"].(map[string]any)
+	assert.Equal(t, "whsec_c2VjcmV0", endpoint["secret"])
+	assert.InDelta(t, rotated.PrevSecretExpiresAt, endpoint["previous_secret_expires_at"], 0)
+}
+
+func TestRouteEndpointRotateSecret_ImmediateRevoke(t *testing.T) {
+	writer := mocks.NewMockEndpointWriter(t)
+	writer.EXPECT().RotateSecret(mock.Anything, "01", time.Duration(0)).Return(testEndpoint(), nil)
+
+	mux := newEndpointsMux(t, mocks.NewMockEndpointReader(t), writer)
+	code, data := serveEndpoints(t, mux, http.MethodPost, "/v1/webhooks/endpoints/01/rotate-secret",
+		`{"rollover_seconds":0}`)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.NotContains(t, data["endpoint"], "previous_secret_expires_at")
+}
+
+func TestRouteEndpoints_RequiresAdminScope(t *testing.T) {
+	mux := http.NewServeMux()
+	webhooks.NewRouteEndpoints(mocks.NewMockEndpointReader(t)).Register(mux, openapi31.NewReflector())
+
+	handler := authtest.Handler(mux, auth.ScopeCheckRead, auth.ScopePlansRead)
+	code, _ := serveEndpoints(t, handler, http.MethodGet, "/v1/webhooks/endpoints", "")
+
+	assert.Equal(t, http.StatusForbidden, code)
+}
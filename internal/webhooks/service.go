@@ -3,54 +3,223 @@ package webhooks
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/iamolegga/goqite"
 	"github.com/iamolegga/goqite/jobs"
 
-	"github.com/grantsy/grantsy/internal/infra/config"
+	"github.com/grantsy/grantsy/internal/infra/db"
 	"github.com/grantsy/grantsy/internal/infra/metrics"
 )
 
+// DeadLetterStore persists messages that exhausted their retries.
+type DeadLetterStore interface {
+	InsertDeadLetter(ctx context.Context, dl *DeadLetter) error
+	DeleteDeadLetter(ctx context.Context, id string) error
+	CountDeadLetters(ctx context.Context) (map[string]int, error)
+}
+
+// SequenceGenerator hands out per-user webhook sequence numbers.
+type SequenceGenerator interface {
+	NextSequence(ctx context.Context, userID string) (int64, error)
+}
+
+// EndpointLister returns every endpoint webhooks are sent to.
+type EndpointLister interface {
+	AllEndpoints(ctx context.Context) ([]Endpoint, error)
+}
+
 // Service handles queueing webhook notifications
 type Service struct {
-	queue     *goqite.Queue
-	endpoints []config.WebhookEndpoint
+	queue       *goqite.Queue
+	endpoints   EndpointLister
+	deadLetters DeadLetterStore
+	sequences   SequenceGenerator
 }
 
 // NewService creates a new webhook service
-func NewService(queue *goqite.Queue, endpoints []config.WebhookEndpoint) *Service {
-	return &Service{queue: queue, endpoints: endpoints}
+func NewService(
+	queue *goqite.Queue,
+	endpoints EndpointLister,
+	deadLetters DeadLetterStore,
+	sequences SequenceGenerator,
+) *Service {
+	return &Service{
+		queue:       queue,
+		endpoints:   endpoints,
+		deadLetters: deadLetters,
+		sequences:   sequences,
+	}
 }
 
-// NotifyPlanUpdated queues a webhook notification for a plan update.
-// One message is enqueued per endpoint for independent retry handling.
+// NotifyPlanUpdated queues webhook notifications for a subscription change:
+// subscription.updated always, user.plan_changed when the plan differs and
+// grant.expired when an inactive subscription took the plan away.
 func (s *Service) NotifyPlanUpdated(
 	ctx context.Context,
 	userID, activePlan, prevPlan string,
+	active bool,
 	subscription any,
 ) error {
-	for _, endpoint := range s.endpoints {
-		payload := Payload{
-			Endpoint:   endpoint.URL,
-			UserID:     userID,
-			ActivePlan: activePlan,
-			Meta: Meta{
-				PrevPlan:     prevPlan,
-				Subscription: subscription,
-			},
+	payload := Payload{
+		UserID:     userID,
+		ActivePlan: activePlan,
+		Meta: Meta{
+			PrevPlan:     prevPlan,
+			Subscription: subscription,
+		},
+	}
+
+	events := []EventType{EventSubscriptionUpdated}
+	if activePlan != prevPlan {
+		events = append(events, EventUserPlanChanged)
+		if !active {
+			events = append(events, EventGrantExpired)
+		}
+	}
+	for _, event := range events {
+		if err := s.publish(ctx, event, payload); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NotifyPayment queues a subscription.payment_* notification for the outcome
+// ("succeeded", "failed" or "recovered") of a subscription payment.
+func (s *Service) NotifyPayment(
+	ctx context.Context,
+	userID, activePlan, outcome string,
+	subscription, payment any,
+) error {
+	return s.publish(ctx, EventType("subscription.payment_"+outcome), Payload{
+		UserID:     userID,
+		ActivePlan: activePlan,
+		Meta: Meta{
+			Subscription: subscription,
+			Payment:      payment,
+		},
+	})
+}
+
+// publish enqueues the event for every endpoint subscribed to it.
+// One message is enqueued per endpoint for independent retry handling. All
+// endpoints receive the same message ID and sequence number for the event.
+func (s *Service) publish(ctx context.Context, event EventType, payload Payload) error {
+	all, err := s.endpoints.AllEndpoints(ctx)
+	if err != nil {
+		return err
+	}
+	var endpoints []Endpoint
+	for _, endpoint := range all {
+		if subscribed(endpoint, event, payload) {
+			endpoints = append(endpoints, endpoint)
 		}
+	}
+	if len(endpoints) == 0 {
+		return nil
+	}
+
+	seq, err := s.sequences.NextSequence(ctx, payload.UserID)
+	if err != nil {
+		return err
+	}
+	payload.ID = uuid.Must(uuid.NewV7()).String()
+	payload.Sequence = seq
+	payload.Type = event
 
+	for _, endpoint := range endpoints {
+		payload.Endpoint = endpoint.URL
+		payload.EndpointID = endpoint.ID
 		body, err := json.Marshal(payload)
 		if err != nil {
 			return err
 		}
-
-		if _, err := jobs.Create(ctx, s.queue, "webhooks", goqite.Message{Body: body}); err != nil {
+		if err := s.enqueueNew(ctx, endpoint.URL, body); err != nil {
 			return err
 		}
+	}
+	return nil
+}
 
-		metrics.RecordWebhookQueued(endpoint.URL)
+// enqueueNew queues a payload for its first delivery attempt.
+func (s *Service) enqueueNew(ctx context.Context, endpoint string, payload []byte) error {
+	msg := Message{Payload: payload, QueuedAt: time.Now().Unix()}
+	if err := s.Enqueue(ctx, msg, 0); err != nil {
+		return err
 	}
+	db.AfterCommit(ctx, func() { metrics.RecordWebhookQueued(endpoint) })
+	return nil
+}
 
+// Enqueue queues a webhook message to be delivered after delay. Inside a
+// db.InTx transaction the message is only queued if the transaction commits.
+func (s *Service) Enqueue(ctx context.Context, msg Message, delay time.Duration) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("webhooks: failed to marshal message: %w", err)
+	}
+	m := goqite.Message{Body: body, Delay: delay}
+	if tx := db.Tx(ctx); tx != nil {
+		_, err = jobs.CreateTx(ctx, tx, s.queue, "webhooks", m)
+	} else {
+		_, err = jobs.Create(ctx, s.queue, "webhooks", m)
+	}
+	if err != nil {
+		return fmt.Errorf("webhooks: failed to queue message: %w", err)
+	}
 	return nil
 }
+
+// subscribed reports whether the endpoint's events and plans filters accept
+// the event. Empty filters accept everything; the plans filter matches either
+// the active or the previous plan.
+func subscribed(endpoint Endpoint, event EventType, payload Payload) bool {
+	if len(endpoint.Events) > 0 && !slices.Contains(endpoint.Events, string(event)) {
+		return false
+	}
+	if len(endpoint.Plans) > 0 &&
+		!slices.Contains(endpoint.Plans, payload.ActivePlan) &&
+		!slices.Contains(endpoint.Plans, payload.Meta.PrevPlan) {
+		return false
+	}
+	return true
+}
+
+// Redeliver queues a recorded delivery to be sent again with its original payload.
+// The redelivery gets a fresh retry budget.
+func (s *Service) Redeliver(ctx context.Context, d *Delivery) error {
+	return s.enqueueNew(ctx, d.Endpoint, d.Payload)
+}
+
+// RequeueDeadLetter queues a dead-lettered message again with a fresh retry
+// budget and removes it from the dead-letter queue.
+func (s *Service) RequeueDeadLetter(ctx context.Context, dl *DeadLetter) error {
+	if err := s.enqueueNew(ctx, dl.Endpoint, dl.Payload); err != nil {
+		return err
+	}
+	if err := s.deadLetters.DeleteDeadLetter(ctx, dl.ID); err != nil {
+		return err
+	}
+	UpdateDeadLetterDepth(ctx, s.deadLetters)
+	return nil
+}
+
+// DeadLetterCounter counts dead letters per endpoint.
+type DeadLetterCounter interface {
+	CountDeadLetters(ctx context.Context) (map[string]int, error)
+}
+
+// UpdateDeadLetterDepth refreshes the dead-letter queue depth metric.
+func UpdateDeadLetterDepth(ctx context.Context, counter DeadLetterCounter) {
+	counts, err := counter.CountDeadLetters(ctx)
+	if err != nil {
+		slog.Error("failed to count webhook dead letters", "error", err)
+		return
+	}
+	metrics.SetWebhookDeadLetters(counts)
+}
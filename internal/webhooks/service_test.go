@@ -0,0 +1,59 @@
+package webhooks_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/iamolegga/goqite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grantsy/grantsy/internal/infra/db"
+	"github.com/grantsy/grantsy/internal/webhooks"
+	"github.com/grantsy/grantsy/internal/webhooks/mocks"
+)
+
+func newTestQueue(t *testing.T) (*db.DB, *goqite.Queue) {
+	t.Helper()
+
+	database, err := db.New("sqlite", filepath.Join(t.TempDir(), "test.db"), "")
+	require.NoError(t, err)
+	t.Cleanup(func() { database.Close() })
+
+	queue := goqite.New(goqite.NewOpts{DB: database.DB, Name: "webhooks"})
+	require.NoError(t, queue.Setup(context.Background()))
+	return database, queue
+}
+
+func TestService_Enqueue_InTx(t *testing.T) {
+	ctx := context.Background()
+	database, queue := newTestQueue(t)
+	svc := webhooks.NewService(queue, mocks.NewMockEndpointLister(t), nil, nil)
+
+	err := database.InTx(ctx, func(ctx context.Context) error {
+		return svc.Enqueue(ctx, webhooks.Message{Payload: []byte(`{}`)}, 0)
+	})
+	require.NoError(t, err)
+
+	msg, err := queue.Receive(ctx)
+	require.NoError(t, err)
+	assert.NotNil(t, msg)
+}
+
+func TestService_Enqueue_InTx_Rollback(t *testing.T) {
+	ctx := context.Background()
+	database, queue := newTestQueue(t)
+	svc := webhooks.NewService(queue, mocks.NewMockEndpointLister(t), nil, nil)
+
+	err := database.InTx(ctx, func(ctx context.Context) error {
+		require.NoError(t, svc.Enqueue(ctx, webhooks.Message{Payload: []byte(`{}`)}, 0))
+		return errors.New("rollback")
+	})
+	require.Error(t, err)
+
+	msg, err := queue.Receive(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, msg)
+}
@@ -5,8 +5,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"log/slog"
+	"math/rand/v2"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/go-http-utils/headers"
@@ -17,60 +20,197 @@ import (
 	"github.com/grantsy/grantsy/internal/infra/metrics"
 )
 
+// DeliveryRecorder persists webhook delivery attempts.
+type DeliveryRecorder interface {
+	InsertDelivery(ctx context.Context, d *Delivery) error
+}
+
+// Enqueuer queues webhook messages for delivery.
+type Enqueuer interface {
+	Enqueue(ctx context.Context, msg Message, delay time.Duration) error
+}
+
+// DeadLetterWriter stores messages that exhausted their retries.
+type DeadLetterWriter interface {
+	InsertDeadLetter(ctx context.Context, dl *DeadLetter) error
+	CountDeadLetters(ctx context.Context) (map[string]int, error)
+}
+
+// maxResponseSnippet is how much of an endpoint's response body is stored
+// with a delivery attempt.
+const maxResponseSnippet = 1024
+
 // Worker processes webhook jobs and sends them to endpoints
 type Worker struct {
-	endpoints []config.WebhookEndpoint
-	client    *http.Client
+	endpoints   EndpointLister
+	deliveries  DeliveryRecorder
+	retries     Enqueuer
+	deadLetters DeadLetterWriter
+	client      *http.Client
 }
 
 // NewWorker creates a new webhook worker
-func NewWorker(endpoints []config.WebhookEndpoint) *Worker {
+func NewWorker(
+	endpoints EndpointLister,
+	deliveries DeliveryRecorder,
+	retries Enqueuer,
+	deadLetters DeadLetterWriter,
+) *Worker {
 	return &Worker{
-		endpoints: endpoints,
-		client:    &http.Client{Timeout: 15 * time.Second},
+		endpoints:   endpoints,
+		deliveries:  deliveries,
+		retries:     retries,
+		deadLetters: deadLetters,
+		client:      &http.Client{Timeout: 15 * time.Second},
 	}
 }
 
-// Handle processes a webhook job from the queue
+// Handle processes a webhook job from the queue.
+// Failed deliveries are queued again with the endpoint's backoff rather than
+// returned as errors, so the queue's own redelivery only covers crashed jobs.
+// Messages out of attempts or past their max age go to the dead-letter queue.
 func (w *Worker) Handle(ctx context.Context, body []byte) error {
+	var msg Message
+	if err := json.Unmarshal(body, &msg); err != nil {
+		return fmt.Errorf("failed to unmarshal message: %w", err)
+	}
+	if msg.Payload == nil {
+		// Queued before messages carried their attempt count.
+		msg = Message{Payload: body, QueuedAt: time.Now().Unix()}
+	}
+
 	var payload Payload
-	if err := json.Unmarshal(body, &payload); err != nil {
+	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
 		return fmt.Errorf("failed to unmarshal payload: %w", err)
 	}
 
-	endpoint := w.findEndpoint(payload.Endpoint)
+	endpoint, err := w.findEndpoint(ctx, payload)
+	if err != nil {
+		return err
+	}
 	if endpoint == nil {
-		slog.Warn("endpoint not found in config, skipping", "url", payload.Endpoint)
+		slog.Warn("endpoint not found, skipping", "url", payload.Endpoint, "endpoint_id", payload.EndpointID)
 		return nil
 	}
 
-	return w.send(ctx, *endpoint, body)
+	err = w.send(ctx, *endpoint, payload, msg.Payload)
+	if err == nil {
+		return nil
+	}
+
+	msg.Attempt++
+	delay := backoff(endpoint.Retry, msg.Attempt)
+	expiresAt := time.Unix(msg.QueuedAt, 0).Add(endpoint.Retry.MaxAge)
+	if msg.Attempt >= endpoint.Retry.MaxAttempts || time.Now().Add(delay).After(expiresAt) {
+		return w.deadLetter(ctx, payload, msg, err)
+	}
+
+	slog.Warn("webhook delivery failed, retrying",
+		"url", endpoint.URL, "attempt", msg.Attempt, "delay", delay, "error", err)
+	return w.retries.Enqueue(ctx, msg, delay)
 }
 
-func (w *Worker) findEndpoint(url string) *config.WebhookEndpoint {
-	for _, ep := range w.endpoints {
-		if ep.URL == url {
-			return &ep
-		}
+// deadLetter moves a message that will not be retried to the dead-letter queue.
+func (w *Worker) deadLetter(ctx context.Context, payload Payload, msg Message, lastErr error) error {
+	dl := &DeadLetter{
+		ID:            uuid.Must(uuid.NewV7()).String(),
+		Endpoint:      payload.Endpoint,
+		EventType:     string(payload.Type),
+		UserID:        payload.UserID,
+		Payload:       msg.Payload,
+		Attempts:      msg.Attempt,
+		LastError:     lastErr.Error(),
+		FirstQueuedAt: msg.QueuedAt,
+		CreatedAt:     time.Now().Unix(),
 	}
+	if err := w.deadLetters.InsertDeadLetter(ctx, dl); err != nil {
+		return err
+	}
+
+	slog.Error("webhook delivery exhausted retries, moved to dead-letter queue",
+		"url", payload.Endpoint, "attempts", msg.Attempt, "dead_letter_id", dl.ID, "error", lastErr)
+	metrics.RecordWebhookDeadLettered(payload.Endpoint)
+	UpdateDeadLetterDepth(ctx, w.deadLetters)
 	return nil
 }
 
+// backoff returns the delay before the given retry attempt (1 for the first
+// retry): InitialInterval doubled per attempt and capped at MaxInterval,
+// randomized to between half and the full value.
+func backoff(retry config.WebhookRetry, attempt int) time.Duration {
+	delay := retry.InitialInterval
+	for i := 1; i < attempt && delay < retry.MaxInterval; i++ {
+		delay *= 2
+	}
+	delay = min(delay, retry.MaxInterval)
+	half := delay / 2
+	return half + rand.N(delay-half+1)
+}
+
+// findEndpoint returns the endpoint the payload was queued for. Stored
+// endpoints are matched by ID, so pending retries follow a URL change.
+// Endpoints from the config file, and messages queued before payloads
+// carried the ID, are matched by URL.
+func (w *Worker) findEndpoint(ctx context.Context, payload Payload) (*Endpoint, error) {
+	endpoints, err := w.endpoints.AllEndpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, ep := range endpoints {
+		if payload.EndpointID != "" && ep.ID == payload.EndpointID ||
+			payload.EndpointID == "" && ep.URL == payload.Endpoint {
+			return &ep, nil
+		}
+	}
+	return nil, nil
+}
+
+// send delivers the webhook and records the attempt.
 func (w *Worker) send(
 	ctx context.Context,
-	endpoint config.WebhookEndpoint,
+	endpoint Endpoint,
+	payload Payload,
 	body []byte,
 ) error {
-	wh, err := standardwebhooks.NewWebhookRaw([]byte(endpoint.Secret))
+	delivery := &Delivery{
+		ID:        uuid.Must(uuid.NewV7()).String(),
+		Endpoint:  endpoint.URL,
+		EventType: string(payload.Type),
+		UserID:    payload.UserID,
+		Payload:   body,
+		CreatedAt: time.Now().Unix(),
+	}
+
+	err := w.deliver(ctx, endpoint, payload, body, delivery)
 	if err != nil {
-		return fmt.Errorf("failed to create webhook signer: %w", err)
+		delivery.Error = err.Error()
+	}
+
+	if recErr := w.deliveries.InsertDelivery(ctx, delivery); recErr != nil {
+		slog.Error("failed to record webhook delivery", "error", recErr, "url", endpoint.URL)
 	}
 
-	msgID := uuid.New().String()
+	return err
+}
+
+// deliver signs and posts the body, filling in the response details of delivery.
+func (w *Worker) deliver(
+	ctx context.Context,
+	endpoint Endpoint,
+	payload Payload,
+	body []byte,
+	delivery *Delivery,
+) error {
+	msgID := payload.ID
+	if msgID == "" {
+		// Queued before payloads carried a message ID.
+		msgID = uuid.New().String()
+	}
 	ts := time.Now()
-	signature, err := wh.Sign(msgID, ts, body)
+
+	signature, err := sign(endpoint.Secrets(ts), endpoint.ID != "", msgID, ts, body)
 	if err != nil {
-		return fmt.Errorf("failed to sign webhook: %w", err)
+		return err
 	}
 
 	req, err := http.NewRequestWithContext(
@@ -91,6 +231,7 @@ func (w *Worker) send(
 	start := time.Now()
 	resp, err := w.client.Do(req)
 	duration := time.Since(start)
+	delivery.DurationMs = duration.Milliseconds()
 
 	if err != nil {
 		metrics.RecordWebhookDelivery(endpoint.URL, false, duration)
@@ -98,7 +239,12 @@ func (w *Worker) send(
 	}
 	defer resp.Body.Close()
 
+	snippet, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseSnippet))
+	delivery.StatusCode = resp.StatusCode
+	delivery.Response = string(snippet)
+
 	success := resp.StatusCode >= 200 && resp.StatusCode < 300
+	delivery.Success = success
 	metrics.RecordWebhookDelivery(endpoint.URL, success, duration)
 
 	if success {
@@ -114,3 +260,31 @@ func (w *Worker) send(
 
 	return fmt.Errorf("webhook failed: status %d", resp.StatusCode)
 }
+
+// sign returns the webhook-signature header value: one signature per secret,
+// space separated, so consumers can verify with either secret during a
+// rollover. Secrets generated for stored endpoints are base64-decoded as the
+// Standard Webhooks spec defines. Secrets from the config file are used as raw
+// key bytes, even with a "whsec_" prefix, so consumers verifying them that way
+// keep working.
+func sign(secrets []string, generated bool, msgID string, ts time.Time, body []byte) (string, error) {
+	signatures := make([]string, 0, len(secrets))
+	for _, secret := range secrets {
+		var wh *standardwebhooks.Webhook
+		var err error
+		if generated && strings.HasPrefix(secret, secretPrefix) {
+			wh, err = standardwebhooks.NewWebhook(secret)
+		} else {
+			wh, err = standardwebhooks.NewWebhookRaw([]byte(secret))
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to create webhook signer: %w", err)
+		}
+		signature, err := wh.Sign(msgID, ts, body)
+		if err != nil {
+			return "", fmt.Errorf("failed to sign webhook: %w", err)
+		}
+		signatures = append(signatures, signature)
+	}
+	return strings.Join(signatures, " "), nil
+}
@@ -0,0 +1,246 @@
+package webhooks_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	standardwebhooks "github.com/standard-webhooks/standard-webhooks/libraries/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grantsy/grantsy/internal/infra/config"
+	"github.com/grantsy/grantsy/internal/webhooks"
+	"github.com/grantsy/grantsy/internal/webhooks/mocks"
+)
+
+type workerMocks struct {
+	deliveries  *mocks.MockDeliveryRecorder
+	retries     *mocks.MockEnqueuer
+	deadLetters *mocks.MockDeadLetterWriter
+}
+
+func newTestWorker(t *testing.T, status int) (*webhooks.Worker, *workerMocks, string) {
+	t.Helper()
+	return newTestWorkerWithHandler(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(status)
+	})
+}
+
+func newTestWorkerWithHandler(
+	t *testing.T,
+	handler http.HandlerFunc,
+) (*webhooks.Worker, *workerMocks, string) {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	m := &workerMocks{
+		deliveries:  mocks.NewMockDeliveryRecorder(t),
+		retries:     mocks.NewMockEnqueuer(t),
+		deadLetters: mocks.NewMockDeadLetterWriter(t),
+	}
+	m.deliveries.EXPECT().InsertDelivery(mock.Anything, mock.Anything).Return(nil)
+
+	return newTestWorkerForEndpoint(t, m, webhooks.Endpoint{
+		URL:    srv.URL,
+		Secret: "c2VjcmV0",
+		Retry: config.WebhookRetry{
+			MaxAttempts:     3,
+			InitialInterval: 30 * time.Second,
+			MaxInterval:     time.Minute,
+			MaxAge:          time.Hour,
+		},
+	}), m, srv.URL
+}
+
+func newTestWorkerForEndpoint(t *testing.T, m *workerMocks, endpoint webhooks.Endpoint) *webhooks.Worker {
+	t.Helper()
+	endpoints := mocks.NewMockEndpointLister(t)
+	endpoints.EXPECT().AllEndpoints(mock.Anything).Return([]webhooks.Endpoint{endpoint}, nil)
+	return webhooks.NewWorker(endpoints, m.deliveries, m.retries, m.deadLetters)
+}
+
+func testMessage(t *testing.T, endpoint string, attempt int, queuedAt time.Time) []byte {
+	t.Helper()
+	payload, err := json.Marshal(webhooks.Payload{
+		ID:       "msg-1",
+		Sequence: 7,
+		Type:     webhooks.EventUserPlanChanged,
+		Endpoint: endpoint,
+		UserID:   "user-1",
+	})
+	require.NoError(t, err)
+	body, err := json.Marshal(webhooks.Message{Payload: payload, Attempt: attempt, QueuedAt: queuedAt.Unix()})
+	require.NoError(t, err)
+	return body
+}
+
+func TestWorker_Handle_Success(t *testing.T) {
+	worker, _, url := newTestWorker(t, http.StatusOK)
+
+	err := worker.Handle(t.Context(), testMessage(t, url, 0, time.Now()))
+
+	assert.NoError(t, err)
+}
+
+func TestWorker_Handle_SchedulesRetryWithBackoff(t *testing.T) {
+	worker, m, url := newTestWorker(t, http.StatusInternalServerError)
+
+	var delay time.Duration
+	var retried webhooks.Message
+	m.retries.EXPECT().Enqueue(mock.Anything, mock.Anything, mock.Anything).
+		Run(func(_ context.Context, msg webhooks.Message, d time.Duration) {
+			retried, delay = msg, d
+		}).
+		Return(nil)
+
+	err := worker.Handle(t.Context(), testMessage(t, url, 1, time.Now()))
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, retried.Attempt)
+	// Second retry: 30s doubled, with jitter between half and the full value.
+	assert.GreaterOrEqual(t, delay, 30*time.Second)
+	assert.LessOrEqual(t, delay, time.Minute)
+}
+
+func TestWorker_Handle_DeadLettersAfterMaxAttempts(t *testing.T) {
+	worker, m, url := newTestWorker(t, http.StatusInternalServerError)
+
+	m.deadLetters.EXPECT().
+		InsertDeadLetter(mock.Anything, mock.MatchedBy(func(dl *webhooks.DeadLetter) bool {
+			return dl.Endpoint == url && dl.Attempts == 3 && dl.UserID == "user-1" &&
+				dl.LastError == "webhook failed: status 500"
+		})).
+		Return(nil)
+	m.deadLetters.EXPECT().CountDeadLetters(mock.Anything).Return(map[string]int{url: 1}, nil)
+
+	err := worker.Handle(t.Context(), testMessage(t, url, 2, time.Now()))
+
+	assert.NoError(t, err)
+}
+
+func TestWorker_Handle_DeadLettersPastMaxAge(t *testing.T) {
+	worker, m, url := newTestWorker(t, http.StatusInternalServerError)
+
+	m.deadLetters.EXPECT().InsertDeadLetter(mock.Anything, mock.Anything).Return(nil)
+	m.deadLetters.EXPECT().CountDeadLetters(mock.Anything).Return(map[string]int{url: 1}, nil)
+
+	err := worker.Handle(t.Context(), testMessage(t, url, 0, time.Now().Add(-time.Hour)))
+
+	assert.NoError(t, err)
+}
+
+func TestWorker_Handle_LegacyPayload(t *testing.T) {
+	worker, _, url := newTestWorker(t, http.StatusOK)
+	body, err := json.Marshal(webhooks.Payload{Type: webhooks.EventUserPlanChanged, Endpoint: url})
+	require.NoError(t, err)
+
+	assert.NoError(t, worker.Handle(t.Context(), body))
+}
+
+func TestWorker_Handle_KeepsMessageIDAcrossRetries(t *testing.T) {
+	var ids []string
+	worker, m, url := newTestWorkerWithHandler(t, func(w http.ResponseWriter, r *http.Request) {
+		ids = append(ids, r.Header.Get("webhook-id"))
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	m.retries.EXPECT().Enqueue(mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	require.NoError(t, worker.Handle(t.Context(), testMessage(t, url, 0, time.Now())))
+	require.NoError(t, worker.Handle(t.Context(), testMessage(t, url, 1, time.Now())))
+
+	assert.Equal(t, []string{"msg-1", "msg-1"}, ids)
+}
+
+func TestWorker_Handle_SignsWithBothSecretsDuringRollover(t *testing.T) {
+	newSecret, err := webhooks.GenerateSecret()
+	require.NoError(t, err)
+	oldSecret, err := webhooks.GenerateSecret()
+	require.NoError(t, err)
+
+	var header http.Header
+	var body []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Clone()
+		body, _ = io.ReadAll(r.Body)
+	}))
+	t.Cleanup(srv.Close)
+
+	m := &workerMocks{deliveries: mocks.NewMockDeliveryRecorder(t)}
+	m.deliveries.EXPECT().InsertDelivery(mock.Anything, mock.Anything).Return(nil)
+	worker := newTestWorkerForEndpoint(t, m, webhooks.Endpoint{
+		ID:                  "ep-1",
+		URL:                 srv.URL,
+		Secret:              newSecret,
+		PrevSecret:          oldSecret,
+		PrevSecretExpiresAt: time.Now().Add(time.Hour).Unix(),
+		Retry:               config.DefaultWebhookRetry,
+	})
+
+	require.NoError(t, worker.Handle(t.Context(), testMessage(t, srv.URL, 0, time.Now())))
+
+	for _, secret := range []string{newSecret, oldSecret} {
+		wh, err := standardwebhooks.NewWebhook(secret)
+		require.NoError(t, err)
+		assert.NoError(t, wh.Verify(body, header))
+	}
+}
+
+func TestWorker_Handle_SignsConfiguredSecretsAsRawBytes(t *testing.T) {
+	const secret = "whsec_configured"
+
+	var header http.Header
+	var body []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Clone()
+		body, _ = io.ReadAll(r.Body)
+	}))
+	t.Cleanup(srv.Close)
+
+	m := &workerMocks{deliveries: mocks.NewMockDeliveryRecorder(t)}
+	m.deliveries.EXPECT().InsertDelivery(mock.Anything, mock.Anything).Return(nil)
+	worker := newTestWorkerForEndpoint(t, m, webhooks.Endpoint{
+		URL:    srv.URL,
+		Secret: secret,
+		Retry:  config.DefaultWebhookRetry,
+	})
+
+	require.NoError(t, worker.Handle(t.Context(), testMessage(t, srv.URL, 0, time.Now())))
+
+	wh, err := standardwebhooks.NewWebhookRaw([]byte(secret))
+	require.NoError(t, err)
+	assert.NoError(t, wh.Verify(body, header))
+}
+
+func TestWorker_Handle_FollowsStoredEndpointURLChange(t *testing.T) {
+	var hits int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		hits++
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	m := &workerMocks{deliveries: mocks.NewMockDeliveryRecorder(t)}
+	m.deliveries.EXPECT().InsertDelivery(mock.Anything, mock.Anything).Return(nil)
+	worker := newTestWorkerForEndpoint(t, m, webhooks.Endpoint{ID: "ep-1", URL: srv.URL, Secret: "c2VjcmV0"})
+
+	// Queued while the endpoint still had its old URL.
+	payload, err := json.Marshal(webhooks.Payload{
+		ID:         "msg-1",
+		Type:       webhooks.EventUserPlanChanged,
+		Endpoint:   "https://old.example.com/hook",
+		EndpointID: "ep-1",
+	})
+	require.NoError(t, err)
+	body, err := json.Marshal(webhooks.Message{Payload: payload, QueuedAt: time.Now().Unix()})
+	require.NoError(t, err)
+
+	require.NoError(t, worker.Handle(t.Context(), body))
+	assert.Equal(t, 1, hits)
+}